@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"image/color"
 	"io/ioutil"
+	"math"
+	"reflect"
 	"strings"
 
 	"github.com/fogleman/gg"
 	"github.com/golang/freetype/truetype"
 	"github.com/maxsid/goCeilings/drawing"
 	"github.com/maxsid/goCeilings/drawing/naming"
+	"github.com/maxsid/goCeilings/estimate"
 	"github.com/maxsid/goCeilings/figure"
 	"github.com/maxsid/goCeilings/value"
 	"golang.org/x/image/colornames"
@@ -28,13 +33,33 @@ const (
 	marginHorizontal, marginVertical                             = marginLeft + marginRight, marginTop + marginDown
 	pointSize                                            float64 = 3
 	marginLetterX, marginLetterY                         float64 = 4, 20
+	seamDash                                             float64 = 12
+	fixtureSize, dimensionDash                           float64 = 10, 6
+	fixtureWalls                                                 = 2
+	levelDash                                            float64 = 8
+	levelFillAlpha                                               = 0x50
+	angleArcRadius, angleTitleDistance                   float64 = 25, 45
+	offsetDash                                           float64 = 4
 )
 
+// levelsColors are colors of levels of a multi-level ceiling in the order of nesting.
+var levelsColors = []color.RGBA{colornames.Skyblue, colornames.Yellowgreen, colornames.Orchid, colornames.Goldenrod,
+	colornames.Coral}
+
 type GGDrawing struct {
 	figure.Polygon
 	Description      *drawing.Description  `json:"description"`
 	Measures         *value.FigureMeasures `json:"measures"`
+	Closure          *figure.Closure       `json:"closure,omitempty"`
+	Holes            []*figure.Hole        `json:"holes,omitempty"`
+	Rolls            *figure.Rolls         `json:"rolls,omitempty"`
+	Fixtures         []*figure.Fixture     `json:"fixtures,omitempty"`
+	Tiles            *figure.Tiles         `json:"tiles,omitempty"`
+	Levels           []*figure.Level       `json:"levels,omitempty"`
 	offsetX, offsetY float64
+	showAngles       bool
+	offsetOutline    *figure.Polygon
+	showTriangles    bool
 }
 
 func NewEmptyGGDrawing() *GGDrawing {
@@ -64,8 +89,28 @@ func (d *GGDrawing) Draw(drawDesc bool) ([]byte, error) {
 	imageWidth, imageHeight := calcImageSize(scale, d.Polygon.Width(), d.Polygon.Height(), drawDesc)
 	ggCtx := gg.NewContext(imageWidth, imageHeight)
 	setBackground(ggCtx)
+	layout, err := d.layout()
+	if err != nil {
+		return nil, err
+	}
+	tileLayout, err := d.tileLayout()
+	if err != nil {
+		return nil, err
+	}
+	d.drawLevels(ggCtx, scale)
+	triangles, err := d.triangulation()
+	if err != nil {
+		return nil, err
+	}
+	d.drawTiles(ggCtx, scale, tileLayout)
+	d.drawTriangles(ggCtx, scale, triangles)
 	d.drawLines(ggCtx, scale)
+	d.drawOffset(ggCtx, scale)
+	d.drawHoles(ggCtx, scale)
+	d.drawSeams(ggCtx, scale, layout)
+	d.drawFixtures(ggCtx, scale)
 	d.drawPoints(ggCtx, scale)
+	d.drawAngles(ggCtx, scale)
 	if err := setFontSize(ggCtx, fontSizeSideTitle); err != nil {
 		return nil, err
 	}
@@ -74,10 +119,20 @@ func (d *GGDrawing) Draw(drawDesc bool) ([]byte, error) {
 		return nil, err
 	}
 	d.drawLinesTitles(ggCtx, imageHeight, scale)
+	d.drawHolesTitles(ggCtx, imageHeight, scale)
+	d.drawLevelsTitles(ggCtx, imageHeight, scale)
+	d.drawAnglesTitles(ggCtx, imageHeight, scale)
+	d.drawFixturesTitles(ggCtx, imageHeight, scale)
 	if drawDesc {
 		desc := drawing.NewDescription()
 		d.addPolygonInfoToDescription(desc)
 		d.addSidesToDescription(desc)
+		d.addDiagonalsToDescription(desc)
+		d.addHolesToDescription(desc)
+		d.addLevelsToDescription(desc)
+		d.addLayoutToDescription(desc, layout)
+		d.addTileLayoutToDescription(desc, tileLayout)
+		d.addFixturesToDescription(desc)
 		d.addPointsToDescription(desc)
 		if err := setFontSize(ggCtx, fontSizeNotes); err != nil {
 			return nil, err
@@ -95,6 +150,32 @@ func (d *GGDrawing) GetDrawer() drawing.Drawer {
 	return d
 }
 
+// ShowAngles turns on or off drawing arcs of interior angles with their values at vertexes of the drawing.
+func (d *GGDrawing) ShowAngles(show bool) {
+	d.showAngles = show
+}
+
+// ShowTriangles turns on or off drawing the triangulation of the drawing by thin lines.
+func (d *GGDrawing) ShowTriangles(show bool) {
+	d.showTriangles = show
+}
+
+// ShowOffset turns on drawing the offset outline with the distance in the drawing measure, e.g. the line of
+// the mounting profile, and its perimeter in the description. Nil turns it off.
+func (d *GGDrawing) ShowOffset(o *figure.Offset) error {
+	if o == nil {
+		d.offsetOutline = nil
+		return nil
+	}
+	o.ConvertToOne(d.Measures)
+	outline, err := d.Polygon.Offset(o)
+	if err != nil {
+		return err
+	}
+	d.offsetOutline = outline
+	return nil
+}
+
 func (d *GGDrawing) updateOffset(scale float64) {
 	left, _ := d.Polygon.LeftPoint()
 	low, _ := d.Polygon.LowPoint()
@@ -118,7 +199,156 @@ func (d *GGDrawing) drawLines(ggCtx *gg.Context, scale float64) {
 	pol := figure.Polygon{Points: d.Points}
 	ggCtx.SetColor(colornames.Red)
 	ggCtx.SetLineWidth(lineWidth)
-	for _, s := range pol.Sides() {
+	for i, s := range pol.Sides() {
+		if cs, _ := pol.CurvedSide(i); cs != nil {
+			x, y := getXYOnDrawing(cs.Center, d.offsetX, d.offsetY, scale)
+			ggCtx.DrawArc(x, y, cs.Radius*scale, cs.Start, cs.Start+cs.Sweep)
+			ggCtx.Stroke()
+			continue
+		}
+		x1, y1 := getXYOnDrawing(s.A, d.offsetX, d.offsetY, scale)
+		x2, y2 := getXYOnDrawing(s.B, d.offsetX, d.offsetY, scale)
+		ggCtx.DrawLine(x1, y1, x2, y2)
+		ggCtx.Stroke()
+	}
+}
+
+// drawTriangles draws sides of triangles by thin lines.
+func (d *GGDrawing) drawTriangles(ggCtx *gg.Context, scale float64, triangles [][3]int) {
+	ggCtx.InvertY()
+	defer ggCtx.InvertY()
+	ggCtx.SetColor(colornames.Gray)
+	ggCtx.SetLineWidth(1)
+	for _, t := range triangles {
+		for k := range t {
+			x1, y1 := getXYOnDrawing(d.Points[t[k]], d.offsetX, d.offsetY, scale)
+			x2, y2 := getXYOnDrawing(d.Points[t[(k+1)%3]], d.offsetX, d.offsetY, scale)
+			ggCtx.DrawLine(x1, y1, x2, y2)
+			ggCtx.Stroke()
+		}
+	}
+}
+
+// drawOffset draws the offset outline by the dashed line.
+func (d *GGDrawing) drawOffset(ggCtx *gg.Context, scale float64) {
+	if d.offsetOutline == nil {
+		return
+	}
+	ggCtx.InvertY()
+	defer ggCtx.InvertY()
+	ggCtx.SetColor(colornames.Darkblue)
+	ggCtx.SetLineWidth(1)
+	ggCtx.SetDash(offsetDash, offsetDash)
+	defer ggCtx.SetDash()
+	pol := d.offsetOutline
+	for i, s := range pol.Sides() {
+		if cs, _ := pol.CurvedSide(i); cs != nil {
+			x, y := getXYOnDrawing(cs.Center, d.offsetX, d.offsetY, scale)
+			ggCtx.DrawArc(x, y, cs.Radius*scale, cs.Start, cs.Start+cs.Sweep)
+			ggCtx.Stroke()
+			continue
+		}
+		x1, y1 := getXYOnDrawing(s.A, d.offsetX, d.offsetY, scale)
+		x2, y2 := getXYOnDrawing(s.B, d.offsetX, d.offsetY, scale)
+		ggCtx.DrawLine(x1, y1, x2, y2)
+		ggCtx.Stroke()
+	}
+}
+
+// drawLevels fills levels of the ceiling by their colors and draws their borders by dashed lines.
+// Surrounding levels are drawn before nested ones.
+func (d *GGDrawing) drawLevels(ggCtx *gg.Context, scale float64) {
+	ggCtx.InvertY()
+	defer ggCtx.InvertY()
+	ggCtx.SetLineWidth(lineWidth)
+	defer ggCtx.SetDash()
+	for i, l := range d.levelsByNesting() {
+		pol, c := l.Polygon(), levelsColors[i%len(levelsColors)]
+		for j, s := range pol.Sides() {
+			if cs, _ := pol.CurvedSide(j); cs != nil {
+				x, y := getXYOnDrawing(cs.Center, d.offsetX, d.offsetY, scale)
+				ggCtx.DrawArc(x, y, cs.Radius*scale, cs.Start, cs.Start+cs.Sweep)
+				continue
+			}
+			if j == 0 {
+				ggCtx.MoveTo(getXYOnDrawing(s.A, d.offsetX, d.offsetY, scale))
+			}
+			ggCtx.LineTo(getXYOnDrawing(s.B, d.offsetX, d.offsetY, scale))
+		}
+		ggCtx.ClosePath()
+		ggCtx.SetColor(color.NRGBA{R: c.R, G: c.G, B: c.B, A: levelFillAlpha})
+		ggCtx.FillPreserve()
+		ggCtx.SetColor(c)
+		ggCtx.SetDash(levelDash, levelDash)
+		ggCtx.Stroke()
+		ggCtx.SetDash()
+	}
+}
+
+// levelsByNesting returns levels in the order of nesting, surrounding levels go before nested ones.
+func (d *GGDrawing) levelsByNesting() []*figure.Level {
+	byName := make(map[string]*figure.Level)
+	for _, l := range d.Levels {
+		byName[l.Name] = l
+	}
+	out := make([]*figure.Level, 0, len(d.Levels))
+	for _, s := range d.Polygon.LevelsSummary(d.Levels...)[1:] {
+		out = append(out, byName[s.Name])
+	}
+	return out
+}
+
+func (d *GGDrawing) drawLevelsTitles(ggCtx *gg.Context, imageHeight int, scale float64) {
+	for i, l := range d.levelsByNesting() {
+		c := levelsColors[i%len(levelsColors)]
+		title := fmt.Sprintf("%s (%v)", l.Name, value.ConvertFromOneRound(d.Measures.Length, l.Drop, numbersPrecision))
+		w, h := ggCtx.MeasureString(title)
+		x, y := getXYOnDrawing(l.LabelPoint(), d.offsetX, d.offsetY, scale)
+		ggCtx.SetColor(color.RGBA{R: c.R / 2, G: c.G / 2, B: c.B / 2, A: c.A})
+		ggCtx.DrawString(title, x-w/2, float64(imageHeight)-(y-h/2))
+		ggCtx.Stroke()
+	}
+}
+
+func (d *GGDrawing) drawHoles(ggCtx *gg.Context, scale float64) {
+	ggCtx.InvertY()
+	defer ggCtx.InvertY()
+	ggCtx.SetColor(colornames.Blue)
+	ggCtx.SetLineWidth(lineWidth)
+	for _, h := range d.Holes {
+		if h.IsCircle() {
+			x, y := getXYOnDrawing(h.Center, d.offsetX, d.offsetY, scale)
+			ggCtx.DrawCircle(x, y, h.Radius*scale)
+			ggCtx.Stroke()
+			continue
+		}
+		pol := h.Polygon()
+		for i, s := range pol.Sides() {
+			if cs, _ := pol.CurvedSide(i); cs != nil {
+				x, y := getXYOnDrawing(cs.Center, d.offsetX, d.offsetY, scale)
+				ggCtx.DrawArc(x, y, cs.Radius*scale, cs.Start, cs.Start+cs.Sweep)
+			} else {
+				x1, y1 := getXYOnDrawing(s.A, d.offsetX, d.offsetY, scale)
+				x2, y2 := getXYOnDrawing(s.B, d.offsetX, d.offsetY, scale)
+				ggCtx.DrawLine(x1, y1, x2, y2)
+			}
+			ggCtx.Stroke()
+		}
+	}
+}
+
+// drawSeams draws seams of the layout by dashed lines.
+func (d *GGDrawing) drawSeams(ggCtx *gg.Context, scale float64, layout *figure.Layout) {
+	if layout == nil {
+		return
+	}
+	ggCtx.InvertY()
+	defer ggCtx.InvertY()
+	ggCtx.SetColor(colornames.Green)
+	ggCtx.SetLineWidth(lineWidth)
+	ggCtx.SetDash(seamDash, seamDash)
+	defer ggCtx.SetDash()
+	for _, s := range layout.Seams {
 		x1, y1 := getXYOnDrawing(s.A, d.offsetX, d.offsetY, scale)
 		x2, y2 := getXYOnDrawing(s.B, d.offsetX, d.offsetY, scale)
 		ggCtx.DrawLine(x1, y1, x2, y2)
@@ -126,13 +356,149 @@ func (d *GGDrawing) drawLines(ggCtx *gg.Context, scale float64) {
 	}
 }
 
+// drawTiles draws main runners and cross tees of the suspended ceiling grid.
+func (d *GGDrawing) drawTiles(ggCtx *gg.Context, scale float64, layout *figure.TileLayout) {
+	if layout == nil {
+		return
+	}
+	ggCtx.InvertY()
+	defer ggCtx.InvertY()
+	for _, lines := range []struct {
+		segments []*figure.Segment
+		color    color.Color
+		width    float64
+	}{{layout.Tees, colornames.Lightsteelblue, lineWidth / 2}, {layout.Runners, colornames.Steelblue, lineWidth}} {
+		ggCtx.SetColor(lines.color)
+		ggCtx.SetLineWidth(lines.width)
+		for _, s := range lines.segments {
+			x1, y1 := getXYOnDrawing(s.A, d.offsetX, d.offsetY, scale)
+			x2, y2 := getXYOnDrawing(s.B, d.offsetX, d.offsetY, scale)
+			ggCtx.DrawLine(x1, y1, x2, y2)
+			ggCtx.Stroke()
+		}
+	}
+}
+
+// drawFixtures draws symbols of fixtures and dimension lines to their nearest walls.
+func (d *GGDrawing) drawFixtures(ggCtx *gg.Context, scale float64) {
+	ggCtx.InvertY()
+	defer ggCtx.InvertY()
+	for _, f := range d.Fixtures {
+		x, y := getXYOnDrawing(f.Center(), d.offsetX, d.offsetY, scale)
+		ggCtx.SetColor(colornames.Gray)
+		ggCtx.SetLineWidth(1)
+		ggCtx.SetDash(dimensionDash, dimensionDash)
+		for _, w := range d.Polygon.NearestWalls(f.Center(), fixtureWalls) {
+			fx, fy := getXYOnDrawing(w.Foot, d.offsetX, d.offsetY, scale)
+			ggCtx.DrawLine(x, y, fx, fy)
+			ggCtx.Stroke()
+		}
+		ggCtx.SetDash()
+		ggCtx.SetColor(colornames.Darkorange)
+		ggCtx.SetLineWidth(lineWidth)
+		drawFixtureSymbol(ggCtx, f.Type, x, y, math.Max(f.Diameter*scale/2, fixtureSize))
+	}
+}
+
+// drawFixtureSymbol draws the symbol of the fixture type with the radius r: a crossed circle for a light,
+// a double circle with a cross for a chandelier, a filled circle for a pipe and a circle with blades for a fan.
+func drawFixtureSymbol(ggCtx *gg.Context, t figure.FixtureType, x, y, r float64) {
+	ggCtx.DrawCircle(x, y, r)
+	switch t {
+	case figure.FixturePipe:
+		ggCtx.Fill()
+		return
+	case figure.FixtureLight:
+		d := r / math.Sqrt2
+		ggCtx.DrawLine(x-d, y-d, x+d, y+d)
+		ggCtx.DrawLine(x-d, y+d, x+d, y-d)
+	case figure.FixtureChandelier:
+		ggCtx.DrawCircle(x, y, r/2)
+		ggCtx.DrawLine(x-r, y, x+r, y)
+		ggCtx.DrawLine(x, y-r, x, y+r)
+	case figure.FixtureFan:
+		for i := 0; i < 3; i++ {
+			sin, cos := math.Sincos(math.Pi * 2 / 3 * float64(i))
+			ggCtx.DrawLine(x, y, x+r*cos, y+r*sin)
+		}
+	}
+	ggCtx.Stroke()
+}
+
+// drawAngles draws arcs of interior angles at vertexes, which aren't on the straight line.
+func (d *GGDrawing) drawAngles(ggCtx *gg.Context, scale float64) {
+	if !d.showAngles {
+		return
+	}
+	ggCtx.InvertY()
+	defer ggCtx.InvertY()
+	ggCtx.SetColor(colornames.Darkgreen)
+	ggCtx.SetLineWidth(1)
+	for i, c := range d.Polygon.Corners() {
+		if c.Type == figure.CornerStraight {
+			continue
+		}
+		x, y := getXYOnDrawing(d.Points[i], d.offsetX, d.offsetY, scale)
+		ggCtx.NewSubPath()
+		ggCtx.DrawArc(x, y, angleArcRadius, c.Bisector-c.Angle/2, c.Bisector+c.Angle/2)
+		ggCtx.Stroke()
+	}
+}
+
+// drawAnglesTitles draws values of interior angles in the drawing measure by bisectors of angles.
+func (d *GGDrawing) drawAnglesTitles(ggCtx *gg.Context, imageHeight int, scale float64) {
+	if !d.showAngles {
+		return
+	}
+	ggCtx.SetColor(colornames.Darkgreen)
+	for i, c := range d.GetCorners() {
+		if c.Type == figure.CornerStraight {
+			continue
+		}
+		title := fmt.Sprint(c.Angle)
+		w, h := ggCtx.MeasureString(title)
+		x, y := getXYOnDrawing(d.Points[i], d.offsetX, d.offsetY, scale)
+		x, y = x+angleTitleDistance*math.Cos(c.Bisector), y+angleTitleDistance*math.Sin(c.Bisector)
+		ggCtx.DrawString(title, x-w/2, float64(imageHeight)-(y-h/2))
+	}
+}
+
+// drawFixturesTitles draws distances from fixtures to their nearest walls on dimension lines.
+func (d *GGDrawing) drawFixturesTitles(ggCtx *gg.Context, imageHeight int, scale float64) {
+	ggCtx.SetColor(colornames.Gray)
+	for _, f := range d.Fixtures {
+		for _, w := range d.Polygon.NearestWalls(f.Center(), fixtureWalls) {
+			title := fmt.Sprint(value.ConvertFromOneRound(d.Measures.Length, w.Distance, numbersPrecision))
+			tw, th := ggCtx.MeasureString(title)
+			x, y := getXYOnDrawing(&figure.Point{X: (f.X + w.Foot.X) / 2, Y: (f.Y + w.Foot.Y) / 2}, d.offsetX, d.offsetY, scale)
+			ggCtx.DrawString(title, x-tw/2, float64(imageHeight)-(y-th/2))
+		}
+	}
+}
+
+func (d *GGDrawing) drawHolesTitles(ggCtx *gg.Context, imageHeight int, scale float64) {
+	ggCtx.SetColor(colornames.Blue)
+	for i, h := range d.Holes {
+		title := holeName(i)
+		w, hgt := ggCtx.MeasureString(title)
+		x, y := getXYOnDrawing(h.LabelPoint(), d.offsetX, d.offsetY, scale)
+		ggCtx.DrawString(title, x-w/2, float64(imageHeight)-(y-hgt/2))
+		ggCtx.Stroke()
+	}
+}
+
 func (d *GGDrawing) drawLinesTitles(ggCtx *gg.Context, imageHeight int, scale float64) {
 	pol := d.Polygon
-	for _, l := range pol.Sides() {
-		dist := fmt.Sprint(value.ConvertFromOneRound(d.Measures.Length, l.Distance(), 2))
+	for i, l := range pol.Sides() {
+		dist := d.sideTitle(i, l)
 		w, h := ggCtx.MeasureString(dist)
 		x1, y1 := getXYOnDrawing(l.A, d.offsetX, d.offsetY, scale)
 		x2, y2 := getXYOnDrawing(l.B, d.offsetX, d.offsetY, scale)
+		if cs, _ := pol.CurvedSide(i); cs != nil {
+			// the title of the arc is placed on its middle
+			x1, y1 = getXYOnDrawing(cs.PointAt(0.5), d.offsetX, d.offsetY, scale)
+			x2, y2 = x1, y1
+		}
 		x, y := (x1+x2)/2-(w/2), float64(imageHeight)-((y1+y2)/2-(h/2))
 		ggCtx.SetColor(colornames.White)
 		ggCtx.DrawRectangle(x+2, y-h, w-2, h)
@@ -143,6 +509,17 @@ func (d *GGDrawing) drawLinesTitles(ggCtx *gg.Context, imageHeight int, scale fl
 	}
 }
 
+// sideTitle returns length of the side by index in the drawing measure.
+// Length of a curved side is the length of its arc and the radius is added.
+func (d *GGDrawing) sideTitle(i int, s *figure.Segment) string {
+	cs, _ := d.Polygon.CurvedSide(i)
+	if cs == nil {
+		return fmt.Sprint(value.ConvertFromOneRound(d.Measures.Length, s.Distance(), numbersPrecision))
+	}
+	return fmt.Sprintf("%v (R%v)", value.ConvertFromOneRound(d.Measures.Length, cs.Length(), numbersPrecision),
+		value.ConvertFromOneRound(d.Measures.Length, cs.Radius, numbersPrecision))
+}
+
 func (d *GGDrawing) drawPointsTitles(ggCtx *gg.Context, imageHeight int, scale float64) {
 	ni := naming.NewNameIterator('A', 'Z')
 	for _, p := range d.Points {
@@ -177,60 +554,1148 @@ func (d *GGDrawing) addSidesToDescription(desc *drawing.Description) {
 	sides, l1, l2 := pol.Sides(), ni.Next(), ni.Next()
 	ss := make([]string, len(sides))
 	for i, s := range sides {
-		dist := value.ConvertFromOneRound(d.Measures.Length, s.Distance(), 2)
-		ss[i] = fmt.Sprintf("%s%s=%v", l1, l2, dist)
+		ss[i] = fmt.Sprintf("%s%s=%v", l1, l2, d.sideTitle(i, s))
 		l1, l2 = l2, ni.Next()
 	}
 	desc.PushBack("Sides", strings.Join(ss, ", "))
 }
 
+func (d *GGDrawing) addDiagonalsToDescription(desc *drawing.Description) {
+	residuals := d.GetResiduals()
+	if len(residuals) == 0 {
+		return
+	}
+	names := d.pointsNames()
+	ds := make([]string, len(residuals))
+	for i, r := range residuals {
+		ds[i] = fmt.Sprintf("%s%s=%v (%+v)", names[r.A], names[r.B], r.Length, r.Residual)
+	}
+	desc.PushBack("Diagonals", strings.Join(ds, ", "))
+}
+
+func (d *GGDrawing) addHolesToDescription(desc *drawing.Description) {
+	if len(d.Holes) == 0 {
+		return
+	}
+	hs := make([]string, len(d.Holes))
+	for i, h := range d.Holes {
+		hs[i] = fmt.Sprintf("%s=%v (P=%v)", holeName(i), value.ConvertFromOneRound(d.Measures.Area, h.Area(), numbersPrecision),
+			value.ConvertFromOneRound(d.Measures.Perimeter, h.Perimeter(), numbersPrecision))
+	}
+	desc.PushBack("Holes", strings.Join(hs, ", "))
+	desc.PushBack("Holes area", fmt.Sprintf("%.2f", d.HolesArea()))
+	desc.PushBack("Holes perimeter", fmt.Sprintf("%.2f", d.HolesPerimeter()))
+}
+
+func (d *GGDrawing) addLayoutToDescription(desc *drawing.Description, layout *figure.Layout) {
+	if layout == nil {
+		return
+	}
+	ss := make([]string, len(layout.Strips))
+	for i, s := range layout.Strips {
+		ss[i] = fmt.Sprintf("%d=%vx%v (%v)", i+1,
+			value.ConvertFromOneRound(d.Measures.Length, s.RollWidth, numbersPrecision),
+			value.ConvertFromOneRound(d.Measures.Length, s.Length, numbersPrecision),
+			value.ConvertFromOneRound(d.Measures.Area, s.Material, numbersPrecision))
+	}
+	desc.PushBack("Strips", strings.Join(ss, ", "))
+	desc.PushBack("Seams", fmt.Sprintf("%d", len(layout.Strips)-1))
+	desc.PushBack("Material", fmt.Sprintf("%.2f", value.ConvertFromOne(d.Measures.Area, layout.Material)))
+	desc.PushBack("Waste", fmt.Sprintf("%.2f", value.ConvertFromOne(d.Measures.Area, layout.Waste)))
+}
+
+func (d *GGDrawing) addLevelsToDescription(desc *drawing.Description) {
+	if len(d.Levels) == 0 {
+		return
+	}
+	summaries := d.LevelsSummary()
+	ls, ts := make([]string, len(summaries)), make([]string, 0, len(summaries)-1)
+	for i, s := range summaries {
+		ls[i] = fmt.Sprintf("%s=%v (%v)", s.Name, s.Area, s.Drop)
+		if s.Parent != "" {
+			ts = append(ts, fmt.Sprintf("%s=%v (%v)", s.Name, s.Transition, s.TransitionArea))
+		}
+	}
+	desc.PushBack("Levels", strings.Join(ls, ", "))
+	desc.PushBack("Transitions", strings.Join(ts, ", "))
+}
+
+func (d *GGDrawing) addTileLayoutToDescription(desc *drawing.Description, layout *figure.TileLayout) {
+	if layout == nil {
+		return
+	}
+	desc.PushBack("Tile", fmt.Sprintf("%vx%v",
+		value.ConvertFromOneRound(d.Measures.Length, d.Tiles.Width, numbersPrecision),
+		value.ConvertFromOneRound(d.Measures.Length, d.Tiles.Length, numbersPrecision)))
+	desc.PushBack("Full tiles", fmt.Sprintf("%d", layout.FullTiles))
+	desc.PushBack("Cut tiles", fmt.Sprintf("%d", layout.CutTiles))
+	desc.PushBack("Main runners", fmt.Sprintf("%.2f", value.ConvertFromOne(d.Measures.Perimeter, layout.MainRunners)))
+	desc.PushBack("Cross tees", fmt.Sprintf("%.2f", value.ConvertFromOne(d.Measures.Perimeter, layout.CrossTees)))
+}
+
+func (d *GGDrawing) addFixturesToDescription(desc *drawing.Description) {
+	if len(d.Fixtures) == 0 {
+		return
+	}
+	counts, fs := d.FixturesCount(), make([]string, 0)
+	for _, t := range figure.FixtureTypes {
+		if counts[t] > 0 {
+			fs = append(fs, fmt.Sprintf("%s=%d", t, counts[t]))
+		}
+	}
+	desc.PushBack("Fixtures", strings.Join(fs, ", "))
+}
+
+// holeName returns the title of the hole by its index.
+func holeName(i int) string {
+	return fmt.Sprintf("H%d", i+1)
+}
+
+// pointsNames returns names of all points of the drawing.
+func (d *GGDrawing) pointsNames() []string {
+	ni := naming.NewNameIterator('A', 'Z')
+	names := make([]string, d.Len())
+	for i := range names {
+		names[i] = ni.Next()
+	}
+	return names
+}
+
 func (d *GGDrawing) addPolygonInfoToDescription(desc *drawing.Description) {
 	desc.PushBack("Area", fmt.Sprintf("%.2f", d.Area()))
 	desc.PushBack("Perimeter", fmt.Sprintf("%.2f", d.Perimeter()))
 	desc.PushBack("Width", fmt.Sprintf("%.2f", d.Width()))
 	desc.PushBack("Height", fmt.Sprintf("%.2f", d.Height()))
 	desc.PushBack("Points", fmt.Sprintf("%d", d.Len()))
+	inner, outer := d.Polygon.CornersCount()
+	desc.PushBack("Corners", fmt.Sprintf("inner=%d, outer=%d", inner, outer))
+	if c := d.GetClosure(); c != nil {
+		desc.PushBack("Misclosure", fmt.Sprintf("%v (1:%.0f, %s)", c.Linear, c.Ratio, c.Method))
+	}
+	if d.offsetOutline != nil {
+		perimeter := value.ConvertFromOneRound(d.Measures.Perimeter, d.offsetOutline.Perimeter(), numbersPrecision)
+		desc.PushBack("Offset perimeter", fmt.Sprintf("%.2f", perimeter))
+	}
 }
 
+// AddPoints adds points with the drawing measures to the end, the new outline is checked like in SetPoint.
 func (d *GGDrawing) AddPoints(points ...*figure.Point) error {
 	for _, p := range points {
-		if p.Calculator == nil {
-			p.X, p.Y = value.ConvertToOne(d.Measures.Length, p.X), value.ConvertToOne(d.Measures.Length, p.Y)
-		} else {
-			p.Calculator.ConvertToOne(d.Measures)
-		}
+		d.convertPointToOne(p)
+	}
+	return d.changeOutline(func() error { return d.Polygon.AddPoints(points...) })
+}
+
+// convertPointToOne converts coordinates or the calculator and the arc of the point from the drawing measures.
+func (d *GGDrawing) convertPointToOne(p *figure.Point) {
+	if p.Calculator == nil {
+		p.X, p.Y = value.ConvertToOne(d.Measures.Length, p.X), value.ConvertToOne(d.Measures.Length, p.Y)
+	} else {
+		p.Calculator.ConvertToOne(d.Measures)
+	}
+	if p.Arc != nil {
+		p.Arc.ConvertToOne(d.Measures)
 	}
-	return d.Polygon.AddPoints(points...)
 }
 
 func (d *GGDrawing) AddPoint(x, y float64) {
 	x, y = value.ConvertToOne(d.Measures.Length, x), value.ConvertToOne(d.Measures.Length, y)
 	d.Polygon.AddPoint(x, y)
+	d.Closure = nil
 }
 
 func (d *GGDrawing) AddPointByDirection(distance float64, direction float64) error {
 	distance = value.ConvertToOne(d.Measures.Length, distance)
 	direction = value.ConvertToOne(d.Measures.Angle, direction)
+	d.Closure = nil
 	return d.Polygon.AddPointByDirection(distance, direction)
 }
 
 func (d *GGDrawing) AddPointByAngle(distance float64, angle float64) error {
 	distance = value.ConvertToOne(d.Measures.Length, distance)
 	angle = value.ConvertToOne(d.Measures.Angle, angle)
+	d.Closure = nil
 	return d.Polygon.AddPointByAngle(distance, angle)
 }
 
+// SetPoint changes the point by index with the drawing measures. Fixtures with offsets are placed by new walls.
+// The drawing isn't changed if holes, levels or fixtures don't fit the new outline.
 func (d *GGDrawing) SetPoint(i int, point *figure.Point) error {
-	if point.Calculator == nil {
-		point.X, point.Y = value.ConvertToOne(d.Measures.Length, point.X), value.ConvertToOne(d.Measures.Length, point.Y)
-	} else {
-		point.Calculator.ConvertToOne(d.Measures)
+	d.convertPointToOne(point)
+	return d.changeOutline(func() error { return d.Polygon.SetPoint(i, point) })
+}
+
+// InsertPoint inserts the point with the drawing measures before the point by index i
+// (look at figure.Polygon.InsertPoint). Offsets of fixtures are moved to renumbered sides, the new outline is checked
+// like in SetPoint.
+func (d *GGDrawing) InsertPoint(i int, point *figure.Point, mode figure.PointsUpdateMode) error {
+	n := d.Len()
+	d.convertPointToOne(point)
+	return d.changeOutline(func() error {
+		if err := d.Polygon.InsertPoint(i, point, mode); err != nil {
+			return err
+		}
+		d.moveFixturesOffsets(newPointsIndexes(n, func(k int) int {
+			if k < i {
+				return k
+			}
+			return k + 1
+		}))
+		return nil
+	})
+}
+
+// DeletePoint removes the point by index and updates calculated points by the mode (look at
+// figure.Polygon.DeletePoint). Offsets of fixtures are moved to renumbered sides, the new outline is checked
+// like in SetPoint.
+func (d *GGDrawing) DeletePoint(i int, mode figure.PointsUpdateMode) error {
+	n := d.Len()
+	return d.changeOutline(func() error {
+		if err := d.Polygon.DeletePoint(i, mode); err != nil {
+			return err
+		}
+		d.moveFixturesOffsets(newPointsIndexes(n, func(k int) int {
+			switch {
+			case k < i:
+				return k
+			case k == i:
+				return -1
+			}
+			return k - 1
+		}))
+		return nil
+	})
+}
+
+// RotatePoints makes the point by index the first one (look at figure.Polygon.RotatePoints).
+// Offsets of fixtures are moved to renumbered sides, the new outline is checked like in SetPoint.
+func (d *GGDrawing) RotatePoints(first int) error {
+	n := d.Len()
+	return d.changeOutline(func() error {
+		if err := d.Polygon.RotatePoints(first); err != nil {
+			return err
+		}
+		d.moveFixturesOffsets(newPointsIndexes(n, func(k int) int { return (k - first + n) % n }))
+		return nil
+	})
+}
+
+// changeOutline changes points of the drawing and checks that holes, levels and fixtures fit the new outline,
+// fixtures with offsets are placed by new walls. Points and fixtures are restored if an error is returned,
+// otherwise the misclosure is dropped, because it doesn't describe new points.
+func (d *GGDrawing) changeOutline(change func() error) error {
+	c, err := d.copy()
+	if err != nil {
+		return err
+	}
+	if err = change(); err == nil {
+		err = d.CheckOutline()
+	}
+	if err != nil {
+		d.Polygon, d.Fixtures = c.Polygon, c.Fixtures
+		return err
+	}
+	d.Closure = nil
+	return nil
+}
+
+// CheckOutline returns an error if holes, levels or fixtures don't fit the outline of the drawing.
+// Fixtures with offsets are placed by walls.
+func (d *GGDrawing) CheckOutline() error {
+	if err := d.Polygon.CheckHoles(d.Holes...); err != nil {
+		return err
+	}
+	if err := d.Polygon.CheckLevels(d.Levels...); err != nil {
+		return err
+	}
+	for _, f := range d.Fixtures {
+		if err := d.Polygon.PlaceFixture(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReversePoints reverses the direction of going around the drawing (look at figure.Polygon.ReversePoints).
+// Offsets of fixtures are moved to renumbered sides, the misclosure is dropped.
+func (d *GGDrawing) ReversePoints() error {
+	n := d.Len()
+	if err := d.Polygon.ReversePoints(); err != nil {
+		return err
+	}
+	d.moveFixturesOffsets(newPointsIndexes(n, func(k int) int { return (n - k) % n }))
+	d.Closure = nil
+	return nil
+}
+
+// moveFixturesOffsets moves offsets of fixtures from walls to sides between the same points by new indexes of points,
+// where -1 is a removed point. Offsets of the fixture are dropped if one of its sides doesn't exist anymore.
+func (d *GGDrawing) moveFixturesOffsets(newIndexes []int) {
+	n, m := len(newIndexes), d.Len()
+	for _, f := range d.Fixtures {
+		for _, o := range f.Offsets {
+			if o.Side < 0 || o.Side >= n {
+				f.Offsets = nil
+				break
+			}
+			a, b := newIndexes[o.Side], newIndexes[(o.Side+1)%n]
+			if a >= 0 && b == (a+1)%m {
+				o.Side = a
+			} else if b >= 0 && a == (b+1)%m {
+				o.Side = b
+			} else {
+				f.Offsets = nil
+				break
+			}
+		}
+	}
+}
+
+// newPointsIndexes returns new indexes of n points by the index function.
+func newPointsIndexes(n int, index func(k int) int) []int {
+	indexes := make([]int, n)
+	for k := range indexes {
+		indexes[k] = index(k)
+	}
+	return indexes
+}
+
+// AddDiagonals adds measurements of diagonals with lengths in the drawing measure, without solving.
+func (d *GGDrawing) AddDiagonals(diagonals ...*figure.Measurement) error {
+	for _, m := range diagonals {
+		m.Length = value.ConvertToOne(d.Measures.Length, m.Length)
+	}
+	return d.Polygon.AddDiagonals(diagonals...)
+}
+
+// SolveDiagonals solves coordinates of the drawing points by measured sides and diagonals
+// and returns residuals of measurements in the drawing measure. The new outline is checked like in SetPoint.
+func (d *GGDrawing) SolveDiagonals() ([]*figure.Residual, error) {
+	var residuals []*figure.Residual
+	err := d.changeOutline(func() (err error) {
+		residuals, err = d.Polygon.Solve()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d.convertResiduals(residuals), nil
+}
+
+// GetResiduals returns diagonals with their residuals in the drawing measure.
+func (d *GGDrawing) GetResiduals() []*figure.Residual {
+	return d.convertResiduals(d.Polygon.Residuals())
+}
+
+func (d *GGDrawing) convertResiduals(residuals []*figure.Residual) []*figure.Residual {
+	for _, r := range residuals {
+		r.Length = value.ConvertFromOneRound(d.Measures.Length, r.Length, numbersPrecision)
+		r.Residual = value.ConvertFromOneRound(d.Measures.Length, r.Residual, numbersPrecision)
+	}
+	return residuals
+}
+
+// CloseTraverse closes the traverse of the drawing on the first point, adjusting calculated points with method
+// (look at figure.Polygon.CloseTraverse), and keeps the misclosure in Closure until points are changed.
+// The new outline is checked like in SetPoint.
+func (d *GGDrawing) CloseTraverse(method figure.AdjustmentMethod, transverseRatio float64) error {
+	var c *figure.Closure
+	err := d.changeOutline(func() (err error) {
+		c, err = d.Polygon.CloseTraverse(method, transverseRatio)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	d.Closure = c
+	return nil
+}
+
+// GetClosure returns Closure of the drawing in its measures or nil if the traverse hasn't been closed or points
+// have been changed since then.
+func (d *GGDrawing) GetClosure() *figure.Closure {
+	if d.Closure == nil {
+		return nil
+	}
+	c := *d.Closure
+	c.DX = value.ConvertFromOneRound(d.Measures.Length, c.DX, numbersPrecision)
+	c.DY = value.ConvertFromOneRound(d.Measures.Length, c.DY, numbersPrecision)
+	c.Linear = value.ConvertFromOneRound(d.Measures.Length, c.Linear, numbersPrecision)
+	c.Length = value.ConvertFromOneRound(d.Measures.Length, c.Length, numbersPrecision)
+	c.Angular = value.ConvertFromOneRound(d.Measures.Angle, c.Angular, numbersPrecision)
+	c.Ratio = value.Round(c.Ratio, 0)
+	return &c
+}
+
+// GetCentroid returns the centroid of the drawing in the drawing measure. Returns nil if the drawing has no area.
+func (d *GGDrawing) GetCentroid() *figure.Point {
+	c, err := d.Polygon.Centroid()
+	if err != nil {
+		return nil
+	}
+	return roundPoint(&figure.Point{X: value.ConvertFromOne(d.Measures.Length, c.X), Y: value.ConvertFromOne(d.Measures.Length, c.Y)})
+}
+
+// GetBoundingBox returns the bounding box of the drawing parallel to axes in the drawing measures.
+// Returns nil if the drawing has less than two points.
+func (d *GGDrawing) GetBoundingBox() *figure.Rectangle {
+	r, err := d.Polygon.BoundingBox()
+	if err != nil {
+		return nil
+	}
+	return d.roundRectangle(r)
+}
+
+// GetOrientedBoundingBox returns the bounding box of the minimal area in the drawing measures, its width and height
+// are real dimensions of the room drawn at an angle. Returns nil if the drawing has less than two points.
+func (d *GGDrawing) GetOrientedBoundingBox() *figure.Rectangle {
+	r, err := d.Polygon.OrientedBoundingBox()
+	if err != nil {
+		return nil
+	}
+	return d.roundRectangle(r)
+}
+
+// GetPrincipalAxis returns the principal axis of the drawing in the drawing measures. Returns nil if the drawing
+// has no area.
+func (d *GGDrawing) GetPrincipalAxis() *figure.Axis {
+	a, err := d.Polygon.PrincipalAxis()
+	if err != nil {
+		return nil
+	}
+	a.ConvertFromOne(d.Measures)
+	a.A, a.B = roundPoint(a.A), roundPoint(a.B)
+	a.Direction, a.Length = value.Round(a.Direction, numbersPrecision), value.Round(a.Length, numbersPrecision)
+	return a
+}
+
+// roundRectangle converts the rectangle to the drawing measures and rounds it.
+func (d *GGDrawing) roundRectangle(r *figure.Rectangle) *figure.Rectangle {
+	r.ConvertFromOne(d.Measures)
+	r.Center = roundPoint(r.Center)
+	r.Width, r.Height = value.Round(r.Width, numbersPrecision), value.Round(r.Height, numbersPrecision)
+	r.Direction = value.Round(r.Direction, numbersPrecision)
+	return r
+}
+
+// ShrinkagePattern returns a new drawing with the cut pattern of the stretch ceiling canvas, which is reduced by
+// shrinkX and shrinkY percents along and across the roll. The roll direction is in the drawing angle measure.
+// Holes aren't included in the pattern, because they're cut after stretching.
+func (d *GGDrawing) ShrinkagePattern(shrinkX, shrinkY, direction float64) (*GGDrawing, error) {
+	s := &figure.Shrinkage{X: shrinkX / 100, Y: shrinkY / 100, Direction: value.ConvertToOne(d.Measures.Angle, direction)}
+	pol, err := s.Pattern(&d.Polygon)
+	if err != nil {
+		return nil, err
+	}
+	measures := *d.Measures
+	pattern := &GGDrawing{
+		Polygon:     *pol,
+		Description: drawing.NewUnionDescription(d.Description),
+		Measures:    &measures,
+	}
+	pattern.Description.PushBack("Shrinkage", fmt.Sprintf("%v%% along the roll, %v%% across", shrinkX, shrinkY))
+	pattern.Description.PushBack("Roll direction", fmt.Sprintf("%v%s", direction, value.NameOfAngleMeasure(d.Measures.Angle)))
+	return pattern, nil
+}
+
+// Square returns a copy of the drawing, where nearly right angles are snapped by the squaring with angles
+// in the drawing measure (look at figure.Polygon.Square), and the report with shifts of points in the length measure.
+// Returns an error if holes, levels or fixtures don't fit the squared outline.
+func (d *GGDrawing) Square(sq *figure.Squaring) (*GGDrawing, *figure.SquaringReport, error) {
+	sq.ConvertToOne(d.Measures)
+	squared, err := d.copy()
+	if err != nil {
+		return nil, nil, err
+	}
+	report, err := squared.Polygon.Square(sq)
+	if err != nil {
+		return nil, nil, err
+	}
+	squared.Closure = nil
+	if err := squared.CheckOutline(); err != nil {
+		return nil, nil, err
+	}
+	report.ConvertFromOne(squared.Measures)
+	for i, s := range report.Shifts {
+		report.Shifts[i] = value.Round(s, numbersPrecision)
+	}
+	report.MaxShift = value.Round(report.MaxShift, numbersPrecision)
+	report.TotalShift = value.Round(report.TotalShift, numbersPrecision)
+	return squared, report, nil
+}
+
+// Simplify returns a copy of the drawing, where close points are merged and redundant points are removed by
+// the simplification with lengths in the drawing measure (look at figure.Polygon.Simplify), and the report
+// with the deviation in the length measure.
+// Returns an error if holes, levels or fixtures don't fit the simplified outline.
+func (d *GGDrawing) Simplify(s *figure.Simplification) (*GGDrawing, *figure.SimplificationReport, error) {
+	s.ConvertToOne(d.Measures)
+	simplified, err := d.copy()
+	if err != nil {
+		return nil, nil, err
+	}
+	report, err := simplified.Polygon.Simplify(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	simplified.Closure = nil
+	if len(report.Merged)+len(report.Removed) > 0 {
+		for _, f := range simplified.Fixtures {
+			f.Offsets = nil
+		}
+	}
+	if err := simplified.CheckOutline(); err != nil {
+		return nil, nil, err
+	}
+	report.ConvertFromOne(simplified.Measures)
+	report.MaxDeviation = value.Round(report.MaxDeviation, numbersPrecision)
+	report.AreaChange = value.Round(report.AreaChange, numbersPrecision)
+	return simplified, report, nil
+}
+
+// copy returns a deep copy of the drawing.
+func (d *GGDrawing) copy() (*GGDrawing, error) {
+	data, err := d.Value()
+	if err != nil {
+		return nil, err
+	}
+	c := &GGDrawing{}
+	if err := c.Scan(data); err != nil {
+		return nil, err
+	}
+	// an empty description is marshaled as null
+	if c.Description == nil {
+		c.Description = drawing.NewDescription()
+	}
+	return c, nil
+}
+
+// Offset returns points of the offset outline (look at figure.Polygon.Offset) with the distance in the drawing
+// measure and its perimeter, e.g. the length of the mounting profile or a cornice.
+func (d *GGDrawing) Offset(o *figure.Offset) ([]*figure.Point, float64, error) {
+	o.ConvertToOne(d.Measures)
+	outline, err := d.Polygon.Offset(o)
+	if err != nil {
+		return nil, 0, err
+	}
+	perimeter := value.ConvertFromOneRound(d.Measures.Perimeter, outline.Perimeter(), numbersPrecision)
+	return convertPointsFromOne(outline.Points, d.Measures.Length, numbersPrecision), perimeter, nil
+}
+
+// Transform applies the transformation with lengths and angles in the drawing measures to the polygon, holes,
+// levels, fixtures and the misclosure. The grid of tiles and seams of rolls are moved and turned with the polygon,
+// sizes of tiles and widths of rolls aren't scaled.
+func (d *GGDrawing) Transform(t *figure.Transform) error {
+	t.ConvertToOne(d.Measures)
+	if err := d.Polygon.Transform(t); err != nil {
+		return err
+	}
+	for _, h := range d.Holes {
+		if err := h.Transform(t); err != nil {
+			return err
+		}
+	}
+	for _, l := range d.Levels {
+		if err := l.Transform(t); err != nil {
+			return err
+		}
+	}
+	for _, f := range d.Fixtures {
+		if err := f.Transform(t); err != nil {
+			return err
+		}
+	}
+	if d.Closure != nil {
+		if err := d.Closure.Transform(t); err != nil {
+			return err
+		}
+	}
+	if d.Tiles != nil {
+		origin := t.Point(&figure.Point{X: d.Tiles.OriginX, Y: d.Tiles.OriginY})
+		d.Tiles.OriginX, d.Tiles.OriginY = origin.X, origin.Y
+		d.Tiles.Direction = t.Direction(d.Tiles.Direction)
+	}
+	if d.Rolls != nil {
+		d.Rolls.Direction = t.Direction(d.Rolls.Direction)
+	}
+	return nil
+}
+
+// MergeGGDrawings returns a new drawing with the union of polygons of drawings, e.g. adjacent rooms under
+// a single ceiling. Holes and fixtures are moved to the new drawing, a hole repeated in several drawings is added once.
+// Levels, rolls and tiles aren't merged. Measures are taken from the first drawing. Returns ErrNotMergeable
+// if drawings don't make a single region or their holes overlap.
+func MergeGGDrawings(drawings ...*GGDrawing) (*GGDrawing, error) {
+	if len(drawings) < 2 {
+		return nil, fmt.Errorf("%w: need at least 2 drawings, got %d", ErrNotMergeable, len(drawings))
+	}
+	polygons := make([]*figure.Polygon, len(drawings))
+	for i, d := range drawings {
+		polygons[i] = &d.Polygon
+	}
+	regions, err := figure.UnionAll(polygons...)
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) != 1 {
+		return nil, fmt.Errorf("%w: drawings make %d separate regions", ErrNotMergeable, len(regions))
+	}
+	measures := *drawings[0].Measures
+	merged := &GGDrawing{
+		Polygon:     *regions[0].Outline,
+		Description: drawing.NewDescription(),
+		Measures:    &measures,
+		Holes:       regions[0].Holes,
+	}
+	for _, d := range drawings {
+		c, err := d.copy()
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range c.Holes {
+			if !containsHole(merged.Holes, h) {
+				merged.Holes = append(merged.Holes, h)
+			}
+		}
+		for _, f := range c.Fixtures {
+			f.Offsets = nil
+			merged.Fixtures = append(merged.Fixtures, f)
+		}
+	}
+	if err := merged.Polygon.CheckHoles(merged.Holes...); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotMergeable, err)
+	}
+	return merged, nil
+}
+
+// containsHole returns true if holes have the same hole as h.
+func containsHole(holes []*figure.Hole, h *figure.Hole) bool {
+	for _, other := range holes {
+		if reflect.DeepEqual(other, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// Split returns new drawings with parts of the drawing cut by the line with coordinates and the direction
+// in the drawing measures (look at figure.Polygon.Split), e.g. separate canvases of a large ceiling. Holes, levels
+// and fixtures go to parts containing them, rolls and tiles are kept in every part. Returns ErrNotSplittable if a hole or a level crosses the line.
+func (d *GGDrawing) Split(line *figure.SplitLine) ([]*GGDrawing, error) {
+	line.ConvertToOne(d.Measures)
+	c, err := d.copy()
+	if err != nil {
+		return nil, err
+	}
+	polygons, err := c.Polygon.Split(line)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]*GGDrawing, len(polygons))
+	for i, pol := range polygons {
+		// every part gets its own copy of rolls and tiles
+		if parts[i], err = d.copy(); err != nil {
+			return nil, err
+		}
+		parts[i].Polygon, parts[i].Description = *pol, drawing.NewDescription()
+		parts[i].Closure, parts[i].Holes, parts[i].Levels, parts[i].Fixtures = nil, nil, nil, nil
+	}
+	for i, h := range c.Holes {
+		part := splitPartOf(parts, func(p *GGDrawing) bool { return p.Polygon.CheckHole(h) == nil })
+		if part == nil {
+			return nil, fmt.Errorf("%w: hole %s crosses the split line", ErrNotSplittable, holeName(i))
+		}
+		part.Holes = append(part.Holes, h)
+	}
+	for _, l := range c.Levels {
+		part := splitPartOf(parts, func(p *GGDrawing) bool { return p.Polygon.CheckLevels(l) == nil })
+		if part == nil {
+			return nil, fmt.Errorf("%w: level %q crosses the split line", ErrNotSplittable, l.Name)
+		}
+		part.Levels = append(part.Levels, l)
+	}
+	for _, f := range c.Fixtures {
+		if part := splitPartOf(parts, func(p *GGDrawing) bool { return p.Polygon.ContainsPoint(f.Center()) }); part != nil {
+			f.Offsets = nil
+			part.Fixtures = append(part.Fixtures, f)
+		}
+	}
+	for _, part := range parts {
+		if err := part.Polygon.CheckLevels(part.Levels...); err != nil {
+			return nil, err
+		}
+	}
+	return parts, nil
+}
+
+// splitPartOf returns the first part of the split drawing, which suits f, or nil.
+func splitPartOf(parts []*GGDrawing, f func(p *GGDrawing) bool) *GGDrawing {
+	for _, p := range parts {
+		if f(p) {
+			return p
+		}
+	}
+	return nil
+}
+
+// GetTriangulation returns the report of triangles of the drawing (look at figure.Polygon.TriangulationReport)
+// with lengths and areas in the drawing measures.
+func (d *GGDrawing) GetTriangulation() (*figure.TriangulationReport, error) {
+	report, err := d.Polygon.TriangulationReport()
+	if err != nil {
+		return nil, err
+	}
+	report.ConvertFromOne(d.Measures)
+	for _, t := range report.Triangles {
+		for i, s := range t.Sides {
+			t.Sides[i] = value.Round(s, numbersPrecision)
+		}
+		t.Area = value.Round(t.Area, numbersPrecision)
+	}
+	report.Area = value.Round(report.Area, numbersPrecision)
+	return report, nil
+}
+
+// Query returns the location of the point with coordinates in the drawing measure relatively to the drawing, the nearest
+// vertex and distances to all walls (look at figure.Polygon.Query). The point lies on a wall if it's not farther than
+// tol in the length measure from the wall.
+func (d *GGDrawing) Query(p *figure.Point, tol float64) (*figure.PointQuery, error) {
+	one := &figure.Point{X: value.ConvertToOne(d.Measures.Length, p.X), Y: value.ConvertToOne(d.Measures.Length, p.Y)}
+	q, err := d.Polygon.Query(one, value.ConvertToOne(d.Measures.Length, tol))
+	if err != nil {
+		return nil, err
+	}
+	q.ConvertFromOne(d.Measures)
+	q.Point = &figure.Point{X: p.X, Y: p.Y}
+	q.VertexDistance = value.Round(q.VertexDistance, numbersPrecision)
+	for _, w := range q.Walls {
+		w.Distance = value.Round(w.Distance, numbersPrecision)
+		w.Foot.X, w.Foot.Y = value.Round(w.Foot.X, numbersPrecision), value.Round(w.Foot.Y, numbersPrecision)
+	}
+	return q, nil
+}
+
+// triangulation returns triangles of the drawing if they're shown.
+func (d *GGDrawing) triangulation() ([][3]int, error) {
+	if !d.showTriangles {
+		return nil, nil
+	}
+	return d.Polygon.Triangulation()
+}
+
+// SetRolls sets widths of rolls and the seams direction in the drawing measures for the layout of strips.
+// Nil removes the layout.
+func (d *GGDrawing) SetRolls(rolls *figure.Rolls) error {
+	if rolls == nil {
+		d.Rolls = nil
+		return nil
+	}
+	rolls.ConvertToOne(d.Measures)
+	if _, err := d.Polygon.Layout(rolls); err != nil && !errors.Is(err, figure.ErrNotEnoughPoints) {
+		return err
+	}
+	d.Rolls = rolls
+	return nil
+}
+
+// GetRolls returns rolls of the layout in the drawing measures or nil if they aren't set.
+func (d *GGDrawing) GetRolls() *figure.Rolls {
+	if d.Rolls == nil {
+		return nil
+	}
+	r := &figure.Rolls{Widths: append([]float64{}, d.Rolls.Widths...), Direction: d.Rolls.Direction}
+	r.ConvertFromOne(d.Measures)
+	for i, w := range r.Widths {
+		r.Widths[i] = value.Round(w, numbersPrecision)
+	}
+	r.Direction = value.Round(r.Direction, numbersPrecision)
+	return r
+}
+
+// GetLayout returns the layout of strips in the drawing measures or nil if rolls aren't set.
+func (d *GGDrawing) GetLayout() (*figure.Layout, error) {
+	layout, err := d.layout()
+	if err != nil || layout == nil {
+		return nil, err
+	}
+	out := &figure.Layout{
+		Direction: value.ConvertFromOneRound(d.Measures.Angle, layout.Direction, numbersPrecision),
+		Material:  value.ConvertFromOneRound(d.Measures.Area, layout.Material, numbersPrecision),
+		Waste:     value.ConvertFromOneRound(d.Measures.Area, layout.Waste, numbersPrecision),
+	}
+	for _, s := range layout.Strips {
+		out.Strips = append(out.Strips, &figure.Strip{
+			RollWidth: value.ConvertFromOneRound(d.Measures.Length, s.RollWidth, numbersPrecision),
+			Width:     value.ConvertFromOneRound(d.Measures.Length, s.Width, numbersPrecision),
+			Length:    value.ConvertFromOneRound(d.Measures.Length, s.Length, numbersPrecision),
+			Material:  value.ConvertFromOneRound(d.Measures.Area, s.Material, numbersPrecision),
+		})
+	}
+	for _, s := range layout.Seams {
+		out.Seams = append(out.Seams, &figure.Segment{
+			A: convertPointsFromOne([]*figure.Point{s.A}, d.Measures.Length, numbersPrecision)[0],
+			B: convertPointsFromOne([]*figure.Point{s.B}, d.Measures.Length, numbersPrecision)[0],
+		})
+	}
+	return out, nil
+}
+
+// layout returns the layout of strips in internal measures or nil if rolls aren't set or the drawing has less
+// than 3 points.
+func (d *GGDrawing) layout() (*figure.Layout, error) {
+	if d.Rolls == nil || d.Len() < 3 {
+		return nil, nil
+	}
+	return d.Polygon.Layout(d.Rolls)
+}
+
+// SetTiles sets the suspended ceiling grid with the size of tiles and the origin in the drawing measures.
+// Nil removes the grid.
+func (d *GGDrawing) SetTiles(tiles *figure.Tiles) error {
+	if tiles == nil {
+		d.Tiles = nil
+		return nil
+	}
+	tiles.ConvertToOne(d.Measures)
+	if _, err := d.Polygon.TileLayout(tiles); err != nil && !errors.Is(err, figure.ErrNotEnoughPoints) {
+		return err
+	}
+	d.Tiles = tiles
+	return nil
+}
+
+// GetTiles returns the suspended ceiling grid in the drawing measures or nil if it isn't set.
+func (d *GGDrawing) GetTiles() *figure.Tiles {
+	if d.Tiles == nil {
+		return nil
+	}
+	t := *d.Tiles
+	t.ConvertFromOne(d.Measures)
+	t.Width, t.Length = value.Round(t.Width, numbersPrecision), value.Round(t.Length, numbersPrecision)
+	t.OriginX, t.OriginY = value.Round(t.OriginX, numbersPrecision), value.Round(t.OriginY, numbersPrecision)
+	t.Direction = value.Round(t.Direction, numbersPrecision)
+	return &t
+}
+
+// GetTileLayout returns the layout of tiles in the drawing measures or nil if tiles aren't set.
+// Lengths of runners and tees are in the perimeter measure, areas of cuts are in the area measure.
+func (d *GGDrawing) GetTileLayout() (*figure.TileLayout, error) {
+	layout, err := d.tileLayout()
+	if err != nil || layout == nil {
+		return nil, err
+	}
+	out := &figure.TileLayout{
+		FullTiles:   layout.FullTiles,
+		CutTiles:    layout.CutTiles,
+		MainRunners: value.ConvertFromOneRound(d.Measures.Perimeter, layout.MainRunners, numbersPrecision),
+		CrossTees:   value.ConvertFromOneRound(d.Measures.Perimeter, layout.CrossTees, numbersPrecision),
+		Cuts:        make([]*figure.TileCut, len(layout.Cuts)),
+	}
+	for i, c := range layout.Cuts {
+		out.Cuts[i] = &figure.TileCut{
+			Points: convertPointsFromOne(c.Points, d.Measures.Length, numbersPrecision),
+			Area:   value.ConvertFromOneRound(d.Measures.Area, c.Area, numbersPrecision),
+		}
+	}
+	return out, nil
+}
+
+// tileLayout returns the layout of tiles in internal measures or nil if tiles aren't set or the drawing has less
+// than 3 points.
+func (d *GGDrawing) tileLayout() (*figure.TileLayout, error) {
+	if d.Tiles == nil || d.Len() < 3 {
+		return nil, nil
+	}
+	return d.Polygon.TileLayout(d.Tiles)
+}
+
+// EstimateInput returns rounded quantities of the drawing for the estimate in metres and square metres. The canvas is
+// the material of the layout if rolls are set, otherwise the area of the outline. Lights, chandeliers and fans
+// are counted as light fixtures and pipes as pipe bypasses.
+func (d *GGDrawing) EstimateInput() (*estimate.Input, error) {
+	if d.Len() < 3 {
+		return nil, fmt.Errorf("%w for estimate (%d), have to be at least 3", ErrTooFewPoints, d.Len())
+	}
+	in := &estimate.Input{
+		Canvas:    d.Polygon.Area(),
+		Area:      d.Polygon.Area(),
+		Perimeter: d.Polygon.Perimeter(),
+	}
+	counts := d.FixturesCount()
+	in.Fixtures = counts[figure.FixtureLight] + counts[figure.FixtureChandelier] + counts[figure.FixtureFan]
+	in.PipeBypasses = counts[figure.FixturePipe]
+	for _, h := range d.Holes {
+		in.Area -= h.Area()
+	}
+	layout, err := d.layout()
+	if err != nil {
+		return nil, err
+	}
+	if layout != nil {
+		in.Canvas = layout.Material
+	}
+	in.InnerCorners, in.OuterCorners = d.Polygon.CornersCount()
+	in.Canvas = value.Round(in.Canvas, numbersPrecision)
+	in.Area = value.Round(in.Area, numbersPrecision)
+	in.Perimeter = value.Round(in.Perimeter, numbersPrecision)
+	return in, nil
+}
+
+// GetCorners returns interior angles of the drawing by points in the angle measure of the drawing.
+func (d *GGDrawing) GetCorners() []*figure.Corner {
+	corners := d.Polygon.Corners()
+	for _, c := range corners {
+		c.Angle = value.ConvertFromOneRound(d.Measures.Angle, c.Angle, numbersPrecision)
+	}
+	return corners
+}
+
+// AddHoles checks and adds holes with coordinates in the drawing measure. Holes must lie inside of the drawing
+// and can't overlap each other.
+func (d *GGDrawing) AddHoles(holes ...*figure.Hole) error {
+	for _, h := range holes {
+		h.ConvertToOne(d.Measures)
+	}
+	all := append(append([]*figure.Hole{}, d.Holes...), holes...)
+	if err := d.Polygon.CheckHoles(all...); err != nil {
+		return err
+	}
+	d.Holes = all
+	return nil
+}
+
+// SetHole checks and changes the hole by index, coordinates are in the drawing measure.
+func (d *GGDrawing) SetHole(i int, hole *figure.Hole) error {
+	if i < 0 || i >= len(d.Holes) {
+		return fmt.Errorf("%w: %d", ErrHoleNotFound, i)
+	}
+	hole.ConvertToOne(d.Measures)
+	all := append([]*figure.Hole{}, d.Holes...)
+	all[i] = hole
+	if err := d.Polygon.CheckHoles(all...); err != nil {
+		return err
+	}
+	d.Holes = all
+	return nil
+}
+
+// RemoveHole removes the hole by index.
+func (d *GGDrawing) RemoveHole(i int) error {
+	if i < 0 || i >= len(d.Holes) {
+		return fmt.Errorf("%w: %d", ErrHoleNotFound, i)
+	}
+	d.Holes = append(d.Holes[:i], d.Holes[i+1:]...)
+	return nil
+}
+
+// GetHoles returns holes in the drawing measure.
+func (d *GGDrawing) GetHoles() []*figure.Hole {
+	return d.GetHolesWithParams(d.Measures.Length, numbersPrecision)
+}
+
+// GetHolesWithParams returns holes with coordinates in the measure m, rounded to the precision.
+func (d *GGDrawing) GetHolesWithParams(m value.Measure, precision int) []*figure.Hole {
+	holes := make([]*figure.Hole, len(d.Holes))
+	for i, h := range d.Holes {
+		holes[i] = &figure.Hole{Radius: value.ConvertFromOneRound(m, h.Radius, precision)}
+		if h.Center != nil {
+			holes[i].Center = &figure.Point{
+				X: value.ConvertFromOneRound(m, h.Center.X, precision),
+				Y: value.ConvertFromOneRound(m, h.Center.Y, precision),
+			}
+		}
+		if len(h.Points) > 0 {
+			holes[i].Points = convertPointsFromOne(h.Points, m, precision)
+		}
+	}
+	return holes
+}
+
+// AddLevels checks and adds levels with coordinates and drops in the drawing measure. Levels must lie inside
+// of the drawing and can't cross each other.
+func (d *GGDrawing) AddLevels(levels ...*figure.Level) error {
+	for _, l := range levels {
+		l.ConvertToOne(d.Measures)
+	}
+	all := append(append([]*figure.Level{}, d.Levels...), levels...)
+	if err := d.Polygon.CheckLevels(all...); err != nil {
+		return err
+	}
+	d.Levels = all
+	return nil
+}
+
+// SetLevel checks and changes the level by index, coordinates and the drop are in the drawing measure.
+func (d *GGDrawing) SetLevel(i int, level *figure.Level) error {
+	if i < 0 || i >= len(d.Levels) {
+		return fmt.Errorf("%w: %d", ErrLevelNotFound, i)
 	}
-	return d.Polygon.SetPoint(i, point)
+	level.ConvertToOne(d.Measures)
+	all := append([]*figure.Level{}, d.Levels...)
+	all[i] = level
+	if err := d.Polygon.CheckLevels(all...); err != nil {
+		return err
+	}
+	d.Levels = all
+	return nil
+}
+
+// RemoveLevel removes the level by index.
+func (d *GGDrawing) RemoveLevel(i int) error {
+	if i < 0 || i >= len(d.Levels) {
+		return fmt.Errorf("%w: %d", ErrLevelNotFound, i)
+	}
+	d.Levels = append(d.Levels[:i], d.Levels[i+1:]...)
+	return nil
+}
+
+// GetLevels returns levels in the drawing measure.
+func (d *GGDrawing) GetLevels() []*figure.Level {
+	return d.GetLevelsWithParams(d.Measures.Length, numbersPrecision)
+}
+
+// GetLevelsWithParams returns levels with coordinates and drops in the measure m, rounded to the precision.
+func (d *GGDrawing) GetLevelsWithParams(m value.Measure, precision int) []*figure.Level {
+	levels := make([]*figure.Level, len(d.Levels))
+	for i, l := range d.Levels {
+		levels[i] = &figure.Level{
+			Name:   l.Name,
+			Drop:   value.ConvertFromOneRound(m, l.Drop, precision),
+			Points: convertPointsFromOne(l.Points, m, precision),
+		}
+	}
+	return levels
+}
+
+// LevelsSummary returns calculations of the main level and levels in the drawing measures or nil if the drawing
+// doesn't have levels. Drops are in the length measure, lengths of perimeters and transitions are
+// in the perimeter measure.
+func (d *GGDrawing) LevelsSummary() []*figure.LevelSummary {
+	if len(d.Levels) == 0 {
+		return nil
+	}
+	summaries := d.Polygon.LevelsSummary(d.Levels...)
+	for _, s := range summaries {
+		s.Drop = value.ConvertFromOneRound(d.Measures.Length, s.Drop, numbersPrecision)
+		s.Area = value.ConvertFromOneRound(d.Measures.Area, s.Area, numbersPrecision)
+		s.Perimeter = value.ConvertFromOneRound(d.Measures.Perimeter, s.Perimeter, numbersPrecision)
+		s.Transition = value.ConvertFromOneRound(d.Measures.Perimeter, s.Transition, numbersPrecision)
+		s.TransitionArea = value.ConvertFromOneRound(d.Measures.Area, s.TransitionArea, numbersPrecision)
+	}
+	return summaries
+}
+
+// AddFixtures places and adds fixtures with coordinates and offsets in the drawing measure.
+// Fixtures must lie inside of the drawing.
+func (d *GGDrawing) AddFixtures(fixtures ...*figure.Fixture) error {
+	for _, f := range fixtures {
+		f.ConvertToOne(d.Measures)
+		if err := d.Polygon.PlaceFixture(f); err != nil {
+			return err
+		}
+	}
+	d.Fixtures = append(d.Fixtures, fixtures...)
+	return nil
+}
+
+// SetFixture places and changes the fixture by index, coordinates and offsets are in the drawing measure.
+func (d *GGDrawing) SetFixture(i int, fixture *figure.Fixture) error {
+	if i < 0 || i >= len(d.Fixtures) {
+		return fmt.Errorf("%w: %d", ErrFixtureNotFound, i)
+	}
+	fixture.ConvertToOne(d.Measures)
+	if err := d.Polygon.PlaceFixture(fixture); err != nil {
+		return err
+	}
+	d.Fixtures[i] = fixture
+	return nil
+}
+
+// RemoveFixture removes the fixture by index.
+func (d *GGDrawing) RemoveFixture(i int) error {
+	if i < 0 || i >= len(d.Fixtures) {
+		return fmt.Errorf("%w: %d", ErrFixtureNotFound, i)
+	}
+	d.Fixtures = append(d.Fixtures[:i], d.Fixtures[i+1:]...)
+	return nil
 }
 
+// GetFixtures returns fixtures in the drawing measure.
+func (d *GGDrawing) GetFixtures() []*figure.Fixture {
+	return d.GetFixturesWithParams(d.Measures.Length, numbersPrecision)
+}
+
+// GetFixturesWithParams returns fixtures with coordinates in the measure m, rounded to the precision.
+func (d *GGDrawing) GetFixturesWithParams(m value.Measure, precision int) []*figure.Fixture {
+	return fixturesWithParams(d.Fixtures, m, precision)
+}
+
+// fixturesWithParams returns copies of fixtures with coordinates in the measure m, rounded to the precision.
+func fixturesWithParams(source []*figure.Fixture, m value.Measure, precision int) []*figure.Fixture {
+	fixtures := make([]*figure.Fixture, len(source))
+	for i, f := range source {
+		fixtures[i] = &figure.Fixture{
+			Type:     f.Type,
+			X:        value.ConvertFromOneRound(m, f.X, precision),
+			Y:        value.ConvertFromOneRound(m, f.Y, precision),
+			Diameter: value.ConvertFromOneRound(m, f.Diameter, precision),
+		}
+		for _, o := range f.Offsets {
+			fixtures[i].Offsets = append(fixtures[i].Offsets,
+				&figure.WallOffset{Side: o.Side, Distance: value.ConvertFromOneRound(m, o.Distance, precision)})
+		}
+	}
+	return fixtures
+}
+
+// LightingGrid returns fixtures evenly placed by the grid with spacing and clearance in the drawing measure.
+// Fixtures avoid holes of the drawing. If write is true, fixtures are added into the drawing.
+func (d *GGDrawing) LightingGrid(g *figure.LightingGrid, write bool) ([]*figure.Fixture, error) {
+	g.ConvertToOne(d.Measures)
+	fixtures, err := d.Polygon.LightingGrid(g, d.Holes...)
+	if err != nil {
+		return nil, err
+	}
+	if write {
+		d.Fixtures = append(d.Fixtures, fixtures...)
+	}
+	return fixturesWithParams(fixtures, d.Measures.Length, numbersPrecision), nil
+}
+
+// FixturesCount returns numbers of fixtures by their types.
+func (d *GGDrawing) FixturesCount() map[figure.FixtureType]int {
+	counts := make(map[figure.FixtureType]int)
+	for _, f := range d.Fixtures {
+		counts[f.Type]++
+	}
+	return counts
+}
+
+// Area returns net area of the drawing: area of the outline without holes.
 func (d *GGDrawing) Area() float64 {
-	return value.ConvertFromOneRound(d.Measures.Area, d.Polygon.Area(), numbersPrecision)
+	area := d.Polygon.Area()
+	for _, h := range d.Holes {
+		area -= h.Area()
+	}
+	return value.ConvertFromOneRound(d.Measures.Area, area, numbersPrecision)
+}
+
+// HolesArea returns the total area of holes.
+func (d *GGDrawing) HolesArea() float64 {
+	var area float64
+	for _, h := range d.Holes {
+		area += h.Area()
+	}
+	return value.ConvertFromOneRound(d.Measures.Area, area, numbersPrecision)
+}
+
+// HolesPerimeter returns the total perimeter of holes, unlike Perimeter, which is a perimeter of outer walls.
+func (d *GGDrawing) HolesPerimeter() float64 {
+	var perimeter float64
+	for _, h := range d.Holes {
+		perimeter += h.Perimeter()
+	}
+	return value.ConvertFromOneRound(d.Measures.Perimeter, perimeter, numbersPrecision)
 }
 
 func (d *GGDrawing) Perimeter() float64 {
@@ -250,12 +1715,29 @@ func (d *GGDrawing) GetPoints() []*figure.Point {
 }
 
 func (d *GGDrawing) GetPointsWithParams(m value.Measure, precision int) []*figure.Point {
-	points := make([]*figure.Point, len(d.Points))
-	for i, p := range d.Points {
+	return convertPointsFromOne(d.Points, m, precision)
+}
+
+// roundPoint returns the point with coordinates rounded to numbersPrecision.
+func roundPoint(p *figure.Point) *figure.Point {
+	return &figure.Point{X: value.Round(p.X, numbersPrecision), Y: value.Round(p.Y, numbersPrecision)}
+}
+
+// convertPointsFromOne returns copies of points with coordinates and arcs in the measure m, rounded to the precision.
+func convertPointsFromOne(ps []*figure.Point, m value.Measure, precision int) []*figure.Point {
+	points := make([]*figure.Point, len(ps))
+	for i, p := range ps {
 		points[i] = &figure.Point{
 			X: value.ConvertFromOneRound(m, p.X, precision),
 			Y: value.ConvertFromOneRound(m, p.Y, precision),
 		}
+		if p.Arc != nil {
+			points[i].Arc = &figure.Arc{
+				Sagitta: value.ConvertFromOneRound(m, p.Arc.Sagitta, precision),
+				Radius:  value.ConvertFromOneRound(m, p.Arc.Radius, precision),
+				Right:   p.Arc.Right,
+			}
+		}
 	}
 	return points
 }
@@ -2,11 +2,13 @@ package raster
 
 import (
 	"bytes"
+	"errors"
 	"image/png"
 	"reflect"
 	"testing"
 
 	"github.com/fogleman/gg"
+	"github.com/maxsid/goCeilings/estimate"
 	. "github.com/maxsid/goCeilings/figure"
 	"golang.org/x/image/colornames"
 )
@@ -47,6 +49,13 @@ func TestGGDrawing_Draw(t *testing.T) {
 			{X: 2.2201, Y: 1.6998},
 			{X: 2.25, Y: 0},
 		},
+		{
+			{X: 0, Y: 0},
+			{X: 4, Y: 0},
+			{X: 4, Y: 2},
+			{X: 0, Y: 2, Arc: &Arc{Sagitta: 2, Right: true}},
+			{X: 0, Y: 1, Arc: &Arc{Radius: 1}},
+		},
 	}
 	drawExamples(examples, t)
 }
@@ -257,3 +266,833 @@ func TestGGDrawing_updateOffset(t *testing.T) {
 		})
 	}
 }
+
+func TestGGDrawing_CloseTraverse(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	err := d.AddPoints(
+		NewPoint(0, 0),
+		NewCalculatedPoint(&DirectionCalculator{Direction: 90, Distance: 300}),
+		NewCalculatedPoint(&AngleCalculator{Angle: 90, Distance: 400}),
+		NewCalculatedPoint(&AngleCalculator{Angle: 90, Distance: 300.5}),
+		NewCalculatedPoint(&DirectionCalculator{Direction: 180, Distance: 400.4}),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if c := d.GetClosure(); c != nil {
+		t.Errorf("GetClosure() got %+v before closing, want nil", c)
+	}
+	if err := d.CloseTraverse(AdjustmentCompass, 0); err != nil {
+		t.Error(err)
+		return
+	}
+	if d.Len() != 4 {
+		t.Errorf("Got %d points after closing, want 4", d.Len())
+	}
+	want := Closure{Method: AdjustmentCompass, Misclosure: Misclosure{DX: -0.4, DY: -0.5, Linear: 0.64, Length: 1400.9,
+		Angular: -0.07, Ratio: 2188}}
+	if got := d.GetClosure(); !reflect.DeepEqual(*got, want) {
+		t.Errorf("GetClosure() got %+v, want %+v", *got, want)
+	}
+	if err := d.CloseTraverse("bad", 0); err == nil {
+		t.Error("CloseTraverse() with unknown method must return an error")
+	}
+	if err := d.SetPoint(2, NewPoint(400, 300)); err != nil {
+		t.Fatal(err)
+	}
+	if c := d.GetClosure(); c != nil {
+		t.Errorf("GetClosure() got %+v after changing points, want nil", c)
+	}
+}
+
+func TestGGDrawing_SolveDiagonals(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	err := d.AddPoints(
+		NewPoint(0, 0),
+		NewCalculatedPoint(&DirectionCalculator{Direction: 90, Distance: 300}),
+		NewCalculatedPoint(&AngleCalculator{Angle: 95, Distance: 400}),
+		NewCalculatedPoint(&AngleCalculator{Angle: 90, Distance: 300}),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := d.AddDiagonals(&Measurement{A: 3, B: 0, Length: 400}, &Measurement{A: 0, B: 2, Length: 500}); err != nil {
+		t.Error(err)
+		return
+	}
+	residuals, err := d.SolveDiagonals()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(residuals) != 5 {
+		t.Errorf("SolveDiagonals() got %d residuals, want 5", len(residuals))
+	}
+	for _, r := range residuals {
+		if r.Residual != 0 {
+			t.Errorf("SolveDiagonals() residual of %d-%d = %v, want 0", r.A, r.B, r.Residual)
+		}
+	}
+	if area := d.Area(); area != 12 {
+		t.Errorf("Area() = %v, want 12", area)
+	}
+	want := []*Residual{{Measurement: Measurement{A: 3, B: 0, Length: 400}}, {Measurement: Measurement{A: 0, B: 2, Length: 500}}}
+	if got := d.GetResiduals(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetResiduals() got %+v, want %+v", got, want)
+	}
+}
+
+func TestGGDrawing_Arcs(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	err := d.AddPoints(
+		NewPoint(0, 0),
+		NewPoint(400, 0),
+		NewPoint(400, 200),
+		&Point{X: 0, Y: 200, Arc: &Arc{Radius: 200, Right: true}},
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if area, perimeter, height := d.Area(), d.Perimeter(), d.Height(); area != 14.28 || perimeter != 14.28 || height != 400 {
+		t.Errorf("Got area %v, perimeter %v, height %v, want 14.28, 14.28 and 400", area, perimeter, height)
+	}
+	if got := d.GetPoints()[3].Arc; !reflect.DeepEqual(got, &Arc{Radius: 200, Right: true}) {
+		t.Errorf("GetPoints() got arc %+v", got)
+	}
+	data, err := d.Value()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	restored := &GGDrawing{}
+	if err := restored.Scan(data); err != nil {
+		t.Error(err)
+		return
+	}
+	if !reflect.DeepEqual(restored.Points[3].Arc, d.Points[3].Arc) || restored.Area() != d.Area() {
+		t.Errorf("Scan() got arc %+v and area %v", restored.Points[3].Arc, restored.Area())
+	}
+	if err := d.AddPoints(&Point{X: 0, Y: 100, Arc: &Arc{Radius: 10}}); err == nil {
+		t.Error("AddPoints() with too small radius must return an error")
+	}
+}
+
+func TestGGDrawing_Holes(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 300), NewPoint(400, 300), NewPoint(400, 0)); err != nil {
+		t.Error(err)
+		return
+	}
+	err := d.AddHoles(
+		&Hole{Points: []*Point{{X: 50, Y: 50}, {X: 50, Y: 100}, {X: 100, Y: 100}, {X: 100, Y: 50}}},
+		&Hole{Center: &Point{X: 200, Y: 150}, Radius: 50},
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if area, holesArea := d.Area(), d.HolesArea(); area != 10.96 || holesArea != 1.04 {
+		t.Errorf("Got area %v and holes area %v, want 10.96 and 1.04", area, holesArea)
+	}
+	if perimeter, holesPerimeter := d.Perimeter(), d.HolesPerimeter(); perimeter != 14 || holesPerimeter != 5.14 {
+		t.Errorf("Got perimeter %v and holes perimeter %v, want 14 and 5.14", perimeter, holesPerimeter)
+	}
+	if got := d.GetHoles()[1]; !reflect.DeepEqual(got, &Hole{Center: &Point{X: 200, Y: 150}, Radius: 50}) {
+		t.Errorf("GetHoles() got %+v", got)
+	}
+	if _, err := d.Draw(true); err != nil {
+		t.Error(err)
+	}
+	if err := d.AddHoles(&Hole{Center: &Point{X: 390, Y: 150}, Radius: 50}); err == nil {
+		t.Error("AddHoles() with a hole out of the drawing must return an error")
+	}
+	if err := d.SetPoint(2, NewPoint(150, 150)); err == nil {
+		t.Error("SetPoint() leaving a hole out of the drawing must return an error")
+	}
+	if err := d.AddPoints(NewPoint(300, 250)); err == nil {
+		t.Error("AddPoints() leaving a hole out of the drawing must return an error")
+	}
+	if d.Len() != 4 || d.Area() != 10.96 {
+		t.Errorf("Points with errors changed the drawing to %v", d.Points)
+	}
+	if err := d.RemoveHole(0); err != nil || len(d.Holes) != 1 {
+		t.Errorf("RemoveHole() error = %v, got %d holes", err, len(d.Holes))
+	}
+	if err := d.RemoveHole(1); !errors.Is(err, ErrHoleNotFound) {
+		t.Errorf("RemoveHole() error = %v, want %v", err, ErrHoleNotFound)
+	}
+}
+
+func TestGGDrawing_ShrinkagePattern(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 300), NewPoint(400, 300), NewPoint(400, 0)); err != nil {
+		t.Error(err)
+		return
+	}
+	pattern, err := d.ShrinkagePattern(10, 5, 90)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := []*Point{{X: 0, Y: 0}, {X: 0, Y: 270}, {X: 380, Y: 270}, {X: 380, Y: 0}}
+	if got := pattern.GetPoints(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ShrinkagePattern() points = %v, want %v", got, want)
+	}
+	if d.Polygon.Width() != 4 {
+		t.Errorf("ShrinkagePattern() changed the drawing")
+	}
+	if _, err := pattern.Draw(true); err != nil {
+		t.Error(err)
+	}
+	if _, err := d.ShrinkagePattern(100, 5, 0); err == nil {
+		t.Error("ShrinkagePattern() with 100% shrinkage must return an error")
+	}
+}
+
+func TestGGDrawing_Layout(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 400), NewPoint(600, 400), NewPoint(600, 0)); err != nil {
+		t.Error(err)
+		return
+	}
+	if layout, err := d.GetLayout(); layout != nil || err != nil {
+		t.Errorf("GetLayout() without rolls = %v, %v", layout, err)
+	}
+	short := NewEmptyGGDrawing()
+	short.AddPoint(0, 0)
+	short.AddPoint(0, 400)
+	if err := short.SetRolls(&Rolls{Widths: []float64{320}}); err != nil {
+		t.Errorf("SetRolls() with 2 points error = %v", err)
+	}
+	if layout, err := short.GetLayout(); layout != nil || err != nil {
+		t.Errorf("GetLayout() with 2 points = %v, %v", layout, err)
+	}
+	if err := d.SetRolls(&Rolls{Widths: []float64{-320}}); err == nil {
+		t.Error("SetRolls() with negative width must return an error")
+	}
+	if err := d.SetRolls(&Rolls{Widths: []float64{320, 200}}); err != nil {
+		t.Error(err)
+		return
+	}
+	if got := d.GetRolls(); !reflect.DeepEqual(got, &Rolls{Widths: []float64{320, 200}}) {
+		t.Errorf("GetRolls() = %+v", got)
+	}
+	layout, err := d.GetLayout()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := &Layout{
+		Strips:   []*Strip{{RollWidth: 200, Width: 200, Length: 600, Material: 12}, {RollWidth: 200, Width: 200, Length: 600, Material: 12}},
+		Seams:    []*Segment{{A: &Point{X: 0, Y: 200}, B: &Point{X: 600, Y: 200}}},
+		Material: 24,
+	}
+	if !reflect.DeepEqual(layout, want) {
+		t.Errorf("GetLayout() = %+v, want %+v", layout, want)
+	}
+	if _, err := d.Draw(true); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGGDrawing_EstimateInput(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if _, err := d.EstimateInput(); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("EstimateInput() error = %v, want %v", err, ErrTooFewPoints)
+	}
+	err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 400), NewPoint(600, 400), NewPoint(600, 200),
+		NewPoint(300, 200), NewPoint(300, 0))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := d.AddHoles(&Hole{Points: []*Point{{X: 50, Y: 50}, {X: 50, Y: 100}, {X: 100, Y: 100}, {X: 100, Y: 50}}}); err != nil {
+		t.Error(err)
+		return
+	}
+	err = d.AddFixtures(&Fixture{Type: FixtureLight, X: 100, Y: 300}, &Fixture{Type: FixtureChandelier, X: 200, Y: 300},
+		&Fixture{Type: FixtureFan, X: 500, Y: 300}, &Fixture{Type: FixtureLight, X: 400, Y: 300},
+		&Fixture{Type: FixturePipe, X: 250, Y: 50, Diameter: 10})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	in, err := d.EstimateInput()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := &estimate.Input{Canvas: 18, Area: 17.75, Perimeter: 20, InnerCorners: 5, OuterCorners: 1, Fixtures: 4, PipeBypasses: 1}
+	if !reflect.DeepEqual(in, want) {
+		t.Errorf("EstimateInput() = %+v, want %+v", in, want)
+	}
+	if err := d.SetRolls(&Rolls{Widths: []float64{320}}); err != nil {
+		t.Error(err)
+		return
+	}
+	if in, err = d.EstimateInput(); err != nil || in.Canvas != 38.4 {
+		t.Errorf("EstimateInput() with rolls = %+v, %v, want canvas 38.4", in, err)
+	}
+	sheet := &QuoteSheet{
+		Title:       "Estimate",
+		Quote:       (&estimate.PriceList{Canvas: 10, Profile: 3}).Estimate(in),
+		Description: d.Description,
+	}
+	for _, drawDesc := range []bool{true, false} {
+		imgBytes, err := sheet.Draw(drawDesc)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if _, err := png.Decode(bytes.NewReader(imgBytes)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestGGDrawing_Fixtures(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 400), NewPoint(600, 400), NewPoint(600, 0)); err != nil {
+		t.Error(err)
+		return
+	}
+	err := d.AddFixtures(
+		&Fixture{Type: FixtureLight, X: 100, Y: 300},
+		&Fixture{Type: FixturePipe, Diameter: 11, Offsets: []*WallOffset{{Side: 0, Distance: 50}, {Side: 3, Distance: 20}}},
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := &Fixture{Type: FixturePipe, X: 50, Y: 20, Diameter: 11, Offsets: []*WallOffset{{Side: 0, Distance: 50}, {Side: 3, Distance: 20}}}
+	if got := d.GetFixtures()[1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFixtures() got %+v, want %+v", got, want)
+	}
+	if got := d.FixturesCount(); !reflect.DeepEqual(got, map[FixtureType]int{FixtureLight: 1, FixturePipe: 1}) {
+		t.Errorf("FixturesCount() = %v", got)
+	}
+	if err := d.SetFixture(0, &Fixture{Type: FixtureFan, X: 300, Y: 200, Diameter: 120}); err != nil {
+		t.Error(err)
+	}
+	for _, drawDesc := range []bool{true, false} {
+		if _, err := d.Draw(drawDesc); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := d.SetPoint(0, NewPoint(0, -100)); err != nil {
+		t.Error(err)
+	}
+	if err := d.SetPoint(3, NewPoint(600, -100)); err != nil {
+		t.Error(err)
+	}
+	if got := d.GetFixtures()[1]; got.X != 50 || got.Y != -80 {
+		t.Errorf("SetPoint() fixture isn't placed by moved walls, got (%v;%v)", got.X, got.Y)
+	}
+	if err := d.SetPoint(2, NewPoint(200, 100)); err == nil {
+		t.Error("SetPoint() leaving a fixture out of the drawing must return an error")
+	}
+	if p := d.GetPoints()[2]; p.X != 600 || p.Y != 400 {
+		t.Errorf("SetPoint() with error changed the point to %v", p)
+	}
+	if err := d.AddFixtures(&Fixture{Type: FixtureLight, X: 700, Y: 300}); err == nil {
+		t.Error("AddFixtures() with a fixture out of the drawing must return an error")
+	}
+	if err := d.RemoveFixture(0); err != nil || len(d.Fixtures) != 1 {
+		t.Errorf("RemoveFixture() error = %v, got %d fixtures", err, len(d.Fixtures))
+	}
+	if err := d.RemoveFixture(1); !errors.Is(err, ErrFixtureNotFound) {
+		t.Errorf("RemoveFixture() error = %v, want %v", err, ErrFixtureNotFound)
+	}
+}
+
+func TestGGDrawing_LightingGrid(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 400), NewPoint(600, 400), NewPoint(600, 0)); err != nil {
+		t.Error(err)
+		return
+	}
+	got, err := d.LightingGrid(&LightingGrid{Spacing: 100, Clearance: 50, Diameter: 8}, false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := &Fixture{Type: FixtureLight, X: 50, Y: 50, Diameter: 8}
+	if len(got) != 24 || !reflect.DeepEqual(got[0], want) {
+		t.Errorf("LightingGrid() got %d fixtures, the first %+v, want 24 and %+v", len(got), got[0], want)
+	}
+	if len(d.Fixtures) != 0 {
+		t.Errorf("LightingGrid() without writing added %d fixtures", len(d.Fixtures))
+	}
+	if _, err := d.LightingGrid(&LightingGrid{Count: 6, Clearance: 50}, true); err != nil || len(d.Fixtures) != 6 {
+		t.Errorf("LightingGrid() with writing error = %v, got %d fixtures, want 6", err, len(d.Fixtures))
+	}
+}
+
+func TestGGDrawing_Tiles(t *testing.T) {
+	short := NewEmptyGGDrawing()
+	short.AddPoint(0, 0)
+	short.AddPoint(0, 100)
+	if err := short.SetTiles(&Tiles{Width: 60, Length: 60}); err != nil {
+		t.Errorf("SetTiles() with 2 points error = %v", err)
+	}
+	if layout, err := short.GetTileLayout(); layout != nil || err != nil {
+		t.Errorf("GetTileLayout() with 2 points = %v, %v", layout, err)
+	}
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 100), NewPoint(200, 100), NewPoint(200, 0)); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := d.SetTiles(&Tiles{Width: 60, Length: 60}); err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := d.GetTiles(), (&Tiles{Width: 60, Length: 60}); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetTiles() got %+v, want %+v", got, want)
+	}
+	layout, err := d.GetTileLayout()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if layout.FullTiles != 3 || layout.CutTiles != 5 || layout.MainRunners != 2 || layout.CrossTees != 3 {
+		t.Errorf("GetTileLayout() got %+v", layout)
+	}
+	if want := (&TileCut{Points: []*Point{{X: 180, Y: 60}, {X: 180, Y: 100}, {X: 200, Y: 100}, {X: 200, Y: 60}}, Area: 0.08}); !reflect.DeepEqual(layout.Cuts[4], want) {
+		t.Errorf("GetTileLayout() the last cut %+v, want %+v", layout.Cuts[4], want)
+	}
+	for _, drawDesc := range []bool{true, false} {
+		if _, err := d.Draw(drawDesc); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := d.SetTiles(&Tiles{Width: -60, Length: 60}); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("SetTiles() error = %v, want %v", err, ErrWrongMeasurement)
+	}
+	if _ = d.SetTiles(nil); d.GetTiles() != nil {
+		t.Error("SetTiles(nil) didn't remove tiles")
+	}
+}
+
+func TestGGDrawing_Levels(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 400), NewPoint(600, 400), NewPoint(600, 0)); err != nil {
+		t.Error(err)
+		return
+	}
+	err := d.AddLevels(
+		&Level{Name: "Bulkhead", Drop: 10, Points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 400}, {X: 100, Y: 400}, {X: 100, Y: 0}}},
+		&Level{Name: "Island", Drop: 20, Points: []*Point{{X: 200, Y: 100}, {X: 200, Y: 300},
+			{X: 500, Y: 300, Arc: &Arc{Sagitta: 50}}, {X: 500, Y: 100}}},
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := []*LevelSummary{
+		{Name: MainLevel, Area: 14, Perimeter: 20},
+		{Name: "Bulkhead", Parent: MainLevel, Drop: 10, Area: 4, Perimeter: 10, Transition: 4, TransitionArea: 0.4},
+	}
+	if got := d.LevelsSummary(); len(got) != 3 || !reflect.DeepEqual(got[1], want[1]) || got[0].Area >= want[0].Area {
+		t.Errorf("LevelsSummary() got %+v, want %+v and the island", got, want)
+	}
+	for _, drawDesc := range []bool{true, false} {
+		if _, err := d.Draw(drawDesc); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := d.AddLevels(&Level{Name: "Cross", Drop: 5, Points: []*Point{{X: 300, Y: 0}, {X: 300, Y: 400}, {X: 400, Y: 400}, {X: 400, Y: 0}}}); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("AddLevels() error = %v, want %v", err, ErrWrongMeasurement)
+	}
+	if err := d.SetLevel(1, &Level{Name: "Island", Drop: 15, Points: []*Point{{X: 200, Y: 100}, {X: 200, Y: 300}, {X: 500, Y: 300}, {X: 500, Y: 100}}}); err != nil {
+		t.Error(err)
+	}
+	if got := d.GetLevels()[1]; got.Drop != 15 || len(d.Levels) != 2 {
+		t.Errorf("SetLevel() got %+v", got)
+	}
+	if err := d.RemoveLevel(2); !errors.Is(err, ErrLevelNotFound) {
+		t.Errorf("RemoveLevel() error = %v, want %v", err, ErrLevelNotFound)
+	}
+	if err := d.RemoveLevel(0); err != nil || len(d.Levels) != 1 {
+		t.Errorf("RemoveLevel() error = %v, got %d levels", err, len(d.Levels))
+	}
+}
+
+func TestGGDrawing_GetCorners(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 400), NewPoint(200, 400), NewPoint(200, 200),
+		NewPoint(600, 200), NewPoint(600, 0)); err != nil {
+		t.Error(err)
+		return
+	}
+	want := []*Corner{
+		{Angle: 90, Type: CornerInner}, {Angle: 90, Type: CornerInner}, {Angle: 90, Type: CornerInner},
+		{Angle: 270, Type: CornerOuter}, {Angle: 90, Type: CornerInner}, {Angle: 90, Type: CornerInner},
+	}
+	got := d.GetCorners()
+	if len(got) != len(want) {
+		t.Fatalf("GetCorners() got %d corners, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Angle != want[i].Angle || got[i].Type != want[i].Type {
+			t.Errorf("GetCorners()[%d] = %v %v, want %v %v", i, got[i].Angle, got[i].Type, want[i].Angle, want[i].Type)
+		}
+	}
+	d.ShowAngles(true)
+	for _, drawDesc := range []bool{true, false} {
+		if _, err := d.Draw(drawDesc); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestGGDrawing_Square(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(3, 300), NewPoint(400, 302), NewPoint(401, -2)); err != nil {
+		t.Error(err)
+		return
+	}
+	squared, report, err := d.Square(&Squaring{Tolerance: 2})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if report.SnappedSides != 4 || len(report.Shifts) != 4 || report.MaxShift <= 0 || report.MaxShift > 5 {
+		t.Errorf("Square() got report %+v", report)
+	}
+	for i, c := range squared.GetCorners() {
+		if c.Angle != 90 {
+			t.Errorf("Square() got angle %v at the point %d, want 90", c.Angle, i)
+		}
+	}
+	if d.Points[1].X != 0.03 {
+		t.Errorf("Square() changed the source drawing, got %v", d.Points[1].X)
+	}
+	if _, err := squared.Draw(true); err != nil {
+		t.Error(err)
+	}
+	if _, _, err := d.Square(&Squaring{Step: 30, Tolerance: 2}); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("Square() error = %v, want %v", err, ErrWrongMeasurement)
+	}
+	if err := d.AddFixtures(&Fixture{Type: FixtureLight, X: 0.5, Y: 0.5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := d.Square(&Squaring{Tolerance: 2}); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("Square() with the fixture out of the squared drawing error = %v, want %v", err, ErrWrongMeasurement)
+	}
+}
+
+func TestGGDrawing_Transform(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 100), NewPoint(200, 100), NewPoint(200, 0)); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := d.AddHoles(&Hole{Center: &Point{X: 50, Y: 50}, Radius: 10}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := d.AddFixtures(&Fixture{Type: FixtureLight, X: 150, Y: 20}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := d.SetTiles(&Tiles{Width: 60, Length: 60, OriginX: 20}); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := d.Transform(&Transform{PivotX: 100, Mirror: true, MirrorAxis: 90}); err != nil {
+		t.Error(err)
+		return
+	}
+	wantPoints := []*Point{{X: 200, Y: 0}, {X: 200, Y: 100}, {X: 0, Y: 100}, {X: 0, Y: 0}}
+	for i, p := range d.GetPoints() {
+		if p.X != wantPoints[i].X || p.Y != wantPoints[i].Y {
+			t.Errorf("Transform() got the point %d (%v;%v), want (%v;%v)", i, p.X, p.Y, wantPoints[i].X, wantPoints[i].Y)
+		}
+	}
+	if h := d.GetHoles()[0]; h.Center.X != 150 || h.Center.Y != 50 || h.Radius != 10 {
+		t.Errorf("Transform() got the hole %+v with the center %+v", h, h.Center)
+	}
+	if f := d.GetFixtures()[0]; f.X != 50 || f.Y != 20 {
+		t.Errorf("Transform() got the fixture %+v", f)
+	}
+	if tiles := d.GetTiles(); tiles.OriginX != 180 || tiles.Direction != 180 {
+		t.Errorf("Transform() got tiles %+v", tiles)
+	}
+	if err := d.Transform(&Transform{Angle: 90, Scale: 2}); err != nil {
+		t.Error(err)
+		return
+	}
+	if p := d.GetPoints()[1]; p.X != -200 || p.Y != 400 || d.Area() != 7.87 {
+		t.Errorf("Transform() got the point (%v;%v) and the area %v", p.X, p.Y, d.Area())
+	}
+	if err := d.Transform(&Transform{Scale: -1}); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("Transform() error = %v, want %v", err, ErrWrongMeasurement)
+	}
+}
+
+func TestGGDrawing_Offset(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 300), NewPoint(400, 300), NewPoint(400, 0)); err != nil {
+		t.Error(err)
+		return
+	}
+	points, perimeter, err := d.Offset(&Offset{Distance: -5})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want := []*Point{{X: 5, Y: 5}, {X: 5, Y: 295}, {X: 395, Y: 295}, {X: 395, Y: 5}}
+	if !reflect.DeepEqual(points, want) || perimeter != 13.6 {
+		t.Errorf("Offset() got points %v and perimeter %v, want %v and 13.6", points, perimeter, want)
+	}
+	if err := d.ShowOffset(&Offset{Distance: 10, Join: OffsetRound}); err != nil {
+		t.Error(err)
+		return
+	}
+	if _, err := d.Draw(true); err != nil {
+		t.Error(err)
+	}
+	if err := d.ShowOffset(&Offset{Distance: -200}); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("ShowOffset() error = %v, want %v", err, ErrWrongMeasurement)
+	}
+}
+
+func TestMergeGGDrawings(t *testing.T) {
+	room := func(x1, x2 float64) *GGDrawing {
+		d := NewEmptyGGDrawing()
+		if err := d.AddPoints(NewPoint(x1, 0), NewPoint(x1, 200), NewPoint(x2, 200), NewPoint(x2, 0)); err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	a, b := room(0, 300), room(300, 500)
+	if err := b.AddFixtures(&Fixture{Type: FixtureLight, Offsets: []*WallOffset{{Side: 0, Distance: 50},
+		{Side: 1, Distance: 100}}}); err != nil {
+		t.Fatal(err)
+	}
+	merged, err := MergeGGDrawings(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Len() != 4 || merged.Area() != 10 {
+		t.Errorf("MergeGGDrawings() got %d points and the area %v, want 4 and 10", merged.Len(), merged.Area())
+	}
+	if f := merged.GetFixtures(); len(f) != 1 || f[0].X != 350 || f[0].Y != 100 || f[0].Offsets != nil {
+		t.Errorf("MergeGGDrawings() got fixtures %v", f)
+	}
+	if _, err := MergeGGDrawings(a, room(400, 500)); !errors.Is(err, ErrNotMergeable) {
+		t.Errorf("MergeGGDrawings() error = %v, want %v", err, ErrNotMergeable)
+	}
+	c, e := room(0, 300), room(200, 500)
+	for _, d := range []*GGDrawing{c, e} {
+		if err := d.AddHoles(&Hole{Center: &Point{X: 250, Y: 100}, Radius: 20}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	merged, err = MergeGGDrawings(c, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged.Holes) != 1 || merged.Area() != 9.87 {
+		t.Errorf("MergeGGDrawings() of the same hole got %d holes and the area %v, want 1 and 9.87",
+			len(merged.Holes), merged.Area())
+	}
+	if err := e.SetHole(0, &Hole{Center: &Point{X: 260, Y: 100}, Radius: 20}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MergeGGDrawings(c, e); !errors.Is(err, ErrNotMergeable) {
+		t.Errorf("MergeGGDrawings() of overlapping holes error = %v, want %v", err, ErrNotMergeable)
+	}
+}
+
+func TestGGDrawing_Split(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 200), NewPoint(500, 200), NewPoint(500, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.AddHoles(&Hole{Center: &Point{X: 400, Y: 100}, Radius: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.AddFixtures(&Fixture{Type: FixtureLight, X: 100, Y: 100}); err != nil {
+		t.Fatal(err)
+	}
+	parts, err := d.Split(&SplitLine{A: &Point{X: 300, Y: 200}, B: &Point{X: 300, Y: 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 2 || parts[0].Area() != 3.97 || parts[1].Area() != 6 {
+		t.Fatalf("Split() got %d parts", len(parts))
+	}
+	if len(parts[0].Holes) != 1 || len(parts[0].Fixtures) != 0 || len(parts[1].Holes) != 0 || len(parts[1].Fixtures) != 1 {
+		t.Errorf("Split() got holes and fixtures in wrong parts")
+	}
+	if _, err := d.Split(&SplitLine{A: &Point{X: 400, Y: 200}, B: &Point{X: 400, Y: 0}}); !errors.Is(err, ErrNotSplittable) {
+		t.Errorf("Split() error = %v, want %v", err, ErrNotSplittable)
+	}
+}
+
+func TestGGDrawing_GetTriangulation(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 300), NewPoint(400, 300), NewPoint(400, 0)); err != nil {
+		t.Fatal(err)
+	}
+	report, err := d.GetTriangulation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Triangles) != 2 || report.Area != 12 || report.Triangles[0].Area != 6 {
+		t.Errorf("GetTriangulation() got %d triangles with the area %v", len(report.Triangles), report.Area)
+	}
+	for _, tr := range report.Triangles {
+		if sides := tr.Sides; sides[0]+sides[1]+sides[2] != 1200 {
+			t.Errorf("GetTriangulation() got sides %v, want 300, 400 and 500", sides)
+		}
+	}
+	d.ShowTriangles(true)
+	if _, err := d.Draw(true); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGGDrawing_Query(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 300), NewPoint(400, 300), NewPoint(400, 0)); err != nil {
+		t.Fatal(err)
+	}
+	q, err := d.Query(NewPoint(100, 50), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Location != PointInside || q.Vertex != 0 || q.VertexDistance != 111.8 {
+		t.Errorf("Query() = %v, vertex %d (%v), want inside, vertex 0 (111.8)", q.Location, q.Vertex, q.VertexDistance)
+	}
+	if w := q.Walls[0]; w.Side != 3 || w.Distance != 50 || w.Foot.X != 100 || w.Foot.Y != 0 {
+		t.Errorf("Query() nearest wall = %+v, foot %+v, want side 3 at 50", w, w.Foot)
+	}
+	if q, err = d.Query(NewPoint(401, 100), 2); err != nil || q.Location != PointOnWall {
+		t.Errorf("Query() = %v, %v, want on the wall", q, err)
+	}
+}
+
+func TestGGDrawing_GetOrientedBoundingBox(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	// the room 400x300 drawn at 30 degrees
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(-150, 259.81), NewPoint(196.41, 459.81), NewPoint(346.41, 200)); err != nil {
+		t.Fatal(err)
+	}
+	if box := d.GetOrientedBoundingBox(); box == nil || box.Width != 400 || box.Height != 300 || box.Direction != 30 {
+		t.Errorf("GetOrientedBoundingBox() = %+v, want 400x300 at 30 degrees", box)
+	}
+	if box := d.GetBoundingBox(); box == nil || box.Width != 496.41 || box.Height != 459.81 || box.Direction != 0 {
+		t.Errorf("GetBoundingBox() = %+v, want 496.41x459.81", box)
+	}
+	if c := d.GetCentroid(); c == nil || c.X != 98.21 || c.Y != 229.91 {
+		t.Errorf("GetCentroid() = %+v, want (98.21;229.91)", c)
+	}
+	if a := d.GetPrincipalAxis(); a == nil || a.Length != 400 || a.Direction != 30 {
+		t.Errorf("GetPrincipalAxis() = %+v, want 400 at 30 degrees", a)
+	}
+}
+
+func TestGGDrawing_Simplify(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 150), NewPoint(1, 300), NewPoint(400, 300), NewPoint(400, 0),
+		NewPoint(399.5, 0)); err != nil {
+		t.Fatal(err)
+	}
+	simplified, report, err := d.Simplify(&Simplification{Tolerance: 2, MergeDistance: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if simplified.Len() != 4 || len(report.Merged) != 1 || len(report.Removed) != 1 || report.MaxDeviation != 0.5 {
+		t.Errorf("Simplify() got %d points, report %+v", simplified.Len(), report)
+	}
+	if d.Len() != 6 {
+		t.Errorf("Simplify() changed the source drawing, got %d points", d.Len())
+	}
+	if _, _, err := d.Simplify(&Simplification{}); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("Simplify() error = %v, want %v", err, ErrWrongMeasurement)
+	}
+	if err := d.AddFixtures(&Fixture{Type: FixtureLight, X: 0.2, Y: 150}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := d.Simplify(&Simplification{Tolerance: 2, MergeDistance: 1}); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("Simplify() with the fixture out of the simplified drawing error = %v, want %v", err, ErrWrongMeasurement)
+	}
+}
+
+func TestGGDrawing_ReorderPoints(t *testing.T) {
+	d := NewEmptyGGDrawing()
+	if err := d.AddPoints(NewPoint(0, 0), NewPoint(0, 300), NewPoint(400, 300), NewPoint(400, 0)); err != nil {
+		t.Fatal(err)
+	}
+	fixture := &Fixture{Type: FixtureLight, Offsets: []*WallOffset{{Side: 0, Distance: 100}, {Side: 1, Distance: 50}}}
+	if err := d.AddFixtures(fixture); err != nil {
+		t.Fatal(err)
+	}
+	sides := func() []int {
+		s := make([]int, 0)
+		for _, o := range fixture.Offsets {
+			s = append(s, o.Side)
+		}
+		return s
+	}
+	steps := []struct {
+		name      string
+		do        func() error
+		wantPoint *Point
+		wantSides []int
+	}{
+		{
+			name:      "Reverse",
+			do:        d.ReversePoints,
+			wantPoint: &Point{X: 4, Y: 0},
+			wantSides: []int{3, 2},
+		},
+		{
+			name:      "Rotate",
+			do:        func() error { return d.RotatePoints(1) },
+			wantPoint: &Point{X: 4, Y: 3},
+			wantSides: []int{2, 1},
+		},
+		{
+			name:      "Insert",
+			do:        func() error { return d.InsertPoint(1, NewPoint(450, 150), KeepCoordinates) },
+			wantPoint: &Point{X: 4.5, Y: 1.5},
+			wantSides: []int{3, 2},
+		},
+		{
+			name:      "Delete",
+			do:        func() error { return d.DeletePoint(1, RecomputeCoordinates) },
+			wantPoint: &Point{X: 4, Y: 3},
+			wantSides: []int{2, 1},
+		},
+	}
+	for _, st := range steps {
+		if err := st.do(); err != nil {
+			t.Fatalf("%s error = %v", st.name, err)
+		}
+		if p := d.Points[1]; p.X != st.wantPoint.X || p.Y != st.wantPoint.Y {
+			t.Errorf("%s second point = %v, want %v", st.name, p, st.wantPoint)
+		}
+		if got := sides(); !reflect.DeepEqual(got, st.wantSides) {
+			t.Errorf("%s sides of offsets = %v, want %v", st.name, got, st.wantSides)
+		}
+	}
+	if fixture.X != 1 || fixture.Y != 2.5 {
+		t.Errorf("fixture is moved to (%v;%v)", fixture.X, fixture.Y)
+	}
+	if err := d.InsertPoint(1, NewPoint(0, 0), "move"); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("InsertPoint() error = %v, want %v", err, ErrWrongMeasurement)
+	}
+	if err := d.DeletePoint(2, RecomputeCoordinates); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("DeletePoint() of the corner with the fixture error = %v, want %v", err, ErrWrongMeasurement)
+	}
+	if d.Len() != 4 || len(d.Fixtures[0].Offsets) != 2 {
+		t.Errorf("DeletePoint() with error changed the drawing to %v with fixtures %v", d.Points, d.Fixtures)
+	}
+}
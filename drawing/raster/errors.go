@@ -5,4 +5,9 @@ import "errors"
 var (
 	ErrWrongValueScanType = errors.New("wrong value scan type")
 	ErrTooFewPoints       = errors.New("too few points")
+	ErrHoleNotFound       = errors.New("hole not found")
+	ErrFixtureNotFound    = errors.New("fixture not found")
+	ErrLevelNotFound      = errors.New("level not found")
+	ErrNotMergeable       = errors.New("drawings can't be merged")
+	ErrNotSplittable      = errors.New("drawing can't be split")
 )
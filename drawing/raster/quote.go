@@ -0,0 +1,92 @@
+package raster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"github.com/maxsid/goCeilings/drawing"
+	"github.com/maxsid/goCeilings/estimate"
+	"golang.org/x/image/colornames"
+)
+
+const (
+	quoteWidth                   = 900
+	quoteRowHeight               = 36
+	fontSizeQuote, fontSizeTitle = 20, 28
+)
+
+// quoteColumns are titles and left borders of the quote sheet table columns.
+var quoteColumns = []struct {
+	title string
+	x     float64
+}{
+	{"Item", 0}, {"Quantity", 320}, {"Price", 480}, {"Cost", 640},
+}
+
+// QuoteSheet draws the quote as a table with the title and the description below.
+type QuoteSheet struct {
+	Title       string
+	Quote       *estimate.Quote
+	Description *drawing.Description
+}
+
+func (qs *QuoteSheet) Draw(drawDesc bool) ([]byte, error) {
+	rows := len(qs.Quote.Items) + 3
+	height := marginVertical + rows*quoteRowHeight
+	if drawDesc && qs.Description != nil {
+		height += (len(*qs.Description) + 1) * quoteRowHeight
+	}
+	ggCtx := gg.NewContext(quoteWidth, height)
+	setBackground(ggCtx)
+	ggCtx.SetColor(colornames.Black)
+	if err := setFontSize(ggCtx, fontSizeTitle); err != nil {
+		return nil, err
+	}
+	y := float64(marginTop + quoteRowHeight)
+	ggCtx.DrawString(qs.Title, marginLeft, y-quoteRowHeight/3)
+	if err := setFontSize(ggCtx, fontSizeQuote); err != nil {
+		return nil, err
+	}
+	y += quoteRowHeight
+	for _, c := range quoteColumns {
+		ggCtx.DrawString(c.title, marginLeft+c.x, y-quoteRowHeight/3)
+	}
+	qs.drawRowLine(ggCtx, y)
+	for _, item := range qs.Quote.Items {
+		y += quoteRowHeight
+		cells := []string{
+			item.Name,
+			fmt.Sprintf("%v %s", item.Quantity, item.Unit),
+			fmt.Sprintf("%v", item.Price),
+			fmt.Sprintf("%v", item.Cost),
+		}
+		for i, c := range quoteColumns {
+			ggCtx.DrawString(cells[i], marginLeft+c.x, y-quoteRowHeight/3)
+		}
+	}
+	qs.drawRowLine(ggCtx, y)
+	y += quoteRowHeight
+	ggCtx.DrawString("Total", marginLeft, y-quoteRowHeight/3)
+	ggCtx.DrawString(strings.TrimSpace(fmt.Sprintf("%v %s", qs.Quote.Total, qs.Quote.Currency)),
+		marginLeft+quoteColumns[len(quoteColumns)-1].x, y-quoteRowHeight/3)
+	if drawDesc && qs.Description != nil {
+		s := strings.Join(qs.Description.ToStringSlice(), "\n")
+		ggCtx.DrawStringWrapped(s, marginLeft, y+quoteRowHeight, 0, 0, quoteWidth-marginHorizontal, 1.5, gg.AlignLeft)
+	}
+	return contextToPNGBytes(ggCtx)
+}
+
+func (qs *QuoteSheet) DrawingMIME() string {
+	return "image/png"
+}
+
+func (qs *QuoteSheet) GetDrawer() drawing.Drawer {
+	return qs
+}
+
+func (qs *QuoteSheet) drawRowLine(ggCtx *gg.Context, y float64) {
+	ggCtx.SetLineWidth(1)
+	ggCtx.DrawLine(marginLeft, y, quoteWidth-marginRight, y)
+	ggCtx.Stroke()
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"strings"
 
 	svg "github.com/ajstarks/svgo/float"
@@ -19,10 +20,19 @@ const (
 	drawingWidth, drawingHeight                    float64 = 1200, 800
 	pointSize                                      float64 = 2
 	marginLetterX, marginLetterY                   float64 = 4, 20
+	fixtureSize                                    float64 = 5
+	angleArcRadius, angleTitleDistance             float64 = 15, 30
 )
 
+// levelsColors are fill colors of levels, they are repeated for many levels.
+var levelsColors = []string{"#1e90ff30", "#ffa50030", "#32cd3230", "#ba55d330", "#ff634730"}
+
 type SVGDrawing struct {
 	Points                          []*Point
+	Fixtures                        []*Fixture
+	Tiles                           *Tiles
+	Levels                          []*Level
+	Angles                          bool
 	Notes                           []string
 	lengthMeasure, perimeterMeasure Measure
 	areaMeasure, angleMeasure       Measure
@@ -31,6 +41,8 @@ type SVGDrawing struct {
 func NewDrawing() *SVGDrawing {
 	return &SVGDrawing{
 		Points:           make([]*Point, 0),
+		Fixtures:         make([]*Fixture, 0),
+		Levels:           make([]*Level, 0),
 		Notes:            make([]string, 0),
 		lengthMeasure:    Centimetre,
 		perimeterMeasure: Metre,
@@ -43,6 +55,11 @@ func (d *SVGDrawing) Draw(wr io.Writer) {
 	d.addAreaToNotes()
 	d.addPerimeterToNotes()
 	d.addSidesToNotes()
+	d.addCornersToNotes()
+	tileLayout := d.tileLayout()
+	d.addTilesToNotes(tileLayout)
+	d.addLevelsToNotes()
+	d.addFixturesToNotes()
 	d.addPointsToNotes()
 	xs, ys := d.getXYs()
 	scale := d.calcScale()
@@ -51,9 +68,13 @@ func (d *SVGDrawing) Draw(wr io.Writer) {
 	canvas.Translate(marginLeft, marginTop)
 	canvas.Scale(scale)
 	canvas.Group()
-	canvas.Polygon(xs, ys, `stroke="#ff0000"`, `fill="#00000012"`, `stroke-width="2"`)
+	d.drawLevels(canvas)
+	d.drawTiles(canvas, tileLayout)
+	canvas.Path(d.getOutlinePath(xs, ys), `stroke="#ff0000"`, `fill="#00000012"`, `stroke-width="2"`)
 	d.drawPoints(canvas, xs, ys)
 	d.drawSidesLengths(canvas, xs, ys)
+	d.drawFixtures(canvas)
+	d.drawAngles(canvas)
 	canvas.Gend()
 	canvas.Group()
 	d.drawNotes(canvas)
@@ -74,15 +95,58 @@ func (d *SVGDrawing) getXYs() ([]float64, []float64) {
 	return xs, ys
 }
 
+// getOutlinePath returns SVG path data of the polygon outline, where curved sides are drawn with arcs.
+func (d *SVGDrawing) getOutlinePath(xs, ys []float64) string {
+	return d.polygonPath(&Polygon{Points: d.Points}, xs, ys)
+}
+
+// polygonPath returns SVG path data of the polygon by its image coordinates xs and ys.
+func (d *SVGDrawing) polygonPath(pol *Polygon, xs, ys []float64) string {
+	xsLen := len(xs)
+	path := []string{fmt.Sprintf("M%v %v", xs[0], ys[0])}
+	for i := 0; i < xsLen; i++ {
+		x, y := xs[(i+1)%xsLen], ys[(i+1)%xsLen]
+		cs, _ := pol.CurvedSide(i)
+		if cs == nil {
+			path = append(path, fmt.Sprintf("L%v %v", x, y))
+			continue
+		}
+		// Y axis of the image is inverted, so counterclockwise arcs have the positive sweep flag
+		large, sweep := 0, 0
+		if math.Abs(cs.Sweep) > math.Pi {
+			large = 1
+		}
+		if cs.Sweep > 0 {
+			sweep = 1
+		}
+		r := ConvertFromOneRound(d.lengthMeasure, cs.Radius, 2)
+		path = append(path, fmt.Sprintf("A%v %v 0 %d %d %v %v", r, r, large, sweep, x, y))
+	}
+	return strings.Join(path, " ") + " Z"
+}
+
 func (d *SVGDrawing) drawSidesLengths(canvas *svg.SVG, xs, ys []float64) {
 	xsLen, pol := len(xs), Polygon{Points: d.Points}
-	sides := pol.Sides()
 	for i := 0; i < xsLen; i++ {
 		x1, y1, x2, y2 := xs[i], ys[i], xs[(i+1)%xsLen], ys[(i+1)%xsLen]
 		x, y := (x1+x2)/2, (y1+y2)/2
-		dist := ConvertFromOneRound(d.lengthMeasure, sides[i].Distance(), 2)
-		canvas.Text(x, y, fmt.Sprintf("%v", dist))
+		if cs, _ := pol.CurvedSide(i); cs != nil {
+			// the title of the arc is placed on its middle
+			m := cs.PointAt(0.5)
+			x, y = ConvertFromOneRound(d.lengthMeasure, m.X, 2), ConvertFromOneRound(d.lengthMeasure, pol.Height()-m.Y, 2)
+		}
+		canvas.Text(x, y, d.sideTitle(&pol, i))
+	}
+}
+
+// sideTitle returns length of the side by index. Length of a curved side is the length of its arc
+// and the radius is added.
+func (d *SVGDrawing) sideTitle(pol *Polygon, i int) string {
+	if cs, _ := pol.CurvedSide(i); cs != nil {
+		return fmt.Sprintf("%v (R%v)", ConvertFromOneRound(d.lengthMeasure, cs.Length(), 2),
+			ConvertFromOneRound(d.lengthMeasure, cs.Radius, 2))
 	}
+	return fmt.Sprint(ConvertFromOneRound(d.lengthMeasure, pol.Sides()[i].Distance(), 2))
 }
 
 func (d *SVGDrawing) drawPoints(canvas *svg.SVG, xs, ys []float64) {
@@ -93,6 +157,116 @@ func (d *SVGDrawing) drawPoints(canvas *svg.SVG, xs, ys []float64) {
 	}
 }
 
+// tileLayout returns the layout of tiles or nil if tiles aren't set or they can't be placed.
+func (d *SVGDrawing) tileLayout() *TileLayout {
+	if d.Tiles == nil {
+		return nil
+	}
+	layout, err := (&Polygon{Points: d.Points}).TileLayout(d.Tiles)
+	if err != nil {
+		return nil
+	}
+	return layout
+}
+
+// drawLevels draws levels of the multi-level ceiling by dashed outlines with distinct fill colors.
+func (d *SVGDrawing) drawLevels(canvas *svg.SVG) {
+	polHeight := (&Polygon{Points: d.Points}).Height()
+	for i, l := range d.Levels {
+		xs, ys := make([]float64, len(l.Points)), make([]float64, len(l.Points))
+		for j, p := range l.Points {
+			xs[j] = ConvertFromOneRound(d.lengthMeasure, p.X, 2)
+			ys[j] = ConvertFromOneRound(d.lengthMeasure, polHeight-p.Y, 2)
+		}
+		canvas.Path(d.polygonPath(l.Polygon(), xs, ys), `stroke="#505050"`, `stroke-dasharray="8"`,
+			fmt.Sprintf(`fill="%s"`, levelsColors[i%len(levelsColors)]))
+	}
+}
+
+// drawTiles draws main runners and cross tees of the suspended ceiling grid.
+func (d *SVGDrawing) drawTiles(canvas *svg.SVG, layout *TileLayout) {
+	if layout == nil {
+		return
+	}
+	polHeight := (&Polygon{Points: d.Points}).Height()
+	for _, lines := range []struct {
+		segments []*Segment
+		style    string
+	}{{layout.Tees, `stroke="lightsteelblue"`}, {layout.Runners, `stroke="steelblue"`}} {
+		for _, s := range lines.segments {
+			canvas.Line(ConvertFromOneRound(d.lengthMeasure, s.A.X, 2), ConvertFromOneRound(d.lengthMeasure, polHeight-s.A.Y, 2),
+				ConvertFromOneRound(d.lengthMeasure, s.B.X, 2), ConvertFromOneRound(d.lengthMeasure, polHeight-s.B.Y, 2), lines.style)
+		}
+	}
+}
+
+// drawFixtures draws symbols of fixtures and dimension lines with distances to their nearest walls.
+func (d *SVGDrawing) drawFixtures(canvas *svg.SVG) {
+	pol := Polygon{Points: d.Points}
+	polHeight := pol.Height()
+	xy := func(p *Point) (float64, float64) {
+		return ConvertFromOneRound(d.lengthMeasure, p.X, 2), ConvertFromOneRound(d.lengthMeasure, polHeight-p.Y, 2)
+	}
+	for _, f := range d.Fixtures {
+		x, y := xy(f.Center())
+		for _, w := range pol.NearestWalls(f.Center(), 2) {
+			fx, fy := xy(w.Foot)
+			canvas.Line(x, y, fx, fy, `stroke="gray"`, `stroke-dasharray="4"`)
+			canvas.Text((x+fx)/2, (y+fy)/2, fmt.Sprint(ConvertFromOneRound(d.lengthMeasure, w.Distance, 2)), `fill="gray"`)
+		}
+		r := math.Max(ConvertFromOne(d.lengthMeasure, f.Diameter)/2, fixtureSize)
+		style := `stroke="darkorange"`
+		switch f.Type {
+		case FixturePipe:
+			canvas.Circle(x, y, r, `fill="darkorange"`)
+		case FixtureLight:
+			canvas.Circle(x, y, r, style, `fill="none"`)
+			c := r / math.Sqrt2
+			canvas.Line(x-c, y-c, x+c, y+c, style)
+			canvas.Line(x-c, y+c, x+c, y-c, style)
+		case FixtureChandelier:
+			canvas.Circle(x, y, r, style, `fill="none"`)
+			canvas.Circle(x, y, r/2, style, `fill="none"`)
+			canvas.Line(x-r, y, x+r, y, style)
+			canvas.Line(x, y-r, x, y+r, style)
+		case FixtureFan:
+			canvas.Circle(x, y, r, style, `fill="none"`)
+			for i := 0; i < 3; i++ {
+				sin, cos := math.Sincos(math.Pi * 2 / 3 * float64(i))
+				canvas.Line(x, y, x+r*cos, y+r*sin, style)
+			}
+		}
+	}
+}
+
+// drawAngles draws arcs of interior angles with their values at vertexes, which aren't on the straight line,
+// if Angles is on.
+func (d *SVGDrawing) drawAngles(canvas *svg.SVG) {
+	if !d.Angles {
+		return
+	}
+	pol := Polygon{Points: d.Points}
+	xs, ys := d.getXYs()
+	for i, c := range pol.Corners() {
+		if c.Type == CornerStraight {
+			continue
+		}
+		// Y axis of the image is inverted, so the arc going counterclockwise from the start has the zero sweep flag
+		start, end := c.Bisector-c.Angle/2, c.Bisector+c.Angle/2
+		large := 0
+		if c.Angle > math.Pi {
+			large = 1
+		}
+		canvas.Path(fmt.Sprintf("M%v %v A%v %v 0 %d 0 %v %v",
+			Round(xs[i]+angleArcRadius*math.Cos(start), 2), Round(ys[i]-angleArcRadius*math.Sin(start), 2),
+			angleArcRadius, angleArcRadius, large,
+			Round(xs[i]+angleArcRadius*math.Cos(end), 2), Round(ys[i]-angleArcRadius*math.Sin(end), 2)),
+			`stroke="darkgreen"`, `fill="none"`)
+		canvas.Text(xs[i]+angleTitleDistance*math.Cos(c.Bisector), ys[i]-angleTitleDistance*math.Sin(c.Bisector),
+			fmt.Sprint(ConvertFromOneRound(d.angleMeasure, c.Angle, 2)), `fill="darkgreen"`, `text-anchor="middle"`)
+	}
+}
+
 func (d *SVGDrawing) calcScale() float64 {
 	pol := Polygon{Points: d.Points}
 	wScale := (drawingWidth - marginHorizontal) / ConvertFromOne(d.lengthMeasure, pol.Width())
@@ -108,14 +282,60 @@ func (d *SVGDrawing) addSidesToNotes() {
 	letterIter := naming.NewNameIterator('A', 'Z')
 	l1, l2, pol := letterIter.Next(), letterIter.Next(), Polygon{Points: d.Points}
 	note := make([]string, 0)
-	for _, s := range pol.Sides() {
-		dist := ConvertFromOneRound(d.lengthMeasure, s.Distance(), 2)
-		note = append(note, fmt.Sprintf("%s%s=%v", l1, l2, dist))
+	for i := range pol.Sides() {
+		note = append(note, fmt.Sprintf("%s%s=%s", l1, l2, d.sideTitle(&pol, i)))
 		l1, l2 = l2, letterIter.Next()
 	}
 	d.Notes = append(d.Notes, "Sides: "+strings.Join(note, ", "))
 }
 
+func (d *SVGDrawing) addCornersToNotes() {
+	inner, outer := (&Polygon{Points: d.Points}).CornersCount()
+	d.Notes = append(d.Notes, fmt.Sprintf("Corners: inner=%d, outer=%d", inner, outer))
+}
+
+func (d *SVGDrawing) addTilesToNotes(layout *TileLayout) {
+	if layout == nil {
+		return
+	}
+	d.Notes = append(d.Notes,
+		fmt.Sprintf("Tiles: full=%d, cut=%d", layout.FullTiles, layout.CutTiles),
+		fmt.Sprintf("Main runners: %v", ConvertFromOneRound(d.perimeterMeasure, layout.MainRunners, 2)),
+		fmt.Sprintf("Cross tees: %v", ConvertFromOneRound(d.perimeterMeasure, layout.CrossTees, 2)))
+}
+
+func (d *SVGDrawing) addLevelsToNotes() {
+	if len(d.Levels) == 0 {
+		return
+	}
+	pol := &Polygon{Points: d.Points}
+	if pol.CheckLevels(d.Levels...) != nil {
+		return
+	}
+	note := make([]string, 0, len(d.Levels)+1)
+	for _, s := range pol.LevelsSummary(d.Levels...) {
+		note = append(note, fmt.Sprintf("%s=%v (%v)", s.Name, ConvertFromOneRound(d.areaMeasure, s.Area, 2),
+			ConvertFromOneRound(d.lengthMeasure, s.Drop, 2)))
+	}
+	d.Notes = append(d.Notes, "Levels: "+strings.Join(note, ", "))
+}
+
+func (d *SVGDrawing) addFixturesToNotes() {
+	if len(d.Fixtures) == 0 {
+		return
+	}
+	counts, note := make(map[FixtureType]int), make([]string, 0)
+	for _, f := range d.Fixtures {
+		counts[f.Type]++
+	}
+	for _, t := range FixtureTypes {
+		if counts[t] > 0 {
+			note = append(note, fmt.Sprintf("%s=%d", t, counts[t]))
+		}
+	}
+	d.Notes = append(d.Notes, "Fixtures: "+strings.Join(note, ", "))
+}
+
 func (d *SVGDrawing) addPointsToNotes() {
 	letterIter := naming.NewNameIterator('A', 'Z')
 	s, pol := make([]string, 0), Polygon{Points: d.Points}
@@ -145,8 +365,8 @@ func (d *SVGDrawing) drawNotes(canvas *svg.SVG) {
 	canvas.Textlines(w+marginNotes, 0, d.Notes, 16, 20, "", "")
 }
 
-func (d *SVGDrawing) DrawBytes() (bOut []byte) {
-	buf := bytes.NewBuffer(bOut)
+func (d *SVGDrawing) DrawBytes() []byte {
+	buf := bytes.NewBuffer(nil)
 	d.Draw(buf)
-	return
+	return buf.Bytes()
 }
@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 	"testing"
 
 	. "github.com/maxsid/goCeilings/figure"
@@ -34,3 +35,66 @@ func TestDrawing_Draw(t *testing.T) {
 	_ = f.Close()
 	log.Printf("See %s file", f.Name())
 }
+
+func TestSVGDrawing_getOutlinePath(t *testing.T) {
+	draw := NewDrawing()
+	draw.Points = []*Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 2}, {X: 0, Y: 2, Arc: &Arc{Sagitta: 2, Right: true}}}
+	xs, ys := draw.getXYs()
+	want := "M0 400 L400 400 L400 200 A200 200 0 0 1 0 200 L0 400 Z"
+	if got := draw.getOutlinePath(xs, ys); got != want {
+		t.Errorf("getOutlinePath() = %v, want %v", got, want)
+	}
+}
+
+func TestSVGDrawing_Fixtures(t *testing.T) {
+	draw := NewDrawing()
+	draw.Points = []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}}
+	draw.Fixtures = []*Fixture{{Type: FixtureLight, X: 1, Y: 1}, {Type: FixtureLight, X: 3, Y: 1},
+		{Type: FixturePipe, X: 0.5, Y: 0.3, Diameter: 0.1}}
+	out := string(draw.DrawBytes())
+	if want := "Fixtures: light=2, pipe=1"; !strings.Contains(out, want) {
+		t.Errorf("Draw() notes don't contain %q", want)
+	}
+	if want := `<line x1="100.00" y1="100.00" x2="0.00" y2="100.00" stroke="gray" stroke-dasharray="4" />`; !strings.Contains(out, want) {
+		t.Errorf("Draw() doesn't contain the dimension line %s", want)
+	}
+}
+
+func TestSVGDrawing_Tiles(t *testing.T) {
+	draw := NewDrawing()
+	draw.Points = []*Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 0}}
+	draw.Tiles = &Tiles{Width: 0.6, Length: 0.6}
+	out := string(draw.DrawBytes())
+	for _, want := range []string{"Tiles: full=3, cut=5", "Main runners: 2", "Cross tees: 3",
+		`<line x1="0.00" y1="40.00" x2="200.00" y2="40.00" stroke="steelblue" />`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Draw() doesn't contain %s", want)
+		}
+	}
+}
+
+func TestSVGDrawing_Levels(t *testing.T) {
+	draw := NewDrawing()
+	draw.Points = []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 0}}
+	draw.Levels = []*Level{{Name: "Bulkhead", Drop: 0.1, Points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 1, Y: 4}, {X: 1, Y: 0}}}}
+	out := string(draw.DrawBytes())
+	for _, want := range []string{"Levels: main=20 (0), Bulkhead=4 (10)",
+		`<path d="M0 400 L0 0 L100 0 L100 400 L0 400 Z" stroke="#505050" stroke-dasharray="8" fill="#1e90ff30" />`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Draw() doesn't contain %s", want)
+		}
+	}
+}
+
+func TestSVGDrawing_Angles(t *testing.T) {
+	draw := NewDrawing()
+	draw.Points = []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 2}, {X: 6, Y: 2}, {X: 6, Y: 0}}
+	draw.Angles = true
+	out := string(draw.DrawBytes())
+	for _, want := range []string{"Corners: inner=5, outer=1", `<path d="M15 400 A15 15 0 0 0 0 385" stroke="darkgreen" fill="none" />`,
+		`<path d="M200 185 A15 15 0 1 0 215 200" stroke="darkgreen" fill="none" />`, `>270</text>`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Draw() doesn't contain %s", want)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+package estimate
+
+import "errors"
+
+var (
+	ErrNegativePrice = errors.New("price can't be negative")
+)
@@ -0,0 +1,111 @@
+package estimate
+
+import (
+	"fmt"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// costPrecision is a number of digits after the dot of costs in the quote.
+const costPrecision = 2
+
+// Names of the quote items.
+const (
+	ItemCanvas      = "Canvas"
+	ItemProfile     = "Profile"
+	ItemInnerCorner = "Inner corners"
+	ItemOuterCorner = "Outer corners"
+	ItemFixture     = "Light fixtures"
+	ItemPipeBypass  = "Pipe bypasses"
+	ItemLabour      = "Labour"
+)
+
+// Units of the quote items.
+const (
+	UnitSquareMetre = "m²"
+	UnitMetre       = "m"
+	UnitPiece       = "pcs"
+)
+
+// PriceList contains prices of the ceiling installation. Canvas and Labour are prices per square metre,
+// Profile is a price per metre of the perimeter, the rest are prices per piece.
+type PriceList struct {
+	Currency    string  `json:"currency"`
+	Canvas      float64 `json:"canvas"`
+	Profile     float64 `json:"profile"`
+	InnerCorner float64 `json:"inner_corner"`
+	OuterCorner float64 `json:"outer_corner"`
+	Fixture     float64 `json:"fixture"`
+	PipeBypass  float64 `json:"pipe_bypass"`
+	Labour      float64 `json:"labour"`
+}
+
+// Check returns an error if some price of the list is negative.
+func (pl *PriceList) Check() error {
+	prices := map[string]float64{
+		ItemCanvas: pl.Canvas, ItemProfile: pl.Profile, ItemInnerCorner: pl.InnerCorner,
+		ItemOuterCorner: pl.OuterCorner, ItemFixture: pl.Fixture, ItemPipeBypass: pl.PipeBypass, ItemLabour: pl.Labour,
+	}
+	for name, price := range prices {
+		if price < 0 {
+			return fmt.Errorf("%w: %s costs %v", ErrNegativePrice, name, price)
+		}
+	}
+	return nil
+}
+
+// Input contains quantities of the ceiling for estimation. Canvas is the area of the spent canvas,
+// Area is the area of the ceiling in square metres and Perimeter is the length of the profile in metres.
+type Input struct {
+	Canvas       float64 `json:"canvas"`
+	Area         float64 `json:"area"`
+	Perimeter    float64 `json:"perimeter"`
+	InnerCorners int     `json:"inner_corners"`
+	OuterCorners int     `json:"outer_corners"`
+	Fixtures     int     `json:"fixtures"`
+	PipeBypasses int     `json:"pipe_bypasses"`
+}
+
+// Item is a line of the quote.
+type Item struct {
+	Name     string  `json:"name"`
+	Unit     string  `json:"unit"`
+	Quantity float64 `json:"quantity"`
+	Price    float64 `json:"price"`
+	Cost     float64 `json:"cost"`
+}
+
+// Quote is the list of the ceiling costs with the total cost.
+type Quote struct {
+	Items    []*Item `json:"items"`
+	Total    float64 `json:"total"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+// Estimate returns the quote of the ceiling with quantities of in. Items with zero quantity are skipped.
+func (pl *PriceList) Estimate(in *Input) *Quote {
+	q := &Quote{Items: make([]*Item, 0), Currency: pl.Currency}
+	add := func(name, unit string, quantity, price float64) {
+		if quantity == 0 {
+			return
+		}
+		item := &Item{
+			Name:     name,
+			Unit:     unit,
+			Quantity: value.Round(quantity, costPrecision),
+			Price:    price,
+			Cost:     value.Round(quantity*price, costPrecision),
+		}
+		q.Items = append(q.Items, item)
+		q.Total += item.Cost
+	}
+	add(ItemCanvas, UnitSquareMetre, in.Canvas, pl.Canvas)
+	add(ItemProfile, UnitMetre, in.Perimeter, pl.Profile)
+	add(ItemInnerCorner, UnitPiece, float64(in.InnerCorners), pl.InnerCorner)
+	add(ItemOuterCorner, UnitPiece, float64(in.OuterCorners), pl.OuterCorner)
+	add(ItemFixture, UnitPiece, float64(in.Fixtures), pl.Fixture)
+	add(ItemPipeBypass, UnitPiece, float64(in.PipeBypasses), pl.PipeBypass)
+	add(ItemLabour, UnitSquareMetre, in.Area, pl.Labour)
+	q.Total = value.Round(q.Total, costPrecision)
+	return q
+}
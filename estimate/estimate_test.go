@@ -0,0 +1,67 @@
+package estimate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPriceList_Estimate(t *testing.T) {
+	priceList := &PriceList{Currency: "USD", Canvas: 10, Profile: 3.5, InnerCorner: 2, OuterCorner: 4,
+		Fixture: 15, PipeBypass: 7, Labour: 5}
+	tests := []struct {
+		name      string
+		priceList *PriceList
+		in        *Input
+		want      *Quote
+	}{
+		{
+			name:      "Full",
+			priceList: priceList,
+			in: &Input{Canvas: 21.6, Area: 20.7, Perimeter: 18.9, InnerCorners: 5, OuterCorners: 1, Fixtures: 4,
+				PipeBypasses: 1},
+			want: &Quote{Items: []*Item{
+				{Name: ItemCanvas, Unit: UnitSquareMetre, Quantity: 21.6, Price: 10, Cost: 216},
+				{Name: ItemProfile, Unit: UnitMetre, Quantity: 18.9, Price: 3.5, Cost: 66.15},
+				{Name: ItemInnerCorner, Unit: UnitPiece, Quantity: 5, Price: 2, Cost: 10},
+				{Name: ItemOuterCorner, Unit: UnitPiece, Quantity: 1, Price: 4, Cost: 4},
+				{Name: ItemFixture, Unit: UnitPiece, Quantity: 4, Price: 15, Cost: 60},
+				{Name: ItemPipeBypass, Unit: UnitPiece, Quantity: 1, Price: 7, Cost: 7},
+				{Name: ItemLabour, Unit: UnitSquareMetre, Quantity: 20.7, Price: 5, Cost: 103.5},
+			}, Total: 466.65, Currency: "USD"},
+		},
+		{
+			name:      "Without optional items",
+			priceList: priceList,
+			in:        &Input{Canvas: 4.123, Area: 4, Perimeter: 8, InnerCorners: 4},
+			want: &Quote{Items: []*Item{
+				{Name: ItemCanvas, Unit: UnitSquareMetre, Quantity: 4.12, Price: 10, Cost: 41.23},
+				{Name: ItemProfile, Unit: UnitMetre, Quantity: 8, Price: 3.5, Cost: 28},
+				{Name: ItemInnerCorner, Unit: UnitPiece, Quantity: 4, Price: 2, Cost: 8},
+				{Name: ItemLabour, Unit: UnitSquareMetre, Quantity: 4, Price: 5, Cost: 20},
+			}, Total: 97.23, Currency: "USD"},
+		},
+		{
+			name:      "Empty",
+			priceList: &PriceList{},
+			in:        &Input{},
+			want:      &Quote{Items: []*Item{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.priceList.Estimate(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Estimate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriceList_Check(t *testing.T) {
+	if err := (&PriceList{Canvas: 10, Labour: 0}).Check(); err != nil {
+		t.Errorf("Check() error = %v, want nil", err)
+	}
+	if err := (&PriceList{Canvas: 10, Fixture: -1}).Check(); !errors.Is(err, ErrNegativePrice) {
+		t.Errorf("Check() error = %v, want %v", err, ErrNegativePrice)
+	}
+}
@@ -0,0 +1,130 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// Arc makes curved the side of the polygon, which ends with the point having the arc.
+// The arc is defined by Sagitta (height of the arc over the middle of the chord) or by Radius, then the shorter arc
+// is taken. By default the arc bulges to the left side of the direction from the previous point, Right changes it.
+type Arc struct {
+	Sagitta float64 `json:"sagitta,omitempty"`
+	Radius  float64 `json:"radius,omitempty"`
+	Right   bool    `json:"right,omitempty"`
+}
+
+func (a *Arc) ConvertToOne(measures *value.FigureMeasures) {
+	a.Sagitta = value.ConvertToOne(measures.Length, a.Sagitta)
+	a.Radius = value.ConvertToOne(measures.Length, a.Radius)
+}
+
+func (a *Arc) ConvertFromOne(measures *value.FigureMeasures) {
+	a.Sagitta = value.ConvertFromOne(measures.Length, a.Sagitta)
+	a.Radius = value.ConvertFromOne(measures.Length, a.Radius)
+}
+
+// signedSagitta returns the sagitta of the arc over the chord, which is positive for the arc bulging to the left.
+func (a *Arc) signedSagitta(chord float64) (float64, error) {
+	var s float64
+	switch {
+	case chord == 0:
+		return 0, fmt.Errorf("%w: arc on a side with zero length", ErrWrongMeasurement)
+	case a.Sagitta > 0:
+		s = a.Sagitta
+	case a.Radius > 0:
+		if a.Radius < chord/2 {
+			return 0, fmt.Errorf("%w: radius %v is less than half of the chord %v", ErrWrongMeasurement, a.Radius, chord)
+		}
+		s = a.Radius - math.Sqrt(math.Max(a.Radius*a.Radius-chord*chord/4, 0))
+	default:
+		return 0, fmt.Errorf("%w: arc must have positive sagitta or radius", ErrWrongMeasurement)
+	}
+	if a.Right {
+		s = -s
+	}
+	return s, nil
+}
+
+// CurvedSide is a side of the polygon from A to B, curved by an arc of a circle with Center and Radius.
+// Start is the direction from Center to A and Sweep is the angle from A to B around Center in radians,
+// positive for moving counterclockwise.
+type CurvedSide struct {
+	Segment
+	Center *Point
+	Radius float64
+	Start  float64
+	Sweep  float64
+}
+
+// NewCurvedSide returns the side from a to b curved by arc.
+func NewCurvedSide(a, b *Point, arc *Arc) (*CurvedSide, error) {
+	chord := (&Segment{A: a, B: b}).Distance()
+	s, err := arc.signedSagitta(chord)
+	if err != nil {
+		return nil, err
+	}
+	ux, uy := (b.X-a.X)/chord, (b.Y-a.Y)/chord
+	radius := (chord*chord/4 + s*s) / (2 * math.Abs(s))
+	shift := s - math.Copysign(radius, s)
+	center := &Point{X: (a.X+b.X)/2 - uy*shift, Y: (a.Y+b.Y)/2 + ux*shift}
+	sweep := -math.Copysign(4*math.Atan(2*math.Abs(s)/chord), s)
+	return &CurvedSide{
+		Segment: Segment{A: a, B: b},
+		Center:  center,
+		Radius:  radius,
+		Start:   math.Atan2(a.Y-center.Y, a.X-center.X),
+		Sweep:   sweep,
+	}, nil
+}
+
+// Length returns length of the arc.
+func (cs *CurvedSide) Length() float64 {
+	return cs.Radius * math.Abs(cs.Sweep)
+}
+
+// SegmentArea returns area between the arc and the chord. It's positive if the arc goes counterclockwise,
+// so it can be added to the signed area of the polygon.
+func (cs *CurvedSide) SegmentArea() float64 {
+	theta := math.Abs(cs.Sweep)
+	return math.Copysign(cs.Radius*cs.Radius/2*(theta-math.Sin(theta)), cs.Sweep)
+}
+
+// PointAt returns a point of the arc, where t is a part of the arc from A (0) to B (1).
+func (cs *CurvedSide) PointAt(t float64) *Point {
+	a := cs.Start + cs.Sweep*t
+	return &Point{X: cs.Center.X + cs.Radius*math.Cos(a), Y: cs.Center.Y + cs.Radius*math.Sin(a)}
+}
+
+// ExtremePoints returns the most left, right, top and low points of the circle, which lie on the arc.
+func (cs *CurvedSide) ExtremePoints() []*Point {
+	out := make([]*Point, 0, 4)
+	for _, a := range []float64{0, math.Pi / 2, math.Pi, math.Pi * 3 / 2} {
+		p := &Point{X: cs.Center.X + cs.Radius*math.Cos(a), Y: cs.Center.Y + cs.Radius*math.Sin(a)}
+		if cs.containsDirection(p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// bulgeSide returns true if the point lies on the side of the chord, where the arc bulges.
+func (cs *CurvedSide) bulgeSide(p *Point) bool {
+	cross := (cs.B.X-cs.A.X)*(p.Y-cs.A.Y) - (cs.B.Y-cs.A.Y)*(p.X-cs.A.X)
+	// the arc going clockwise bulges to the left
+	return (cross > 0) == (cs.Sweep < 0)
+}
+
+// containsDirection returns true if the direction from the center to the point lies within the arc.
+func (cs *CurvedSide) containsDirection(p *Point) bool {
+	t := math.Mod(math.Atan2(p.Y-cs.Center.Y, p.X-cs.Center.X)-cs.Start, math.Pi*2)
+	if cs.Sweep < 0 {
+		t = -t
+	}
+	if t < 0 {
+		t += math.Pi * 2
+	}
+	return t <= math.Abs(cs.Sweep)
+}
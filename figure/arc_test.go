@@ -0,0 +1,142 @@
+package figure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// newArcExample returns a rectangle 4x2, whose upper side is curved by the arc.
+func newArcExample(arc *Arc) *Polygon {
+	return NewPolygon(NewPoint(0, 0), NewPoint(4, 0), NewPoint(4, 2), &Point{X: 0, Y: 2, Arc: arc})
+}
+
+func TestNewCurvedSide(t *testing.T) {
+	tests := []struct {
+		name       string
+		arc        *Arc
+		wantCenter *Point
+		wantRadius float64
+		wantSweep  float64
+		wantErr    error
+	}{
+		{
+			name:       "Semicircle by sagitta",
+			arc:        &Arc{Sagitta: 2},
+			wantCenter: &Point{X: 2, Y: 0},
+			wantRadius: 2,
+			wantSweep:  -math.Pi,
+		},
+		{
+			name:       "Right arc by radius",
+			arc:        &Arc{Radius: 2.5, Right: true},
+			wantCenter: &Point{X: 2, Y: 1.5},
+			wantRadius: 2.5,
+			wantSweep:  2 * math.Atan2(2, 1.5),
+		},
+		{
+			name:       "Major arc by sagitta",
+			arc:        &Arc{Sagitta: 4},
+			wantCenter: &Point{X: 2, Y: 1.5},
+			wantRadius: 2.5,
+			wantSweep:  -(2*math.Pi - 2*math.Atan2(2, 1.5)),
+		},
+		{
+			name:    "Too small radius",
+			arc:     &Arc{Radius: 1.9},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Empty arc",
+			arc:     &Arc{},
+			wantErr: ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCurvedSide(NewPoint(0, 0), NewPoint(4, 0), tt.arc)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewCurvedSide() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !comparePoints(tt.wantCenter, got.Center, 1e-9) || !compareFloats(got.Radius, tt.wantRadius, 1e-9) ||
+				!compareFloats(got.Sweep, tt.wantSweep, 1e-9) {
+				t.Errorf("NewCurvedSide() got center %v, radius %v, sweep %v", got.Center, got.Radius, got.Sweep)
+			}
+			if end := got.PointAt(1); !comparePoints(end, got.B, 1e-9) {
+				t.Errorf("PointAt(1) = %v, want %v", end, got.B)
+			}
+		})
+	}
+}
+
+func TestPolygon_Arcs(t *testing.T) {
+	tests := []struct {
+		name          string
+		arc           *Arc
+		wantArea      float64
+		wantPerimeter float64
+		wantWidth     float64
+		wantHeight    float64
+	}{
+		{
+			name:          "Without arc",
+			wantArea:      8,
+			wantPerimeter: 12,
+			wantWidth:     4,
+			wantHeight:    2,
+		},
+		{
+			name:          "Outer semicircle",
+			arc:           &Arc{Sagitta: 2, Right: true},
+			wantArea:      8 + 2*math.Pi,
+			wantPerimeter: 8 + 2*math.Pi,
+			wantWidth:     4,
+			wantHeight:    4,
+		},
+		{
+			name:          "Inner semicircle by radius",
+			arc:           &Arc{Radius: 2},
+			wantArea:      8 - 2*math.Pi,
+			wantPerimeter: 8 + 2*math.Pi,
+			wantWidth:     4,
+			wantHeight:    2,
+		},
+		{
+			name:          "Outer major arc",
+			arc:           &Arc{Sagitta: 4, Right: true},
+			wantArea:      8 + (2*math.Pi-2*math.Atan2(2, 1.5))*3.125 + 3,
+			wantPerimeter: 8 + (2*math.Pi-2*math.Atan2(2, 1.5))*2.5,
+			wantWidth:     5,
+			wantHeight:    6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := newArcExample(tt.arc)
+			if got := pol.Area(); !compareFloats(got, tt.wantArea, 1e-9) {
+				t.Errorf("Area() = %v, want %v", got, tt.wantArea)
+			}
+			if got := pol.Perimeter(); !compareFloats(got, tt.wantPerimeter, 1e-9) {
+				t.Errorf("Perimeter() = %v, want %v", got, tt.wantPerimeter)
+			}
+			if got := pol.Width(); !compareFloats(got, tt.wantWidth, 1e-9) {
+				t.Errorf("Width() = %v, want %v", got, tt.wantWidth)
+			}
+			if got := pol.Height(); !compareFloats(got, tt.wantHeight, 1e-9) {
+				t.Errorf("Height() = %v, want %v", got, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestPolygon_AddPoints_WrongArc(t *testing.T) {
+	pol := NewPolygon(NewPoint(0, 0), NewPoint(4, 0))
+	err := pol.AddPoints(&Point{X: 4, Y: 2, Arc: &Arc{Radius: 0.5}})
+	if !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("AddPoints() error = %v, want %v", err, ErrWrongMeasurement)
+	}
+}
@@ -0,0 +1,370 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// booleanTolerance is the distance in metres, within which points of polygons are taken as the same point
+// and a point is taken as lying on a side.
+const booleanTolerance = 1e-6
+
+// BooleanOperation is an operation between two polygons.
+type BooleanOperation string
+
+const (
+	BooleanUnion        BooleanOperation = "union"
+	BooleanIntersection BooleanOperation = "intersection"
+	BooleanDifference   BooleanOperation = "difference"
+)
+
+// Region is a part of the plane bounded by the Outline going clockwise without Holes.
+type Region struct {
+	Outline *Polygon `json:"outline"`
+	Holes   []*Hole  `json:"holes,omitempty"`
+}
+
+// booleanRing is a closed outline by points, the region lies to the left of it: outlines go counterclockwise
+// and holes go clockwise.
+type booleanRing []*Point
+
+// Union returns regions covered by the polygon or the other one.
+func (pol *Polygon) Union(other *Polygon) ([]*Region, error) {
+	return pol.Boolean(BooleanUnion, other)
+}
+
+// Intersection returns regions covered by both the polygon and the other one.
+func (pol *Polygon) Intersection(other *Polygon) ([]*Region, error) {
+	return pol.Boolean(BooleanIntersection, other)
+}
+
+// Difference returns regions of the polygon, which aren't covered by the other one.
+func (pol *Polygon) Difference(other *Polygon) ([]*Region, error) {
+	return pol.Boolean(BooleanDifference, other)
+}
+
+// Boolean returns regions of the operation between the polygon and the other one in the order of decreasing area.
+// The result can have several regions, e.g. the difference can cut the polygon in parts, and regions can have
+// holes. Polygons can be concave and can have common sides. Curved sides are approximated by chords.
+func (pol *Polygon) Boolean(op BooleanOperation, other *Polygon) ([]*Region, error) {
+	if op != BooleanUnion && op != BooleanIntersection && op != BooleanDifference {
+		return nil, fmt.Errorf("%w: unknown boolean operation %q", ErrWrongMeasurement, op)
+	}
+	a, err := pol.booleanRing()
+	if err != nil {
+		return nil, err
+	}
+	b, err := other.booleanRing()
+	if err != nil {
+		return nil, err
+	}
+	return ringsRegions(booleanOverlay(op, []booleanRing{a}, []booleanRing{b})), nil
+}
+
+// UnionAll returns regions covered by any of polygons in the order of decreasing area.
+func UnionAll(polygons ...*Polygon) ([]*Region, error) {
+	if len(polygons) == 0 {
+		return nil, fmt.Errorf("%w: no polygons for the union", ErrWrongMeasurement)
+	}
+	rings := make([]booleanRing, 0, 1)
+	for _, p := range polygons {
+		r, err := p.booleanRing()
+		if err != nil {
+			return nil, err
+		}
+		rings = booleanOverlay(BooleanUnion, rings, []booleanRing{r})
+	}
+	return ringsRegions(rings), nil
+}
+
+// booleanRing returns the outline of the polygon going counterclockwise.
+func (pol *Polygon) booleanRing() (booleanRing, error) {
+	if pol.Len() < 3 {
+		return nil, fmt.Errorf("%w for a boolean operation (%d), must be at least 3", ErrNotEnoughPoints, pol.Len())
+	}
+	if err := pol.checkArcs(); err != nil {
+		return nil, err
+	}
+	ring := make(booleanRing, 0, pol.Len())
+	for _, s := range pol.path() {
+		ring = append(ring, &Point{X: s.A.X, Y: s.A.Y})
+	}
+	ring = ring.simplified()
+	area := ring.signedArea()
+	if len(ring) < 3 || math.Abs(area) < booleanTolerance*booleanTolerance {
+		return nil, fmt.Errorf("%w: polygon has zero area", ErrWrongMeasurement)
+	}
+	if area < 0 {
+		ring.reverse()
+	}
+	return ring, nil
+}
+
+func (r booleanRing) signedArea() float64 {
+	return (&Polygon{Points: r}).signedArea()
+}
+
+func (r booleanRing) reverse() {
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+}
+
+// edges returns sides of the ring.
+func (r booleanRing) edges() []*Segment {
+	out := make([]*Segment, len(r))
+	for i, p := range r {
+		out[i] = &Segment{A: p, B: r[(i+1)%len(r)]}
+	}
+	return out
+}
+
+// simplified returns the ring without repeated points and points lying on the straight line between neighbours.
+func (r booleanRing) simplified() booleanRing {
+	out := append(booleanRing{}, r...)
+	for changed := true; changed && len(out) > 2; {
+		changed = false
+		for i := 0; i < len(out) && len(out) > 2; i++ {
+			prev, p, next := out[(i+len(out)-1)%len(out)], out[i], out[(i+1)%len(out)]
+			length := (&Segment{A: prev, B: next}).Distance()
+			repeated := (&Segment{A: prev, B: p}).Distance() <= booleanTolerance
+			straight := length > booleanTolerance && math.Abs(crossProduct(prev, next, p))/length <= booleanTolerance &&
+				(p.X-prev.X)*(next.X-p.X)+(p.Y-prev.Y)*(next.Y-p.Y) > 0
+			if repeated || straight {
+				out, changed = append(out[:i], out[i+1:]...), true
+				i--
+			}
+		}
+	}
+	return out
+}
+
+// ringsContain returns true if the point lies inside of the region bounded by rings.
+func ringsContain(rings []booleanRing, p *Point) bool {
+	inside := false
+	for _, r := range rings {
+		if (&Polygon{Points: r}).ContainsPoint(p) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// ringsBoundary returns true if the segment lies on the border of rings and true for same if the border goes
+// in the same direction.
+func ringsBoundary(rings []booleanRing, s *Segment) (shared, same bool) {
+	mid := &Point{X: (s.A.X + s.B.X) / 2, Y: (s.A.Y + s.B.Y) / 2}
+	for _, r := range rings {
+		for _, e := range r.edges() {
+			if (&Segment{A: mid, B: e.nearestPoint(mid)}).Distance() <= booleanTolerance {
+				return true, (s.B.X-s.A.X)*(e.B.X-e.A.X)+(s.B.Y-s.A.Y)*(e.B.Y-e.A.Y) > 0
+			}
+		}
+	}
+	return false, false
+}
+
+// segmentInterior returns true if the point lies on the segment, but not at its ends.
+func segmentInterior(p *Point, s *Segment) bool {
+	return (&Segment{A: p, B: s.nearestPoint(p)}).Distance() <= booleanTolerance &&
+		(&Segment{A: p, B: s.A}).Distance() > booleanTolerance && (&Segment{A: p, B: s.B}).Distance() > booleanTolerance
+}
+
+// segmentsCrossing returns the crossing point of lines of segments.
+func segmentsCrossing(s, o *Segment) *Point {
+	d1, d2 := crossProduct(o.A, o.B, s.A), crossProduct(o.A, o.B, s.B)
+	t := d1 / (d1 - d2)
+	return &Point{X: s.A.X + (s.B.X-s.A.X)*t, Y: s.A.Y + (s.B.Y-s.A.Y)*t}
+}
+
+// splitEdges returns sides of rings a and b cut by points, where they cross or touch each other.
+func splitEdges(a, b []booleanRing) (aEdges, bEdges []*Segment) {
+	for _, r := range a {
+		aEdges = append(aEdges, r.edges()...)
+	}
+	for _, r := range b {
+		bEdges = append(bEdges, r.edges()...)
+	}
+	aCuts, bCuts := make([][]*Point, len(aEdges)), make([][]*Point, len(bEdges))
+	for i, e := range aEdges {
+		for j, f := range bEdges {
+			touch := false
+			for _, p := range []*Point{f.A, f.B} {
+				if (&Segment{A: p, B: e.nearestPoint(p)}).Distance() <= booleanTolerance {
+					touch = true
+					if segmentInterior(p, e) {
+						aCuts[i] = append(aCuts[i], p)
+					}
+				}
+			}
+			for _, p := range []*Point{e.A, e.B} {
+				if (&Segment{A: p, B: f.nearestPoint(p)}).Distance() <= booleanTolerance {
+					touch = true
+					if segmentInterior(p, f) {
+						bCuts[j] = append(bCuts[j], p)
+					}
+				}
+			}
+			if !touch && e.Crosses(f) {
+				p := segmentsCrossing(e, f)
+				aCuts[i], bCuts[j] = append(aCuts[i], p), append(bCuts[j], p)
+			}
+		}
+	}
+	return cutEdges(aEdges, aCuts), cutEdges(bEdges, bCuts)
+}
+
+// cutEdges returns parts of segments cut by points.
+func cutEdges(edges []*Segment, cuts [][]*Point) []*Segment {
+	out := make([]*Segment, 0, len(edges))
+	for i, e := range edges {
+		points := cuts[i]
+		project := func(p *Point) float64 { return (p.X-e.A.X)*(e.B.X-e.A.X) + (p.Y-e.A.Y)*(e.B.Y-e.A.Y) }
+		sort.Slice(points, func(a, b int) bool { return project(points[a]) < project(points[b]) })
+		prev := e.A
+		for _, p := range append(points, e.B) {
+			if (&Segment{A: prev, B: p}).Distance() > booleanTolerance {
+				out, prev = append(out, &Segment{A: prev, B: p}), p
+			}
+		}
+	}
+	return out
+}
+
+// booleanOverlay returns rings of the operation between regions bounded by rings a and b. Parts of sides are
+// selected by their position relative to the other region and linked into rings.
+func booleanOverlay(op BooleanOperation, a, b []booleanRing) []booleanRing {
+	aEdges, bEdges := splitEdges(a, b)
+	selected := make([]*Segment, 0, len(aEdges)+len(bEdges))
+	for _, s := range aEdges {
+		shared, same := ringsBoundary(b, s)
+		inside := !shared && ringsContain(b, &Point{X: (s.A.X + s.B.X) / 2, Y: (s.A.Y + s.B.Y) / 2})
+		switch {
+		case op == BooleanUnion && ((!shared && !inside) || (shared && same)),
+			op == BooleanIntersection && (inside || (shared && same)),
+			op == BooleanDifference && ((!shared && !inside) || (shared && !same)):
+			selected = append(selected, s)
+		}
+	}
+	for _, s := range bEdges {
+		// common parts of borders are taken from the first region
+		if shared, _ := ringsBoundary(a, s); shared {
+			continue
+		}
+		inside := ringsContain(a, &Point{X: (s.A.X + s.B.X) / 2, Y: (s.A.Y + s.B.Y) / 2})
+		switch {
+		case op == BooleanUnion && !inside, op == BooleanIntersection && inside:
+			selected = append(selected, s)
+		case op == BooleanDifference && inside:
+			selected = append(selected, &Segment{A: s.B, B: s.A})
+		}
+	}
+	return linkRings(selected)
+}
+
+// linkRings links segments into closed rings. Ends of segments are matched within booleanTolerance. At points,
+// where several rings touch each other, the ring turns to the left as much as possible, so regions touching
+// by a point are kept apart.
+func linkRings(segments []*Segment) []booleanRing {
+	nodes := make([]*Point, 0, len(segments))
+	node := func(p *Point) *Point {
+		for _, n := range nodes {
+			if (&Segment{A: p, B: n}).Distance() <= booleanTolerance {
+				return n
+			}
+		}
+		nodes = append(nodes, p)
+		return p
+	}
+	outgoing := make(map[*Point][]int)
+	for i, s := range segments {
+		segments[i] = &Segment{A: node(s.A), B: node(s.B)}
+		outgoing[segments[i].A] = append(outgoing[segments[i].A], i)
+	}
+
+	rings, used := make([]booleanRing, 0), make([]bool, len(segments))
+	for start := range segments {
+		if used[start] {
+			continue
+		}
+		ring, closed := make(booleanRing, 0), false
+		for current := start; ; {
+			used[current] = true
+			s := segments[current]
+			ring = append(ring, &Point{X: s.A.X, Y: s.A.Y})
+			if s.B == segments[start].A {
+				closed = true
+				break
+			}
+			next, best, in := -1, math.Inf(-1), pointDirection(s.A, s.B)
+			for _, k := range outgoing[s.B] {
+				if used[k] {
+					continue
+				}
+				turn := normalizeAngle(pointDirection(segments[k].A, segments[k].B) - in)
+				if turn > math.Pi-straightTurn {
+					turn = -math.Pi
+				}
+				if turn > best {
+					next, best = k, turn
+				}
+			}
+			if next < 0 {
+				break
+			}
+			current = next
+		}
+		if ring = ring.simplified(); closed && len(ring) > 2 && math.Abs(ring.signedArea()) > booleanTolerance*booleanTolerance {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// ringsRegions returns regions of rings in the order of decreasing area: counterclockwise rings are outlines
+// and clockwise ones are holes of the smallest outlines containing them.
+func ringsRegions(rings []booleanRing) []*Region {
+	outlines, holes := make([]booleanRing, 0), make([]booleanRing, 0)
+	for _, r := range rings {
+		if r.signedArea() > 0 {
+			outlines = append(outlines, r)
+		} else {
+			holes = append(holes, r)
+		}
+	}
+	sort.SliceStable(outlines, func(i, j int) bool { return outlines[i].signedArea() > outlines[j].signedArea() })
+	regions := make([]*Region, len(outlines))
+	for i, r := range outlines {
+		// the outline starts with the left bottom point, as drawings usually do
+		first := 0
+		for k, p := range r {
+			if p.X < r[first].X-booleanTolerance || (math.Abs(p.X-r[first].X) <= booleanTolerance && p.Y < r[first].Y) {
+				first = k
+			}
+		}
+		points := append(append([]*Point{}, r[first+1:]...), r[:first+1]...)
+		booleanRing(points).reverse()
+		regions[i] = &Region{Outline: &Polygon{Points: points}}
+	}
+	for _, h := range holes {
+		for i := len(outlines) - 1; i >= 0; i-- {
+			if outlineContains(outlines[i], h) {
+				regions[i].Holes = append(regions[i].Holes, &Hole{Points: h})
+				break
+			}
+		}
+	}
+	return regions
+}
+
+// outlineContains returns true if all points of the ring lie inside of the outline or on its border.
+func outlineContains(outline, ring booleanRing) bool {
+	pol := &Polygon{Points: outline}
+	for _, p := range ring {
+		if !pol.ContainsPoint(p) {
+			return false
+		}
+	}
+	return true
+}
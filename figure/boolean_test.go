@@ -0,0 +1,151 @@
+package figure
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolygon_Boolean(t *testing.T) {
+	rectangle := func(x1, y1, x2, y2 float64) *Polygon {
+		return NewPolygon(NewPoint(x1, y1), NewPoint(x1, y2), NewPoint(x2, y2), NewPoint(x2, y1))
+	}
+	lShaped := NewPolygon(NewPoint(0, 0), NewPoint(0, 4), NewPoint(2, 4), NewPoint(2, 2), NewPoint(4, 2),
+		NewPoint(4, 0))
+	tests := []struct {
+		name      string
+		a, b      *Polygon
+		op        BooleanOperation
+		wantAreas []float64
+		wantHoles []int
+		wantErr   error
+	}{
+		{
+			name:      "Union of overlapping rectangles",
+			a:         rectangle(0, 0, 4, 2),
+			b:         rectangle(2, 1, 6, 3),
+			op:        BooleanUnion,
+			wantAreas: []float64{14},
+			wantHoles: []int{0},
+		},
+		{
+			name:      "Union by the common wall",
+			a:         rectangle(0, 0, 4, 2),
+			b:         rectangle(4, 0, 6, 2),
+			op:        BooleanUnion,
+			wantAreas: []float64{12},
+			wantHoles: []int{0},
+		},
+		{
+			name:      "Union of apart rectangles",
+			a:         rectangle(0, 0, 1, 1),
+			b:         rectangle(2, 0, 4, 1),
+			op:        BooleanUnion,
+			wantAreas: []float64{2, 1},
+			wantHoles: []int{0, 0},
+		},
+		{
+			name:      "Union closing a yard",
+			a:         NewPolygon(NewPoint(0, 0), NewPoint(0, 3), NewPoint(3, 3), NewPoint(3, 2), NewPoint(1, 2), NewPoint(1, 0)),
+			b:         NewPolygon(NewPoint(1, 0), NewPoint(1, 1), NewPoint(2, 1), NewPoint(2, 2), NewPoint(3, 2), NewPoint(3, 0)),
+			op:        BooleanUnion,
+			wantAreas: []float64{9},
+			wantHoles: []int{1},
+		},
+		{
+			name:      "Intersection of concave polygons",
+			a:         lShaped,
+			b:         rectangle(1, 1, 3, 3),
+			op:        BooleanIntersection,
+			wantAreas: []float64{3},
+			wantHoles: []int{0},
+		},
+		{
+			name:      "Intersection of apart rectangles",
+			a:         rectangle(0, 0, 1, 1),
+			b:         rectangle(2, 0, 4, 1),
+			op:        BooleanIntersection,
+			wantAreas: []float64{},
+			wantHoles: []int{},
+		},
+		{
+			name:      "Difference cutting in parts",
+			a:         rectangle(0, 0, 5, 2),
+			b:         rectangle(2, -1, 3, 3),
+			op:        BooleanDifference,
+			wantAreas: []float64{4, 4},
+			wantHoles: []int{0, 0},
+		},
+		{
+			name:      "Difference making a hole",
+			a:         rectangle(0, 0, 4, 4),
+			b:         rectangle(1, 1, 2, 2),
+			op:        BooleanDifference,
+			wantAreas: []float64{16},
+			wantHoles: []int{1},
+		},
+		{
+			name:      "Difference by the common wall",
+			a:         lShaped,
+			b:         rectangle(2, 0, 4, 2),
+			op:        BooleanDifference,
+			wantAreas: []float64{8},
+			wantHoles: []int{0},
+		},
+		{
+			name:    "Unknown operation",
+			a:       rectangle(0, 0, 1, 1),
+			b:       rectangle(0, 0, 1, 1),
+			op:      "xor",
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Not enough points",
+			a:       rectangle(0, 0, 1, 1),
+			b:       NewPolygon(NewPoint(0, 0), NewPoint(1, 1)),
+			op:      BooleanUnion,
+			wantErr: ErrNotEnoughPoints,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.Boolean(tt.op, tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Boolean() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(got) != len(tt.wantAreas) {
+				t.Fatalf("Boolean() got %d regions, want %d", len(got), len(tt.wantAreas))
+			}
+			for i, r := range got {
+				if !compareFloats(r.Outline.Area(), tt.wantAreas[i], 1e-9) {
+					t.Errorf("Boolean() region %d area = %v, want %v", i, r.Outline.Area(), tt.wantAreas[i])
+				}
+				if len(r.Holes) != tt.wantHoles[i] {
+					t.Errorf("Boolean() region %d got %d holes, want %d", i, len(r.Holes), tt.wantHoles[i])
+				}
+				if r.Outline.signedArea() > 0 || len(r.Outline.intersectionsProblems()) > 0 {
+					t.Errorf("Boolean() region %d got a wrong outline %v", i, r.Outline.Problems())
+				}
+			}
+		})
+	}
+}
+
+func TestUnionAll(t *testing.T) {
+	got, err := UnionAll(
+		NewPolygon(NewPoint(0, 0), NewPoint(0, 2), NewPoint(2, 2), NewPoint(2, 0)),
+		NewPolygon(NewPoint(2, 0), NewPoint(2, 2), NewPoint(4, 2), NewPoint(4, 0)),
+		NewPolygon(NewPoint(0, 2), NewPoint(0, 3), NewPoint(4, 3), NewPoint(4, 2)),
+	)
+	if err != nil {
+		t.Fatalf("UnionAll() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Outline.Len() != 4 || !compareFloats(got[0].Outline.Area(), 12, 1e-9) {
+		t.Errorf("UnionAll() = %v, want the single rectangle 4x3", got)
+	}
+	if _, err := UnionAll(); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("UnionAll() error = %v, wantErr %v", err, ErrWrongMeasurement)
+	}
+}
@@ -0,0 +1,264 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// Rectangle is the bounding rectangle of the polygon with the Center, the Width along the Direction in radians
+// and the Height across it.
+type Rectangle struct {
+	Center    *Point  `json:"center"`
+	Width     float64 `json:"width"`
+	Height    float64 `json:"height"`
+	Direction float64 `json:"direction"`
+}
+
+func (r *Rectangle) ConvertToOne(measures *value.FigureMeasures) {
+	r.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) })
+	r.Direction = value.ConvertToOne(measures.Angle, r.Direction)
+}
+
+func (r *Rectangle) ConvertFromOne(measures *value.FigureMeasures) {
+	r.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) })
+	r.Direction = value.ConvertFromOne(measures.Angle, r.Direction)
+}
+
+func (r *Rectangle) convert(length func(float64) float64) {
+	r.Center = &Point{X: length(r.Center.X), Y: length(r.Center.Y)}
+	r.Width, r.Height = length(r.Width), length(r.Height)
+}
+
+// Corners returns corners of the rectangle clockwise.
+func (r *Rectangle) Corners() []*Point {
+	uy, ux := math.Sincos(r.Direction)
+	w, h := r.Width/2, r.Height/2
+	corners := make([]*Point, 0, 4)
+	for _, k := range [][2]float64{{-1, -1}, {-1, 1}, {1, 1}, {1, -1}} {
+		corners = append(corners, &Point{
+			X: r.Center.X + k[0]*w*ux - k[1]*h*uy,
+			Y: r.Center.Y + k[0]*w*uy + k[1]*h*ux,
+		})
+	}
+	return corners
+}
+
+// Axis is the segment from A to B with its Direction in radians and Length.
+type Axis struct {
+	A         *Point  `json:"a"`
+	B         *Point  `json:"b"`
+	Direction float64 `json:"direction"`
+	Length    float64 `json:"length"`
+}
+
+func (a *Axis) ConvertToOne(measures *value.FigureMeasures) {
+	a.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) })
+	a.Direction = value.ConvertToOne(measures.Angle, a.Direction)
+}
+
+func (a *Axis) ConvertFromOne(measures *value.FigureMeasures) {
+	a.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) })
+	a.Direction = value.ConvertFromOne(measures.Angle, a.Direction)
+}
+
+func (a *Axis) convert(length func(float64) float64) {
+	a.A = &Point{X: length(a.A.X), Y: length(a.A.Y)}
+	a.B = &Point{X: length(a.B.X), Y: length(a.B.Y)}
+	a.Length = length(a.Length)
+}
+
+// Centroid returns the centre of mass of the polygon area. Curved sides are taken into account.
+func (pol *Polygon) Centroid() (*Point, error) {
+	n := pol.Len()
+	if n < 2 {
+		return nil, fmt.Errorf("%w for the centroid (%d), must be at least 2", ErrNotEnoughPoints, n)
+	}
+	var area, mx, my float64
+	for i, p := range pol.Points {
+		q := pol.Points[(i+1)%n]
+		c := p.X*q.Y - q.X*p.Y
+		area += c / 2
+		mx += (p.X + q.X) * c / 6
+		my += (p.Y + q.Y) * c / 6
+	}
+	// a segment between the chord and the arc has the centroid on the bisector of the arc
+	for _, cs := range pol.CurvedSides() {
+		theta := math.Abs(cs.Sweep)
+		sa := cs.SegmentArea()
+		d := 4 * cs.Radius * math.Pow(math.Sin(theta/2), 3) / (3 * (theta - math.Sin(theta)))
+		sy, sx := math.Sincos(cs.Start + cs.Sweep/2)
+		area += sa
+		mx += sa * (cs.Center.X + d*sx)
+		my += sa * (cs.Center.Y + d*sy)
+	}
+	if math.Abs(area) < tolerance {
+		return nil, fmt.Errorf("%w: the polygon has zero area", ErrInvalidPolygon)
+	}
+	return &Point{X: mx / area, Y: my / area}, nil
+}
+
+// BoundingBox returns the smallest rectangle with sides parallel to axes containing the polygon.
+func (pol *Polygon) BoundingBox() (*Rectangle, error) {
+	if pol.Len() < 2 {
+		return nil, fmt.Errorf("%w for the bounding box (%d), must be at least 2", ErrNotEnoughPoints, pol.Len())
+	}
+	left, _ := pol.LeftPoint()
+	right, _ := pol.RightPoint()
+	top, _ := pol.TopPoint()
+	low, _ := pol.LowPoint()
+	return &Rectangle{Center: pol.boundsCenter(), Width: right.X - left.X, Height: top.Y - low.Y}, nil
+}
+
+// OrientedBoundingBox returns the rectangle of the minimal area containing the polygon, so it gives real dimensions
+// of the room drawn at an angle. The Width is the longer side, its direction is from 0 to Pi. The box parallel
+// to axes is preferred, if areas are the same. Sides of the box are looked for along sides of the convex hull,
+// where curved sides are approximated by chords, but the box always contains whole arcs.
+func (pol *Polygon) OrientedBoundingBox() (*Rectangle, error) {
+	best, err := pol.BoundingBox()
+	if err != nil {
+		return nil, err
+	}
+	hull := convexHull(pol.outlinePoints())
+	for i, p := range hull {
+		q := hull[(i+1)%len(hull)]
+		direction := pointDirection(p, q)
+		if r := pol.boundingRectangle(direction); r.Width*r.Height < best.Width*best.Height-tolerance {
+			best = r
+		}
+	}
+	if best.Width < best.Height-tolerance {
+		best.Width, best.Height, best.Direction = best.Height, best.Width, best.Direction+math.Pi/2
+	}
+	best.Direction = math.Mod(best.Direction, math.Pi)
+	if best.Direction < 0 {
+		best.Direction += math.Pi
+	}
+	if math.Abs(best.Direction-math.Pi) < tolerance {
+		best.Direction = 0
+	}
+	return best, nil
+}
+
+// PrincipalAxis returns the axis of the polygon through its centroid, along which the area is stretched the most.
+// Ends of the axis are projections of the most distant points of the polygon on it, so the length is the length
+// of the room. The direction is from 0 to Pi. Curved sides are approximated by chords for the direction.
+func (pol *Polygon) PrincipalAxis() (*Axis, error) {
+	c, err := pol.Centroid()
+	if err != nil {
+		return nil, err
+	}
+	// second moments of the area about the centroid
+	var area, sxx, syy, sxy float64
+	points := pol.outlinePoints()
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		px, py, qx, qy := p.X-c.X, p.Y-c.Y, q.X-c.X, q.Y-c.Y
+		k := px*qy - qx*py
+		area += k / 2
+		sxx += k * (px*px + px*qx + qx*qx) / 12
+		syy += k * (py*py + py*qy + qy*qy) / 12
+		sxy += k * (px*qy + 2*px*py + 2*qx*qy + qx*py) / 24
+	}
+	if area < 0 {
+		sxx, syy, sxy = -sxx, -syy, -sxy
+	}
+	direction := 0.0
+	if math.Abs(sxy) > tolerance || math.Abs(sxx-syy) > tolerance {
+		direction = math.Atan2(2*sxy, sxx-syy) / 2
+	}
+	if direction < 0 {
+		direction += math.Pi
+	}
+	uy, ux := math.Sincos(direction)
+	lo, hi := pol.extent(ux, uy)
+	s := c.X*ux + c.Y*uy
+	return &Axis{
+		A:         &Point{X: c.X + (lo-s)*ux, Y: c.Y + (lo-s)*uy},
+		B:         &Point{X: c.X + (hi-s)*ux, Y: c.Y + (hi-s)*uy},
+		Direction: direction,
+		Length:    hi - lo,
+	}, nil
+}
+
+// boundingRectangle returns the smallest rectangle containing the polygon with the width along the direction.
+func (pol *Polygon) boundingRectangle(direction float64) *Rectangle {
+	uy, ux := math.Sincos(direction)
+	lo, hi := pol.extent(ux, uy)
+	loN, hiN := pol.extent(-uy, ux)
+	along, across := (lo+hi)/2, (loN+hiN)/2
+	return &Rectangle{
+		Center:    &Point{X: along*ux - across*uy, Y: along*uy + across*ux},
+		Width:     hi - lo,
+		Height:    hiN - loN,
+		Direction: direction,
+	}
+}
+
+// extent returns the smallest and the largest projections of the polygon on the unit vector (ux;uy).
+// Curved sides are taken into account.
+func (pol *Polygon) extent(ux, uy float64) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	add := func(p *Point) {
+		v := p.X*ux + p.Y*uy
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	for _, p := range pol.Points {
+		add(p)
+	}
+	for _, cs := range pol.CurvedSides() {
+		for _, k := range []float64{1, -1} {
+			if p := (&Point{X: cs.Center.X + k*cs.Radius*ux, Y: cs.Center.Y + k*cs.Radius*uy}); cs.containsDirection(p) {
+				add(p)
+			}
+		}
+	}
+	return lo, hi
+}
+
+// outlinePoints returns points of the outline, where curved sides are approximated by chords.
+func (pol *Polygon) outlinePoints() []*Point {
+	path := pol.path()
+	points := make([]*Point, len(path))
+	for i, s := range path {
+		points[i] = s.A
+	}
+	return points
+}
+
+// convexHull returns the convex hull of points counterclockwise.
+func convexHull(points []*Point) []*Point {
+	sorted := append([]*Point{}, points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	if len(sorted) < 3 {
+		return sorted
+	}
+	hull := make([]*Point, 0, 2*len(sorted))
+	for _, pass := range [][]*Point{sorted, reversedPoints(sorted)} {
+		start := len(hull)
+		for _, p := range pass {
+			for len(hull) >= start+2 && crossProduct(hull[len(hull)-2], hull[len(hull)-1], p) <= tolerance {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1]
+	}
+	return hull
+}
+
+// reversedPoints returns points in the reversed order.
+func reversedPoints(points []*Point) []*Point {
+	out := make([]*Point, len(points))
+	for i, p := range points {
+		out[len(points)-1-i] = p
+	}
+	return out
+}
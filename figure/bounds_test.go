@@ -0,0 +1,119 @@
+package figure
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPolygon_Centroid(t *testing.T) {
+	tests := []struct {
+		name string
+		pol  *Polygon
+		want *Point
+	}{
+		{
+			name: "Rectangle",
+			pol:  &Polygon{Points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}}},
+			want: &Point{X: 2, Y: 1},
+		},
+		{
+			name: "L-shaped",
+			pol:  &Polygon{Points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}}},
+			want: &Point{X: 1.6666666666666667, Y: 1.6666666666666667},
+		},
+		{
+			name: "Semicircle",
+			pol:  &Polygon{Points: []*Point{{X: -1, Y: 0}, {X: 1, Y: 0, Arc: &Arc{Radius: 1}}}},
+			want: &Point{X: 0, Y: 4 / (3 * math.Pi)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pol.Centroid()
+			if err != nil {
+				t.Errorf("Centroid() error = %v", err)
+				return
+			}
+			if !compareFloats(got.X, tt.want.X, 1e-9) || !compareFloats(got.Y, tt.want.Y, 1e-9) {
+				t.Errorf("Centroid() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygon_OrientedBoundingBox(t *testing.T) {
+	// the rectangle 4x2 turned by 30 degrees around (0;0)
+	turned := &Polygon{Points: []*Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 2}, {X: 0, Y: 2}}}
+	if err := turned.Rotation(&Point{}, math.Pi/6); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		pol  *Polygon
+		want *Rectangle
+	}{
+		{
+			name: "Straight",
+			pol:  &Polygon{Points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 0}}},
+			want: &Rectangle{Center: &Point{X: 1, Y: 2}, Width: 4, Height: 2, Direction: math.Pi / 2},
+		},
+		{
+			name: "Turned",
+			pol:  turned,
+			want: &Rectangle{Center: &Point{X: 2*math.Cos(math.Pi/6) - math.Sin(math.Pi/6), Y: 2*math.Sin(math.Pi/6) + math.Cos(math.Pi/6)},
+				Width: 4, Height: 2, Direction: math.Pi / 6},
+		},
+		{
+			name: "Semicircle",
+			pol:  &Polygon{Points: []*Point{{X: -1, Y: 0}, {X: 1, Y: 0, Arc: &Arc{Radius: 1}}}},
+			want: &Rectangle{Center: &Point{X: 0, Y: 0.5}, Width: 2, Height: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pol.OrientedBoundingBox()
+			if err != nil {
+				t.Errorf("OrientedBoundingBox() error = %v", err)
+				return
+			}
+			if !compareFloats(got.Center.X, tt.want.Center.X, 1e-9) || !compareFloats(got.Center.Y, tt.want.Center.Y, 1e-9) ||
+				!compareFloats(got.Width, tt.want.Width, 1e-9) || !compareFloats(got.Height, tt.want.Height, 1e-9) ||
+				!compareFloats(got.Direction, tt.want.Direction, 1e-9) {
+				t.Errorf("OrientedBoundingBox() = %+v, center %+v, want %+v, center %+v", got, got.Center, tt.want, tt.want.Center)
+			}
+		})
+	}
+}
+
+func TestPolygon_PrincipalAxis(t *testing.T) {
+	tests := []struct {
+		name string
+		pol  *Polygon
+		want *Axis
+	}{
+		{
+			name: "Vertical",
+			pol:  &Polygon{Points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 0}}},
+			want: &Axis{A: &Point{X: 1, Y: 0}, B: &Point{X: 1, Y: 4}, Direction: math.Pi / 2, Length: 4},
+		},
+		{
+			name: "Diagonal",
+			pol:  &Polygon{Points: []*Point{{X: 0, Y: 1}, {X: 3, Y: 4}, {X: 4, Y: 3}, {X: 1, Y: 0}}},
+			want: &Axis{A: &Point{X: 0.5, Y: 0.5}, B: &Point{X: 3.5, Y: 3.5}, Direction: math.Pi / 4, Length: 3 * math.Sqrt2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pol.PrincipalAxis()
+			if err != nil {
+				t.Errorf("PrincipalAxis() error = %v", err)
+				return
+			}
+			if !compareFloats(got.A.X, tt.want.A.X, 1e-9) || !compareFloats(got.A.Y, tt.want.A.Y, 1e-9) ||
+				!compareFloats(got.B.X, tt.want.B.X, 1e-9) || !compareFloats(got.B.Y, tt.want.B.Y, 1e-9) ||
+				!compareFloats(got.Direction, tt.want.Direction, 1e-9) || !compareFloats(got.Length, tt.want.Length, 1e-9) {
+				t.Errorf("PrincipalAxis() = %+v %+v, want %+v %+v", got.A, got.B, tt.want.A, tt.want.B)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+)
+
+// AdjustmentMethod is a way of distributing the misclosure of a traverse across its calculated points.
+type AdjustmentMethod string
+
+const (
+	// AdjustmentNone closes the traverse on the first point without distributing the error,
+	// so the whole error lands on the closing side.
+	AdjustmentNone AdjustmentMethod = "none"
+	// AdjustmentCompass (Bowditch rule) distributes the error proportionally to the lengths of the sides.
+	AdjustmentCompass AdjustmentMethod = "compass"
+	// AdjustmentTransit distributes X and Y errors proportionally to X and Y projections of the sides.
+	AdjustmentTransit AdjustmentMethod = "transit"
+	// AdjustmentLeastSquares distributes the error with the weighted least squares method, where length of a side
+	// is measured with an error growing with the length, and its direction with a constant angular error.
+	AdjustmentLeastSquares AdjustmentMethod = "least_squares"
+)
+
+// DefaultTransverseRatio is the ratio of the variance of a side transverse shift to the variance of its length
+// in the least squares adjustment, if the ratio isn't set. Angles measured on site are usually less accurate than
+// lengths, so sides are shifted across more willingly than stretched. The ratio 1 gives the compass rule.
+const DefaultTransverseRatio = 2.0
+
+// Misclosure is a closure error of a traverse, where the last calculated point has to land on the first point.
+// Lengths are in metres, Angular is in radians.
+type Misclosure struct {
+	DX      float64 `json:"dx"`
+	DY      float64 `json:"dy"`
+	Linear  float64 `json:"linear"`
+	Angular float64 `json:"angular"`
+	Length  float64 `json:"length"`
+	Ratio   float64 `json:"ratio"`
+}
+
+// Closure contains the misclosure of the traverse and the method it was adjusted with. TransverseRatio is set
+// for the least squares method.
+type Closure struct {
+	Method          AdjustmentMethod `json:"method"`
+	TransverseRatio float64          `json:"transverse_ratio,omitempty"`
+	Misclosure
+}
+
+// traverseStart returns index of the fixed point, from which the traverse ending with the last point starts.
+func (pol *Polygon) traverseStart() (int, error) {
+	n := pol.Len()
+	if n < 4 {
+		return 0, fmt.Errorf("%w for a traverse (%d), must be at least 4", ErrNotEnoughPoints, n)
+	}
+	if pol.Points[n-1].Calculator == nil {
+		return 0, fmt.Errorf("the last point of a traverse: %w", ErrPointDoesNotHaveCalculator)
+	}
+	start := 0
+	for i := n - 2; i > 0; i-- {
+		if pol.Points[i].Calculator == nil {
+			start = i
+			break
+		}
+	}
+	return start, nil
+}
+
+// Misclosure returns the closure error of the traverse, expecting the last point has to land on the first point.
+// Angular is the angle between the last side and the direction in which it had to go for closing on the first point.
+// Ratio is the length of the traverse divided by the linear misclosure (the precision is 1:Ratio).
+func (pol *Polygon) Misclosure() (*Misclosure, error) {
+	start, err := pol.traverseStart()
+	if err != nil {
+		return nil, err
+	}
+	n := pol.Len()
+	first, last, penultimate := pol.Points[0], pol.Points[n-1], pol.Points[n-2]
+	m := &Misclosure{DX: last.X - first.X, DY: last.Y - first.Y}
+	m.Linear = math.Hypot(m.DX, m.DY)
+	for i := start + 1; i < n; i++ {
+		m.Length += (&Segment{A: pol.Points[i-1], B: pol.Points[i]}).Distance()
+	}
+	if m.Linear != 0 {
+		m.Ratio = m.Length / m.Linear
+	}
+	if penultimate.X != first.X || penultimate.Y != first.Y {
+		m.Angular = normalizeAngle(pointDirection(penultimate, first) - pointDirection(penultimate, last))
+	}
+	return m, nil
+}
+
+// CloseTraverse adjusts calculated points of the traverse with the method, so the last point lands on the first one,
+// then rebases calculators of adjusted points and removes the last point, because it duplicates the first one.
+// The least squares method uses transverseRatio or DefaultTransverseRatio if it's 0, other methods ignore it.
+func (pol *Polygon) CloseTraverse(method AdjustmentMethod, transverseRatio float64) (*Closure, error) {
+	if transverseRatio < 0 {
+		return nil, fmt.Errorf("%w: negative transverse ratio %v", ErrWrongMeasurement, transverseRatio)
+	}
+	if transverseRatio == 0 {
+		transverseRatio = DefaultTransverseRatio
+	}
+	m, err := pol.Misclosure()
+	if err != nil {
+		return nil, err
+	}
+	start, _ := pol.traverseStart()
+	n := pol.Len()
+	legs := make([]*Point, 0, n-start-1)
+	for i := start + 1; i < n; i++ {
+		legs = append(legs, &Point{X: pol.Points[i].X - pol.Points[i-1].X, Y: pol.Points[i].Y - pol.Points[i-1].Y})
+	}
+	switch method {
+	case AdjustmentNone:
+	case AdjustmentCompass:
+		adjustLegsByCompass(legs, m)
+	case AdjustmentTransit:
+		adjustLegsByTransit(legs, m)
+	case AdjustmentLeastSquares:
+		adjustLegsByLeastSquares(legs, m, transverseRatio)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAdjustmentMethod, method)
+	}
+	if method != AdjustmentNone {
+		for i, l := range legs {
+			p, pp := pol.Points[start+1+i], pol.Points[start+i]
+			p.X, p.Y = pp.X+l.X, pp.Y+l.Y
+		}
+	}
+	pol.Points = pol.Points[:n-1]
+	diagonals := make([]*Measurement, 0, len(pol.Diagonals))
+	for _, d := range pol.Diagonals {
+		// the removed last point coincides with the first one
+		if d.A == n-1 {
+			d.A = 0
+		}
+		if d.B == n-1 {
+			d.B = 0
+		}
+		if d.A != d.B {
+			diagonals = append(diagonals, d)
+		}
+	}
+	pol.Diagonals = diagonals
+	for i := start + 1; i < n-1; i++ {
+		if err := pol.rebasePoint(i); err != nil {
+			return nil, err
+		}
+	}
+	c := &Closure{Method: method, Misclosure: *m}
+	if method == AdjustmentLeastSquares {
+		c.TransverseRatio = transverseRatio
+	}
+	return c, nil
+}
+
+// adjustLegsByCompass changes legs by the compass (Bowditch) rule.
+func adjustLegsByCompass(legs []*Point, m *Misclosure) {
+	if m.Length == 0 {
+		return
+	}
+	for _, l := range legs {
+		k := math.Hypot(l.X, l.Y) / m.Length
+		l.X, l.Y = l.X-m.DX*k, l.Y-m.DY*k
+	}
+}
+
+// adjustLegsByTransit changes legs by the transit rule.
+func adjustLegsByTransit(legs []*Point, m *Misclosure) {
+	var sumX, sumY float64
+	for _, l := range legs {
+		sumX, sumY = sumX+math.Abs(l.X), sumY+math.Abs(l.Y)
+	}
+	for _, l := range legs {
+		if sumX != 0 {
+			l.X -= m.DX * math.Abs(l.X) / sumX
+		}
+		if sumY != 0 {
+			l.Y -= m.DY * math.Abs(l.Y) / sumY
+		}
+	}
+}
+
+// adjustLegsByLeastSquares changes legs by the weighted least squares method.
+// Variances of a leg length and of a leg transverse shift are both proportional to the length with
+// transverseRatio between them, so the result doesn't depend on the unit and the scale of the drawing.
+// The weighted sum of squared corrections is minimal while the legs close the traverse.
+func adjustLegsByLeastSquares(legs []*Point, m *Misclosure, transverseRatio float64) {
+	weights := make([][3]float64, len(legs))
+	var sum [3]float64
+	for i, l := range legs {
+		length := math.Hypot(l.X, l.Y)
+		if length == 0 {
+			continue
+		}
+		c, s := l.X/length, l.Y/length
+		along, across := length, transverseRatio*length
+		weights[i] = [3]float64{along*c*c + across*s*s, (along - across) * c * s, along*s*s + across*c*c}
+		for j := range sum {
+			sum[j] += weights[i][j]
+		}
+	}
+	det := sum[0]*sum[2] - sum[1]*sum[1]
+	if det == 0 {
+		adjustLegsByCompass(legs, m)
+		return
+	}
+	lx := (sum[2]*m.DX - sum[1]*m.DY) / det
+	ly := (sum[0]*m.DY - sum[1]*m.DX) / det
+	for i, l := range legs {
+		w := weights[i]
+		l.X, l.Y = l.X-(w[0]*lx+w[1]*ly), l.Y-(w[1]*lx+w[2]*ly)
+	}
+}
@@ -0,0 +1,174 @@
+package figure
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/maxsid/goCeilings/value"
+)
+
+// newTraverseExample returns a rectangle 400x300 traverse, whose last point misses the first one by (-0.4;-0.5).
+func newTraverseExample() *Polygon {
+	return newScaledTraverseExample(1)
+}
+
+// newScaledTraverseExample returns the traverse of newTraverseExample with distances multiplied by the scale.
+func newScaledTraverseExample(scale float64) *Polygon {
+	return NewPolygon(
+		NewPoint(0, 0),
+		NewCalculatedPoint(&DirectionCalculator{Direction: ConvertToOne(Degree, 90), Distance: 300 * scale}),
+		NewCalculatedPoint(&AngleCalculator{Angle: ConvertToOne(Degree, 90), Distance: 400 * scale}),
+		NewCalculatedPoint(&AngleCalculator{Angle: ConvertToOne(Degree, 90), Distance: 300.5 * scale}),
+		NewCalculatedPoint(&DirectionCalculator{Direction: ConvertToOne(Degree, 180), Distance: 400.4 * scale}),
+	)
+}
+
+func TestPolygon_Misclosure(t *testing.T) {
+	tests := []struct {
+		name    string
+		pol     *Polygon
+		want    *Misclosure
+		wantErr error
+	}{
+		{
+			name: "Rectangle",
+			pol:  newTraverseExample(),
+			want: &Misclosure{DX: -0.4, DY: -0.5, Linear: 0.6403, Length: 1400.9, Ratio: 2187.84,
+				Angular: ConvertToOne(Degree, -0.0716)},
+		},
+		{
+			name:    "Not enough points",
+			pol:     NewPolygon(NewPoint(0, 0), NewCalculatedPoint(&DirectionCalculator{Distance: 1})),
+			wantErr: ErrNotEnoughPoints,
+		},
+		{
+			name:    "The last point is fixed",
+			pol:     NewPolygon(example1...),
+			wantErr: ErrPointDoesNotHaveCalculator,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pol.Misclosure()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Misclosure() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !compareFloats(got.DX, tt.want.DX, 1e-4) || !compareFloats(got.DY, tt.want.DY, 1e-4) ||
+				!compareFloats(got.Linear, tt.want.Linear, 1e-4) || !compareFloats(got.Length, tt.want.Length, 1e-4) ||
+				!compareFloats(got.Ratio, tt.want.Ratio, 1e-2) || !compareFloats(got.Angular, tt.want.Angular, 1e-5) {
+				t.Errorf("Misclosure() got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygon_CloseTraverse(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  AdjustmentMethod
+		ratio   float64
+		want    []*Point
+		wantErr bool
+	}{
+		{
+			name:   "None",
+			method: AdjustmentNone,
+			want:   []*Point{{X: 0, Y: 0}, {X: 0, Y: 300}, {X: 400, Y: 300}, {X: 400, Y: -0.5}},
+		},
+		{
+			name:   "Compass",
+			method: AdjustmentCompass,
+			want:   []*Point{{X: 0, Y: 0}, {X: 0.0857, Y: 300.1071}, {X: 400.1999, Y: 300.2498}, {X: 400.2857, Y: -0.1429}},
+		},
+		{
+			name:   "Transit",
+			method: AdjustmentTransit,
+			want:   []*Point{{X: 0, Y: 0}, {X: 0, Y: 300.2498}, {X: 400.1999, Y: 300.2498}, {X: 400.1999, Y: 0}},
+		},
+		{
+			name:   "Least squares",
+			method: AdjustmentLeastSquares,
+			want:   []*Point{{X: 0, Y: 0}, {X: 0.1199, Y: 300.0681}, {X: 400.1999, Y: 300.2499}, {X: 400.32, Y: -0.1819}},
+		},
+		{
+			name:   "Least squares like compass",
+			method: AdjustmentLeastSquares,
+			ratio:  1,
+			want:   []*Point{{X: 0, Y: 0}, {X: 0.0857, Y: 300.1071}, {X: 400.1999, Y: 300.2498}, {X: 400.2857, Y: -0.1429}},
+		},
+		{
+			name:   "Least squares with inaccurate angles",
+			method: AdjustmentLeastSquares,
+			ratio:  10,
+			want:   []*Point{{X: 0, Y: 0}, {X: 0.1763, Y: 300.0174}, {X: 400.1998, Y: 300.2499}, {X: 400.3765, Y: -0.2327}},
+		},
+		{
+			name:    "Negative ratio",
+			method:  AdjustmentLeastSquares,
+			ratio:   -1,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown method",
+			method:  "bad",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := newTraverseExample()
+			got, err := pol.CloseTraverse(tt.method, tt.ratio)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CloseTraverse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Method != tt.method || !compareFloats(got.Linear, 0.6403, 1e-4) {
+				t.Errorf("CloseTraverse() got %+v", got)
+			}
+			wantRatio := 0.0
+			if tt.method == AdjustmentLeastSquares {
+				wantRatio = tt.ratio
+				if wantRatio == 0 {
+					wantRatio = DefaultTransverseRatio
+				}
+			}
+			if got.TransverseRatio != wantRatio {
+				t.Errorf("CloseTraverse() transverse ratio = %v, want %v", got.TransverseRatio, wantRatio)
+			}
+			if err := comparePointsSlices(tt.want, pol.Points, 1e-3); err != nil {
+				t.Error(err)
+			}
+			// calculators have to give the same coordinates after rebasing
+			if err := pol.CalculatePoints(); err != nil {
+				t.Error(err)
+			}
+			if err := comparePointsSlices(tt.want, pol.Points, 1e-3); err != nil {
+				t.Errorf("After recalculating: %v", err)
+			}
+		})
+	}
+}
+
+func TestPolygon_CloseTraverse_Scale(t *testing.T) {
+	for _, method := range []AdjustmentMethod{AdjustmentCompass, AdjustmentTransit, AdjustmentLeastSquares} {
+		pol, scaled := newTraverseExample(), newScaledTraverseExample(0.01)
+		if _, err := pol.CloseTraverse(method, 0); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := scaled.CloseTraverse(method, 0); err != nil {
+			t.Fatal(err)
+		}
+		for i, p := range scaled.Points {
+			if want := (&Point{X: pol.Points[i].X / 100, Y: pol.Points[i].Y / 100}); !comparePoints(want, p, 1e-9) {
+				t.Errorf("CloseTraverse(%s) depends on the scale: point %d = %v, want %v", method, i, p, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package figure
+
+import "math"
+
+// sideDirections returns directions of the side i at its start and at its end in radians.
+// Directions of a curved side are tangents of the arc.
+func (pol *Polygon) sideDirections(i int) (start, end float64) {
+	a, b := pol.Points[i], pol.Points[(i+1)%pol.Len()]
+	cs, err := pol.CurvedSide(i)
+	if err != nil || cs == nil {
+		d := math.Atan2(b.Y-a.Y, b.X-a.X)
+		return d, d
+	}
+	tangent := math.Copysign(math.Pi/2, cs.Sweep)
+	return cs.Start + tangent, cs.Start + cs.Sweep + tangent
+}
+
+// vertexTurn returns the angle in radians, which the outline turns by at the point i.
+// It's positive for turning to the left.
+func (pol *Polygon) vertexTurn(i int) float64 {
+	_, in := pol.sideDirections((i + pol.Len() - 1) % pol.Len())
+	out, _ := pol.sideDirections(i)
+	return normalizeAngle(out - in)
+}
+
+// straightTurn is the turn in radians at a point, which is considered as lying on the straight line.
+const straightTurn = 1e-6
+
+// CornerType is a type of the vertex of the polygon by the angle inside of the room.
+type CornerType string
+
+const (
+	// CornerInner is the vertex with the angle less than 180 degrees (convex).
+	CornerInner CornerType = "inner"
+	// CornerOuter is the vertex with the angle more than 180 degrees (concave).
+	CornerOuter CornerType = "outer"
+	// CornerStraight is the vertex on the straight line, it isn't a corner.
+	CornerStraight CornerType = "straight"
+)
+
+// Corner is the interior angle of the polygon at a vertex in radians. Directions of curved sides are tangents
+// of arcs. Bisector is the direction in radians, which divides the angle in half and goes inside of the polygon.
+type Corner struct {
+	Angle    float64    `json:"angle"`
+	Type     CornerType `json:"type"`
+	Bisector float64    `json:"-"`
+}
+
+// Corners returns interior angles of the polygon by vertexes in the order of points. Both clockwise and
+// counterclockwise polygons are supported.
+func (pol *Polygon) Corners() []*Corner {
+	if pol.Len() < 3 {
+		return nil
+	}
+	orientation := math.Copysign(1, pol.signedArea())
+	corners := make([]*Corner, pol.Len())
+	for i := range pol.Points {
+		turn := pol.vertexTurn(i) * orientation
+		c := &Corner{Angle: math.Pi - turn, Type: CornerStraight}
+		switch {
+		case math.Abs(turn) < straightTurn:
+		case turn > 0:
+			c.Type = CornerInner
+		default:
+			c.Type = CornerOuter
+		}
+		// the interior lies to the left of the outgoing side for counterclockwise polygons and to the right for clockwise ones
+		out, _ := pol.sideDirections(i)
+		c.Bisector = normalizeAngle(out + orientation*c.Angle/2)
+		corners[i] = c
+	}
+	return corners
+}
+
+// CornersCount returns numbers of inner corners (the angle inside of the room is less than 180 degrees)
+// and outer corners (the angle is more than 180 degrees). Points on the straight line aren't corners.
+func (pol *Polygon) CornersCount() (inner, outer int) {
+	for _, c := range pol.Corners() {
+		switch c.Type {
+		case CornerInner:
+			inner++
+		case CornerOuter:
+			outer++
+		}
+	}
+	return inner, outer
+}
@@ -0,0 +1,104 @@
+package figure
+
+import (
+	"math"
+	"testing"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+func TestPolygon_CornersCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		points    []*Point
+		wantInner int
+		wantOuter int
+	}{
+		{
+			name:      "Rectangle",
+			points:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}},
+			wantInner: 4,
+		},
+		{
+			name:      "Counterclockwise L-shape",
+			points:    []*Point{{X: 0, Y: 0}, {X: 6, Y: 0}, {X: 6, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 4}, {X: 0, Y: 4}},
+			wantInner: 5,
+			wantOuter: 1,
+		},
+		{
+			name:      "Point on the side",
+			points:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}},
+			wantInner: 4,
+		},
+		{
+			name: "Tangent arc",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}, {X: 4, Y: 0, Arc: &Arc{Radius: 2}},
+				{X: 4, Y: -2}},
+			wantInner: 3,
+		},
+		{
+			name:   "Not enough points",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, outer := (&Polygon{Points: tt.points}).CornersCount()
+			if inner != tt.wantInner || outer != tt.wantOuter {
+				t.Errorf("CornersCount() = %d, %d, want %d, %d", inner, outer, tt.wantInner, tt.wantOuter)
+			}
+		})
+	}
+}
+
+func TestPolygon_Corners(t *testing.T) {
+	tests := []struct {
+		name         string
+		points       []*Point
+		wantAngles   []float64
+		wantTypes    []CornerType
+		wantBisector []float64
+	}{
+		{
+			name:         "Clockwise L-shape",
+			points:       []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 2}, {X: 6, Y: 2}, {X: 6, Y: 0}},
+			wantAngles:   []float64{90, 90, 90, 270, 90, 90},
+			wantTypes:    []CornerType{CornerInner, CornerInner, CornerInner, CornerOuter, CornerInner, CornerInner},
+			wantBisector: []float64{45, -45, -135, -135, -135, 135},
+		},
+		{
+			name:         "Counterclockwise triangle with a point on the side",
+			points:       []*Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 4}},
+			wantAngles:   []float64{90, 180, 45, 45},
+			wantTypes:    []CornerType{CornerInner, CornerStraight, CornerInner, CornerInner},
+			wantBisector: []float64{45, 90, 157.5, -67.5},
+		},
+		{
+			name: "Tangent arc",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}, {X: 4, Y: 0, Arc: &Arc{Radius: 2}},
+				{X: 4, Y: -2}},
+			wantAngles: []float64{90 + math.Atan(0.5)*180/math.Pi, 90, 180, 180, math.Atan(2) * 180 / math.Pi},
+			wantTypes:  []CornerType{CornerInner, CornerInner, CornerStraight, CornerStraight, CornerInner},
+		},
+		{
+			name:   "Not enough points",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (&Polygon{Points: tt.points}).Corners()
+			if len(got) != len(tt.wantAngles) {
+				t.Fatalf("Corners() got %d corners, want %d", len(got), len(tt.wantAngles))
+			}
+			for i, c := range got {
+				if !compareFloats(value.ConvertFromOne(value.Degree, c.Angle), tt.wantAngles[i], 1e-9) || c.Type != tt.wantTypes[i] {
+					t.Errorf("Corners()[%d] = %v %v, want %v %v", i, value.ConvertFromOne(value.Degree, c.Angle), c.Type, tt.wantAngles[i], tt.wantTypes[i])
+				}
+				if tt.wantBisector != nil && !compareFloats(value.ConvertFromOne(value.Degree, c.Bisector), tt.wantBisector[i], 1e-9) {
+					t.Errorf("Corners()[%d] bisector = %v, want %v", i, value.ConvertFromOne(value.Degree, c.Bisector), tt.wantBisector[i])
+				}
+			}
+		})
+	}
+}
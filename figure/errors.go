@@ -13,4 +13,13 @@ var (
 	ErrVariableIsNotSlice = fmt.Errorf("%w: variable is not a slice", ErrInvalidType)
 
 	ErrPointDoesNotHaveCalculator = errors.New("point does not have a calculator")
+
+	ErrUnknownAdjustmentMethod = errors.New("unknown adjustment method")
+
+	ErrWrongMeasurement = errors.New("wrong measurement")
+	ErrUnsolvable       = errors.New("system of equations is unsolvable")
+
+	ErrImpossibleTriangle = fmt.Errorf("%w: impossible triangle", ErrWrongMeasurement)
+
+	ErrInvalidPolygon = errors.New("invalid polygon")
 )
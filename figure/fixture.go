@@ -0,0 +1,184 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// FixtureType is a kind of the fixture placed on the ceiling.
+type FixtureType string
+
+const (
+	FixtureLight      FixtureType = "light"
+	FixtureChandelier FixtureType = "chandelier"
+	FixturePipe       FixtureType = "pipe"
+	FixtureFan        FixtureType = "fan"
+)
+
+// FixtureTypes contains all known types of fixtures in the order of their output.
+var FixtureTypes = []FixtureType{FixtureLight, FixtureChandelier, FixturePipe, FixtureFan}
+
+// WallOffset is a distance from the side of the polygon to the fixture, where side i goes
+// from the point i to the point i+1.
+type WallOffset struct {
+	Side     int     `json:"side"`
+	Distance float64 `json:"distance"`
+}
+
+// Fixture is a point-like object on the ceiling: a light, a chandelier, a pipe or a fan. Its position is set
+// by X and Y or by Offsets from two walls, then X and Y are calculated. Diameter is optional. Drawings drop Offsets,
+// when their walls don't exist anymore, e.g. after merging, splitting or simplifying, and keep X and Y.
+type Fixture struct {
+	Type     FixtureType   `json:"type"`
+	X        float64       `json:"x"`
+	Y        float64       `json:"y"`
+	Diameter float64       `json:"diameter,omitempty"`
+	Offsets  []*WallOffset `json:"offsets,omitempty"`
+}
+
+// Center returns the position of the fixture.
+func (f *Fixture) Center() *Point {
+	return &Point{X: f.X, Y: f.Y}
+}
+
+func (f *Fixture) check() error {
+	known := false
+	for _, t := range FixtureTypes {
+		known = known || f.Type == t
+	}
+	switch {
+	case !known:
+		return fmt.Errorf("%w: unknown fixture type %q", ErrInvalidType, f.Type)
+	case f.Diameter < 0:
+		return fmt.Errorf("%w: diameter of the fixture can't be negative, got %v", ErrWrongMeasurement, f.Diameter)
+	case len(f.Offsets) != 0 && len(f.Offsets) != 2:
+		return fmt.Errorf("%w: the fixture needs offsets from two walls, got %d", ErrWrongMeasurement, len(f.Offsets))
+	}
+	return nil
+}
+
+func (f *Fixture) ConvertToOne(measures *value.FigureMeasures) {
+	f.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) })
+}
+
+func (f *Fixture) ConvertFromOne(measures *value.FigureMeasures) {
+	f.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) })
+}
+
+func (f *Fixture) convert(length func(float64) float64) {
+	f.X, f.Y, f.Diameter = length(f.X), length(f.Y), length(f.Diameter)
+	for _, o := range f.Offsets {
+		o.Distance = length(o.Distance)
+	}
+}
+
+// PlaceFixture checks the fixture and calculates its coordinates by offsets from walls, if they are set.
+// Offsets are measured inside of the polygon from straight sides. The fixture must lie inside of the polygon.
+func (pol *Polygon) PlaceFixture(f *Fixture) error {
+	if err := f.check(); err != nil {
+		return err
+	}
+	if len(f.Offsets) == 2 {
+		if pol.Len() < 3 {
+			return fmt.Errorf("%w for placing by walls (%d), must be at least 3", ErrNotEnoughPoints, pol.Len())
+		}
+		// the inner normal is on the right side of the clockwise polygon
+		orientation := -math.Copysign(1, pol.signedArea())
+		a, b := make([][]float64, 2), make([]float64, 2)
+		for i, o := range f.Offsets {
+			if o.Side < 0 || o.Side >= pol.Len() {
+				return fmt.Errorf("%w: side %d doesn't exist", ErrWrongMeasurement, o.Side)
+			}
+			if cs, _ := pol.CurvedSide(o.Side); cs != nil {
+				return fmt.Errorf("%w: offset from the curved side %d", ErrWrongMeasurement, o.Side)
+			}
+			s := pol.Sides()[o.Side]
+			length := s.Distance()
+			if length == 0 {
+				return fmt.Errorf("%w: side %d has zero length", ErrWrongMeasurement, o.Side)
+			}
+			nx, ny := orientation*(s.B.Y-s.A.Y)/length, -orientation*(s.B.X-s.A.X)/length
+			a[i], b[i] = []float64{nx, ny}, o.Distance+nx*s.A.X+ny*s.A.Y
+		}
+		xy, err := solveLinearSystem(a, b)
+		if err != nil {
+			return fmt.Errorf("%w: walls of offsets are parallel", ErrUnsolvable)
+		}
+		f.X, f.Y = xy[0], xy[1]
+	}
+	if !pol.ContainsPoint(f.Center()) {
+		return fmt.Errorf("%w: the fixture is out of the polygon at (%v;%v)", ErrWrongMeasurement, f.X, f.Y)
+	}
+	return nil
+}
+
+// WallDistance is the shortest distance from a point to the side of the polygon. Foot is the nearest point of the side.
+type WallDistance struct {
+	Side     int     `json:"side"`
+	Distance float64 `json:"distance"`
+	Foot     *Point  `json:"foot"`
+}
+
+// NearestWalls returns distances from the point to the n nearest sides of the polygon, sorted by the distance.
+func (pol *Polygon) NearestWalls(p *Point, n int) []*WallDistance {
+	walls := make([]*WallDistance, 0, pol.Len())
+	for i, s := range pol.Sides() {
+		if s.Distance() == 0 {
+			continue
+		}
+		foot := pol.wallFoot(i, s, p)
+		walls = append(walls, &WallDistance{Side: i, Distance: (&Segment{A: p, B: foot}).Distance(), Foot: foot})
+	}
+	sort.SliceStable(walls, func(i, j int) bool { return walls[i].Distance < walls[j].Distance })
+	if n < len(walls) {
+		walls = walls[:n]
+	}
+	return walls
+}
+
+// minWallDistance returns the distance from the point to the nearest side of the polygon like NearestWalls,
+// but without sorting all sides. Returns +Inf if the polygon has no sides.
+func (pol *Polygon) minWallDistance(p *Point) float64 {
+	distance := math.Inf(1)
+	for i, s := range pol.Sides() {
+		if s.Distance() == 0 {
+			continue
+		}
+		distance = math.Min(distance, (&Segment{A: p, B: pol.wallFoot(i, s, p)}).Distance())
+	}
+	return distance
+}
+
+// wallFoot returns the point of the side s by index i nearest to p, curved sides are taken into account.
+func (pol *Polygon) wallFoot(i int, s *Segment, p *Point) *Point {
+	if cs, _ := pol.CurvedSide(i); cs != nil {
+		return cs.nearestPoint(p)
+	}
+	return s.nearestPoint(p)
+}
+
+// nearestPoint returns the point of the segment nearest to p.
+func (l *Segment) nearestPoint(p *Point) *Point {
+	dx, dy := l.B.X-l.A.X, l.B.Y-l.A.Y
+	t := ((p.X-l.A.X)*dx + (p.Y-l.A.Y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return &Point{X: l.A.X + dx*t, Y: l.A.Y + dy*t}
+}
+
+// nearestPoint returns the point of the arc nearest to p.
+func (cs *CurvedSide) nearestPoint(p *Point) *Point {
+	dist := (&Segment{A: cs.Center, B: p}).Distance()
+	if dist > 0 {
+		onCircle := &Point{X: cs.Center.X + (p.X-cs.Center.X)*cs.Radius/dist, Y: cs.Center.Y + (p.Y-cs.Center.Y)*cs.Radius/dist}
+		if cs.containsDirection(onCircle) {
+			return onCircle
+		}
+	}
+	if (&Segment{A: p, B: cs.A}).Distance() < (&Segment{A: p, B: cs.B}).Distance() {
+		return cs.A
+	}
+	return cs.B
+}
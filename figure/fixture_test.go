@@ -0,0 +1,119 @@
+package figure
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolygon_PlaceFixture(t *testing.T) {
+	// the L-shape in the clockwise order
+	lShape := &Polygon{Points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 0}}}
+	tests := []struct {
+		name    string
+		pol     *Polygon
+		fixture *Fixture
+		wantX   float64
+		wantY   float64
+		wantErr error
+	}{
+		{
+			name:    "By coordinates",
+			pol:     lShape,
+			fixture: &Fixture{Type: FixtureLight, X: 1, Y: 3},
+			wantX:   1,
+			wantY:   3,
+		},
+		{
+			name:    "By walls",
+			pol:     lShape,
+			fixture: &Fixture{Type: FixtureChandelier, Offsets: []*WallOffset{{Side: 1, Distance: 1}, {Side: 2, Distance: 0.5}}},
+			wantX:   5.5,
+			wantY:   3,
+		},
+		{
+			name: "By walls of the counterclockwise polygon",
+			pol:  &Polygon{Points: []*Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 3}, {X: 0, Y: 3}}},
+			fixture: &Fixture{Type: FixturePipe, Diameter: 0.1,
+				Offsets: []*WallOffset{{Side: 0, Distance: 0.5}, {Side: 3, Distance: 1.5}}},
+			wantX: 1.5,
+			wantY: 0.5,
+		},
+		{
+			name:    "Parallel walls",
+			pol:     lShape,
+			fixture: &Fixture{Type: FixtureFan, Offsets: []*WallOffset{{Side: 1, Distance: 1}, {Side: 3, Distance: 1}}},
+			wantErr: ErrUnsolvable,
+		},
+		{
+			name:    "Out of the polygon",
+			pol:     lShape,
+			fixture: &Fixture{Type: FixtureLight, X: 4, Y: 1},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Unknown type",
+			pol:     lShape,
+			fixture: &Fixture{Type: "lamp", X: 1, Y: 1},
+			wantErr: ErrInvalidType,
+		},
+		{
+			name:    "One wall",
+			pol:     lShape,
+			fixture: &Fixture{Type: FixtureLight, Offsets: []*WallOffset{{Side: 1, Distance: 1}}},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Wrong side",
+			pol:     lShape,
+			fixture: &Fixture{Type: FixtureLight, Offsets: []*WallOffset{{Side: 1, Distance: 1}, {Side: 6, Distance: 1}}},
+			wantErr: ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pol.PlaceFixture(tt.fixture)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PlaceFixture() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && (!compareFloats(tt.fixture.X, tt.wantX, 1e-9) || !compareFloats(tt.fixture.Y, tt.wantY, 1e-9)) {
+				t.Errorf("PlaceFixture() got (%v;%v), want (%v;%v)", tt.fixture.X, tt.fixture.Y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+func TestPolygon_NearestWalls(t *testing.T) {
+	pol := &Polygon{Points: []*Point{{X: 0, Y: 0, Arc: &Arc{Sagitta: 1}}, {X: 0, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 0}}}
+	tests := []struct {
+		name string
+		p    *Point
+		want []*WallDistance
+	}{
+		{
+			name: "Straight walls",
+			p:    &Point{X: 1, Y: 3},
+			want: []*WallDistance{{Side: 0, Distance: 1, Foot: &Point{X: 0, Y: 3}}, {Side: 1, Distance: 1, Foot: &Point{X: 1, Y: 4}}},
+		},
+		{
+			name: "Curved wall",
+			p:    &Point{X: 3, Y: 0.5},
+			want: []*WallDistance{{Side: 3, Distance: 1.5, Foot: &Point{X: 3, Y: -1}}, {Side: 0, Distance: 3, Foot: &Point{X: 0, Y: 0.5}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pol.NearestWalls(tt.p, 2)
+			if len(got) != len(tt.want) {
+				t.Errorf("NearestWalls() got %d walls, want %d", len(got), len(tt.want))
+				return
+			}
+			for i, w := range got {
+				if w.Side != tt.want[i].Side || !compareFloats(w.Distance, tt.want[i].Distance, 1e-9) ||
+					!compareFloats(w.Foot.X, tt.want[i].Foot.X, 1e-9) || !compareFloats(w.Foot.Y, tt.want[i].Foot.Y, 1e-9) {
+					t.Errorf("NearestWalls()[%d] = %+v, foot %+v, want %+v, foot %+v", i, w, w.Foot, tt.want[i], tt.want[i].Foot)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// Hole is a part inside of the polygon, which isn't covered (column, shaft, skylight).
+// It's a polygon by Points or a circle by Center and Radius.
+type Hole struct {
+	Points []*Point `json:"points,omitempty"`
+	Center *Point   `json:"center,omitempty"`
+	Radius float64  `json:"radius,omitempty"`
+}
+
+// NewPolygonHole creates a hole by points of its outline.
+func NewPolygonHole(points ...*Point) (*Hole, error) {
+	h := &Hole{Points: points}
+	if err := h.check(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// NewCircleHole creates a circle hole.
+func NewCircleHole(center *Point, radius float64) (*Hole, error) {
+	h := &Hole{Center: center, Radius: radius}
+	if err := h.check(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// IsCircle returns true if the hole is a circle.
+func (h *Hole) IsCircle() bool {
+	return h.Center != nil
+}
+
+// check returns an error if the hole is neither a polygon, nor a circle.
+func (h *Hole) check() error {
+	switch {
+	case h.IsCircle() && len(h.Points) > 0:
+		return fmt.Errorf("%w: hole can't be a polygon and a circle at once", ErrWrongMeasurement)
+	case h.IsCircle() && h.Radius <= 0:
+		return fmt.Errorf("%w: radius of the hole must be positive, got %v", ErrWrongMeasurement, h.Radius)
+	case h.IsCircle():
+		return nil
+	case len(h.Points) < 3:
+		return fmt.Errorf("%w for a hole (%d), must be at least 3", ErrNotEnoughPoints, len(h.Points))
+	}
+	pol := h.Polygon()
+	if err := pol.checkArcs(); err != nil {
+		return err
+	}
+	if pol.Area() == 0 {
+		return fmt.Errorf("%w: hole has zero area", ErrWrongMeasurement)
+	}
+	return nil
+}
+
+// Polygon returns the polygon of the hole outline or nil if it's a circle.
+func (h *Hole) Polygon() *Polygon {
+	if h.IsCircle() {
+		return nil
+	}
+	return &Polygon{Points: h.Points}
+}
+
+func (h *Hole) Area() float64 {
+	if h.IsCircle() {
+		return math.Pi * h.Radius * h.Radius
+	}
+	return h.Polygon().Area()
+}
+
+func (h *Hole) Perimeter() float64 {
+	if h.IsCircle() {
+		return 2 * math.Pi * h.Radius
+	}
+	return h.Polygon().Perimeter()
+}
+
+// BoundaryPoints returns points, which the hole must have inside of the polygon for lying inside:
+// vertexes and extreme points of arcs for a polygon and extreme points for a circle.
+func (h *Hole) BoundaryPoints() []*Point {
+	if h.IsCircle() {
+		c, r := h.Center, h.Radius
+		return []*Point{{X: c.X + r, Y: c.Y}, {X: c.X, Y: c.Y + r}, {X: c.X - r, Y: c.Y}, {X: c.X, Y: c.Y - r}}
+	}
+	return h.Polygon().boundaryPoints()
+}
+
+// LabelPoint returns the point for placing a title of the hole: the center of a circle
+// or the middle of a polygon bounding box.
+func (h *Hole) LabelPoint() *Point {
+	if h.IsCircle() {
+		return h.Center
+	}
+	return h.Polygon().boundsCenter()
+}
+
+func (h *Hole) ConvertToOne(measures *value.FigureMeasures) {
+	h.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) },
+		func(a *Arc) { a.ConvertToOne(measures) })
+}
+
+func (h *Hole) ConvertFromOne(measures *value.FigureMeasures) {
+	h.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) },
+		func(a *Arc) { a.ConvertFromOne(measures) })
+}
+
+func (h *Hole) convert(length func(float64) float64, arc func(*Arc)) {
+	if h.Center != nil {
+		h.Center.X, h.Center.Y = length(h.Center.X), length(h.Center.Y)
+	}
+	h.Radius = length(h.Radius)
+	for _, p := range h.Points {
+		p.X, p.Y = length(p.X), length(p.Y)
+		if p.Arc != nil {
+			arc(p.Arc)
+		}
+	}
+}
+
+// CheckHole returns an error, if the hole is wrong or it doesn't lie inside of the polygon. Borders of the hole
+// and the polygon can touch, but can't cross each other.
+func (pol *Polygon) CheckHole(h *Hole) error {
+	if err := h.check(); err != nil {
+		return err
+	}
+	for _, p := range h.BoundaryPoints() {
+		if !pol.ContainsPoint(p) {
+			return fmt.Errorf("%w: the hole is out of the polygon at (%v;%v)", ErrWrongMeasurement, p.X, p.Y)
+		}
+	}
+	if h.IsCircle() && pol.minWallDistance(h.Center) < h.Radius-tolerance {
+		return fmt.Errorf("%w: the hole crosses a side of the polygon", ErrWrongMeasurement)
+	}
+	if !h.IsCircle() && pathsCross(h.Polygon().path(), pol.path()) {
+		return fmt.Errorf("%w: the hole crosses a side of the polygon", ErrWrongMeasurement)
+	}
+	return nil
+}
+
+// CheckHoles returns an error if any hole is wrong, lies out of the polygon or overlaps another hole,
+// so the area of holes isn't subtracted twice. Holes can touch each other.
+func (pol *Polygon) CheckHoles(holes ...*Hole) error {
+	for _, h := range holes {
+		if err := pol.CheckHole(h); err != nil {
+			return err
+		}
+	}
+	for i, h1 := range holes {
+		for j, h2 := range holes[i+1:] {
+			if holesOverlap(h1, h2) {
+				return fmt.Errorf("%w: holes %d and %d overlap", ErrWrongMeasurement, i, i+1+j)
+			}
+		}
+	}
+	return nil
+}
+
+// holesOverlap returns true if the holes have a common part, touching isn't overlapping.
+func holesOverlap(first, second *Hole) bool {
+	switch {
+	case first.IsCircle() && second.IsCircle():
+		return (&Segment{A: first.Center, B: second.Center}).Distance() < first.Radius+second.Radius-tolerance
+	case first.IsCircle():
+		return circleOverlapsPolygon(first, second.Polygon())
+	case second.IsCircle():
+		return circleOverlapsPolygon(second, first.Polygon())
+	}
+	p1, p2 := first.Polygon(), second.Polygon()
+	return levelsOverlap(p1, p2) || levelInside(p1, p2) || levelInside(p2, p1)
+}
+
+// circleOverlapsPolygon returns true if the circle hole and the polygon have a common part.
+func circleOverlapsPolygon(circle *Hole, pol *Polygon) bool {
+	return pol.ContainsPoint(circle.Center) || pol.minWallDistance(circle.Center) < circle.Radius-tolerance
+}
@@ -0,0 +1,185 @@
+package figure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestHole_AreaAndPerimeter(t *testing.T) {
+	tests := []struct {
+		name          string
+		hole          *Hole
+		wantArea      float64
+		wantPerimeter float64
+	}{
+		{
+			name:          "Polygon",
+			hole:          &Hole{Points: []*Point{{X: 1, Y: 1}, {X: 1, Y: 1.5}, {X: 1.4, Y: 1.5}, {X: 1.4, Y: 1}}},
+			wantArea:      0.2,
+			wantPerimeter: 1.8,
+		},
+		{
+			name:          "Circle",
+			hole:          &Hole{Center: &Point{X: 1, Y: 1}, Radius: 0.5},
+			wantArea:      math.Pi * 0.25,
+			wantPerimeter: math.Pi,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hole.Area(); !compareFloats(got, tt.wantArea, 1e-9) {
+				t.Errorf("Area() = %v, want %v", got, tt.wantArea)
+			}
+			if got := tt.hole.Perimeter(); !compareFloats(got, tt.wantPerimeter, 1e-9) {
+				t.Errorf("Perimeter() = %v, want %v", got, tt.wantPerimeter)
+			}
+		})
+	}
+}
+
+func TestNewPolygonHole(t *testing.T) {
+	tests := []struct {
+		name    string
+		points  []*Point
+		wantErr error
+	}{
+		{
+			name:   "OK",
+			points: []*Point{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 1}},
+		},
+		{
+			name:    "Not enough points",
+			points:  []*Point{{X: 1, Y: 1}, {X: 1, Y: 2}},
+			wantErr: ErrNotEnoughPoints,
+		},
+		{
+			name:    "Zero area",
+			points:  []*Point{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 1, Y: 3}},
+			wantErr: ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPolygonHole(tt.points...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewPolygonHole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := NewCircleHole(&Point{X: 1, Y: 1}, 0); !errors.Is(err, ErrWrongMeasurement) {
+		t.Errorf("NewCircleHole() error = %v, wantErr %v", err, ErrWrongMeasurement)
+	}
+}
+
+func TestPolygon_CheckHole(t *testing.T) {
+	tests := []struct {
+		name    string
+		pol     *Polygon
+		hole    *Hole
+		wantErr bool
+	}{
+		{
+			name: "Polygon inside",
+			pol:  newNotchExample(),
+			hole: &Hole{Points: []*Point{{X: 10, Y: 10}, {X: 10, Y: 20}, {X: 20, Y: 20}, {X: 20, Y: 10}}},
+		},
+		{
+			name: "Polygon touches the border",
+			pol:  newNotchExample(),
+			hole: &Hole{Points: []*Point{{X: 0, Y: 10}, {X: 0, Y: 20}, {X: 20, Y: 20}, {X: 20, Y: 10}}},
+		},
+		{
+			name:    "Polygon in the notch",
+			pol:     newNotchExample(),
+			hole:    &Hole{Points: []*Point{{X: 10, Y: 130}, {X: 10, Y: 140}, {X: 20, Y: 140}}},
+			wantErr: true,
+		},
+		{
+			name:    "Polygon crosses the inner corner",
+			pol:     newNotchExample(),
+			hole:    &Hole{Points: []*Point{{X: 10, Y: 120}, {X: 40, Y: 140}, {X: 40, Y: 110}}},
+			wantErr: true,
+		},
+		{
+			name:    "Circle crosses the inner corner",
+			pol:     newNotchExample(),
+			hole:    &Hole{Center: &Point{X: 34, Y: 118}, Radius: 12},
+			wantErr: true,
+		},
+		{
+			name: "Circle near the inner corner",
+			pol:  newNotchExample(),
+			hole: &Hole{Center: &Point{X: 34, Y: 118}, Radius: 9.5},
+		},
+		{
+			name: "Circle in the outer arc",
+			pol:  newArcExample(&Arc{Sagitta: 2, Right: true}),
+			hole: &Hole{Center: &Point{X: 2, Y: 3}, Radius: 0.5},
+		},
+		{
+			name:    "Circle in the inner arc",
+			pol:     newArcExample(&Arc{Sagitta: 1}),
+			hole:    &Hole{Center: &Point{X: 2, Y: 1.5}, Radius: 0.2},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.pol.CheckHole(tt.hole); (err != nil) != tt.wantErr {
+				t.Errorf("CheckHole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolygon_CheckHoles(t *testing.T) {
+	square := func(x, y, size float64) *Hole {
+		return &Hole{Points: []*Point{{X: x, Y: y}, {X: x, Y: y + size}, {X: x + size, Y: y + size}, {X: x + size, Y: y}}}
+	}
+	tests := []struct {
+		name    string
+		holes   []*Hole
+		wantErr bool
+	}{
+		{
+			name:  "Apart",
+			holes: []*Hole{square(10, 10, 20), {Center: &Point{X: 100, Y: 50}, Radius: 10}, square(150, 10, 20)},
+		},
+		{
+			name:  "Touching",
+			holes: []*Hole{square(10, 10, 20), square(30, 10, 20), {Center: &Point{X: 60, Y: 20}, Radius: 10}},
+		},
+		{
+			name:    "Polygons overlap",
+			holes:   []*Hole{square(10, 10, 20), square(20, 20, 20)},
+			wantErr: true,
+		},
+		{
+			name:    "Polygon inside of polygon",
+			holes:   []*Hole{square(10, 10, 40), square(10, 10, 20)},
+			wantErr: true,
+		},
+		{
+			name:    "Circles overlap",
+			holes:   []*Hole{{Center: &Point{X: 100, Y: 50}, Radius: 10}, {Center: &Point{X: 115, Y: 50}, Radius: 10}},
+			wantErr: true,
+		},
+		{
+			name:    "Circle inside of polygon",
+			holes:   []*Hole{square(10, 10, 40), {Center: &Point{X: 30, Y: 30}, Radius: 5}},
+			wantErr: true,
+		},
+		{
+			name:    "Circle crosses polygon",
+			holes:   []*Hole{square(10, 10, 20), {Center: &Point{X: 35, Y: 20}, Radius: 10}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newNotchExample().CheckHoles(tt.holes...); (err != nil) != tt.wantErr {
+				t.Errorf("CheckHoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
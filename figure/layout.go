@@ -0,0 +1,221 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// Rolls are available widths of canvas rolls and the direction of seams in radians.
+type Rolls struct {
+	Widths    []float64 `json:"widths"`
+	Direction float64   `json:"direction"`
+}
+
+func (r *Rolls) ConvertToOne(measures *value.FigureMeasures) {
+	for i, w := range r.Widths {
+		r.Widths[i] = value.ConvertToOne(measures.Length, w)
+	}
+	r.Direction = value.ConvertToOne(measures.Angle, r.Direction)
+}
+
+func (r *Rolls) ConvertFromOne(measures *value.FigureMeasures) {
+	for i, w := range r.Widths {
+		r.Widths[i] = value.ConvertFromOne(measures.Length, w)
+	}
+	r.Direction = value.ConvertFromOne(measures.Angle, r.Direction)
+}
+
+func (r *Rolls) check() error {
+	if len(r.Widths) == 0 {
+		return fmt.Errorf("%w: widths of rolls are not specified", ErrWrongMeasurement)
+	}
+	for _, w := range r.Widths {
+		if w <= 0 {
+			return fmt.Errorf("%w: width of a roll must be positive, got %v", ErrWrongMeasurement, w)
+		}
+	}
+	return nil
+}
+
+// Strip is a part of the canvas cut from one roll. Width is a part of the roll width, which covers the ceiling,
+// Material is the area of the roll spent on the strip.
+type Strip struct {
+	RollWidth float64 `json:"roll_width"`
+	Width     float64 `json:"width"`
+	Length    float64 `json:"length"`
+	Material  float64 `json:"material"`
+}
+
+// Layout is the ceiling split into strips along the seams direction. Seams are parts of the seam lines
+// inside of the polygon. Waste is the material, which doesn't cover the ceiling.
+type Layout struct {
+	Direction float64    `json:"direction"`
+	Strips    []*Strip   `json:"strips"`
+	Seams     []*Segment `json:"-"`
+	Material  float64    `json:"material"`
+	Waste     float64    `json:"waste"`
+}
+
+// layoutStep is the best choice of the rest strips from some offset across the seams.
+type layoutStep struct {
+	cost   float64
+	widths []float64
+}
+
+// layoutPlanner keeps the polygon sides rotated so seams go along X axis.
+type layoutPlanner struct {
+	sides      []*Segment
+	vMin, vMax float64
+	widths     []float64
+	steps      map[[2]int64]*layoutStep
+}
+
+// Layout splits the polygon into strips of the rolls with seams in the rolls direction. The number of seams
+// is the least possible and widths of rolls are chosen with the least waste of the material.
+func (pol *Polygon) Layout(r *Rolls) (*Layout, error) {
+	if err := r.check(); err != nil {
+		return nil, err
+	}
+	if pol.Len() < 3 {
+		return nil, fmt.Errorf("%w for layout (%d), must be at least 3", ErrNotEnoughPoints, pol.Len())
+	}
+	lp := &layoutPlanner{widths: r.Widths, steps: make(map[[2]int64]*layoutStep)}
+	sin, cos := math.Sincos(r.Direction)
+	rotate := func(p *Point) *Point { return &Point{X: p.X*cos + p.Y*sin, Y: -p.X*sin + p.Y*cos} }
+	lp.vMin, lp.vMax = math.Inf(1), math.Inf(-1)
+	for i := range pol.Points {
+		for _, s := range pol.sidePath(i) {
+			rs := &Segment{A: rotate(s.A), B: rotate(s.B)}
+			lp.sides = append(lp.sides, rs)
+			lp.vMin, lp.vMax = math.Min(lp.vMin, math.Min(rs.A.Y, rs.B.Y)), math.Max(lp.vMax, math.Max(rs.A.Y, rs.B.Y))
+		}
+	}
+
+	maxWidth := 0.0
+	for _, w := range r.Widths {
+		maxWidth = math.Max(maxWidth, w)
+	}
+	count := int(math.Ceil((lp.vMax-lp.vMin)/maxWidth - tolerance))
+	if count < 1 {
+		count = 1
+	}
+	step := lp.best(count, 0)
+
+	layout := &Layout{Direction: r.Direction}
+	offset := lp.vMin
+	for i, w := range step.widths {
+		top := math.Min(offset+w, lp.vMax)
+		length := lp.bandLength(offset, top)
+		layout.Strips = append(layout.Strips, &Strip{RollWidth: w, Width: top - offset, Length: length, Material: w * length})
+		layout.Material += w * length
+		if i != len(step.widths)-1 {
+			for _, s := range lp.seam(top) {
+				// rotating back to the polygon coordinates
+				layout.Seams = append(layout.Seams, &Segment{
+					A: &Point{X: s.A.X*cos - s.A.Y*sin, Y: s.A.X*sin + s.A.Y*cos},
+					B: &Point{X: s.B.X*cos - s.B.Y*sin, Y: s.B.X*sin + s.B.Y*cos},
+				})
+			}
+		}
+		offset = top
+	}
+	layout.Waste = layout.Material - pol.Area()
+	return layout, nil
+}
+
+// best returns the cheapest widths of count strips, which cover the polygon from offset.
+func (lp *layoutPlanner) best(count int, offset float64) *layoutStep {
+	key := [2]int64{int64(count), int64(math.Round(offset / tolerance))}
+	if s, ok := lp.steps[key]; ok {
+		return s
+	}
+	rest, maxWidth := lp.vMax-lp.vMin-offset, 0.0
+	for _, w := range lp.widths {
+		maxWidth = math.Max(maxWidth, w)
+	}
+	var best *layoutStep
+	for _, w := range lp.widths {
+		var next *layoutStep
+		switch {
+		case count == 1 && w < rest-tolerance:
+			// the last strip doesn't cover the rest
+			continue
+		case count > 1 && (w >= rest-tolerance || rest-w > float64(count-1)*maxWidth+tolerance):
+			// the rest strips are unnecessary or can't cover the rest
+			continue
+		case count > 1:
+			if next = lp.best(count-1, offset+w); next == nil {
+				continue
+			}
+		}
+		bottom := lp.vMin + offset
+		cost := w * lp.bandLength(bottom, math.Min(bottom+w, lp.vMax))
+		if next != nil {
+			cost += next.cost
+		}
+		if best == nil || cost < best.cost-tolerance {
+			best = &layoutStep{cost: cost, widths: []float64{w}}
+			if next != nil {
+				best.widths = append(best.widths, next.widths...)
+			}
+		}
+	}
+	lp.steps[key] = best
+	return best
+}
+
+// bandLength returns length of the polygon part between v0 and v1 across the seams.
+func (lp *layoutPlanner) bandLength(v0, v1 float64) float64 {
+	uMin, uMax := math.Inf(1), math.Inf(-1)
+	for _, s := range lp.sides {
+		a, b := s.A, s.B
+		if a.Y > b.Y {
+			a, b = b, a
+		}
+		// sides touching the band only by its border don't lie in it
+		if b.Y <= v0 || a.Y >= v1 {
+			continue
+		}
+		// the part of the side inside of the band
+		for _, v := range []float64{math.Max(a.Y, v0), math.Min(b.Y, v1)} {
+			u := a.X
+			if b.Y != a.Y {
+				u = a.X + (b.X-a.X)*(v-a.Y)/(b.Y-a.Y)
+			}
+			uMin, uMax = math.Min(uMin, u), math.Max(uMax, u)
+			if b.Y == a.Y {
+				uMin, uMax = math.Min(uMin, b.X), math.Max(uMax, b.X)
+			}
+		}
+	}
+	if uMax < uMin {
+		return 0
+	}
+	return uMax - uMin
+}
+
+// seam returns parts of the line v across the seams, which lie inside of the polygon.
+func (lp *layoutPlanner) seam(v float64) []*Segment {
+	return chords(lp.sides, v)
+}
+
+// chords returns parts of the horizontal line y = v, which lie inside of the polygon by its sides.
+func chords(sides []*Segment, v float64) []*Segment {
+	us := make([]float64, 0)
+	for _, s := range sides {
+		a, b := s.A, s.B
+		// the half-open interval counts every vertex once
+		if (a.Y > v) != (b.Y > v) {
+			us = append(us, a.X+(b.X-a.X)*(v-a.Y)/(b.Y-a.Y))
+		}
+	}
+	sort.Float64s(us)
+	out := make([]*Segment, 0, len(us)/2)
+	for i := 0; i+1 < len(us); i += 2 {
+		out = append(out, &Segment{A: &Point{X: us[i], Y: v}, B: &Point{X: us[i+1], Y: v}})
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package figure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPolygon_Layout(t *testing.T) {
+	rectangle := []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 0}}
+	tests := []struct {
+		name       string
+		points     []*Point
+		rolls      Rolls
+		wantWidths []float64
+		wantLength []float64
+		wantWaste  float64
+		wantSeams  []*Segment
+		wantErr    error
+	}{
+		{
+			name:       "One strip",
+			points:     rectangle,
+			rolls:      Rolls{Widths: []float64{3.2, 5}},
+			wantWidths: []float64{5},
+			wantLength: []float64{6},
+			wantWaste:  6,
+			wantSeams:  []*Segment{},
+		},
+		{
+			name:       "Two strips without waste",
+			points:     rectangle,
+			rolls:      Rolls{Widths: []float64{3.2, 2}},
+			wantWidths: []float64{2, 2},
+			wantLength: []float64{6, 6},
+			wantSeams:  []*Segment{{A: &Point{X: 0, Y: 2}, B: &Point{X: 6, Y: 2}}},
+		},
+		{
+			name:       "Seams along Y axis",
+			points:     rectangle,
+			rolls:      Rolls{Widths: []float64{3.2, 5}, Direction: math.Pi / 2},
+			wantWidths: []float64{3.2, 3.2},
+			wantLength: []float64{4, 4},
+			wantWaste:  1.6,
+			wantSeams:  []*Segment{{A: &Point{X: 2.8, Y: 0}, B: &Point{X: 2.8, Y: 4}}},
+		},
+		{
+			name:       "L-shape",
+			points:     []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 2}, {X: 6, Y: 2}, {X: 6, Y: 0}},
+			rolls:      Rolls{Widths: []float64{2}},
+			wantWidths: []float64{2, 2},
+			wantLength: []float64{6, 2},
+			wantSeams:  []*Segment{{A: &Point{X: 0, Y: 2}, B: &Point{X: 2, Y: 2}}},
+		},
+		{
+			name:    "Without rolls",
+			points:  rectangle,
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Not enough points",
+			points:  rectangle[:2],
+			rolls:   Rolls{Widths: []float64{2}},
+			wantErr: ErrNotEnoughPoints,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&Polygon{Points: tt.points}).Layout(&tt.rolls)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Layout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got.Strips) != len(tt.wantWidths) {
+				t.Fatalf("Layout() got %d strips, want %d", len(got.Strips), len(tt.wantWidths))
+			}
+			for i, s := range got.Strips {
+				if !compareFloats(s.RollWidth, tt.wantWidths[i], 1e-9) || !compareFloats(s.Length, tt.wantLength[i], 1e-9) {
+					t.Errorf("Layout() strip %d = %+v, want roll width %v and length %v", i, s, tt.wantWidths[i], tt.wantLength[i])
+				}
+			}
+			if !compareFloats(got.Waste, tt.wantWaste, 1e-9) {
+				t.Errorf("Layout() waste = %v, want %v", got.Waste, tt.wantWaste)
+			}
+			if len(got.Seams) != len(tt.wantSeams) {
+				t.Fatalf("Layout() got %d seams, want %d", len(got.Seams), len(tt.wantSeams))
+			}
+			for i, s := range got.Seams {
+				w := tt.wantSeams[i]
+				if !compareFloats(s.A.X, w.A.X, 1e-9) || !compareFloats(s.A.Y, w.A.Y, 1e-9) ||
+					!compareFloats(s.B.X, w.B.X, 1e-9) || !compareFloats(s.B.Y, w.B.Y, 1e-9) {
+					t.Errorf("Layout() seam %d = (%v;%v)-(%v;%v), want (%v;%v)-(%v;%v)", i, s.A.X, s.A.Y, s.B.X, s.B.Y,
+						w.A.X, w.A.Y, w.B.X, w.B.Y)
+				}
+			}
+		})
+	}
+}
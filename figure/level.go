@@ -0,0 +1,281 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// MainLevel is the name of the level bounded by the polygon outline.
+const MainLevel = "main"
+
+// levelTolerance is the distance in metres, which a side of the level can be apart from the side of the
+// surrounding level and still lie on it.
+const levelTolerance = 1e-6
+
+// Level is a part of a multi-level ceiling: a named polygon by Points, which is lower than the main level by Drop.
+// Negative Drop raises the level above the main one. Levels can be nested one into another.
+type Level struct {
+	Name   string   `json:"name"`
+	Drop   float64  `json:"drop"`
+	Points []*Point `json:"points"`
+}
+
+// LevelSummary is calculations of one level. Area is the visible area of the level without nested levels,
+// Transition is the length of the vertical strip between the level and the surrounding one (Parent) without parts
+// on walls, TransitionArea is the area of the strip.
+type LevelSummary struct {
+	Name           string  `json:"name"`
+	Parent         string  `json:"parent,omitempty"`
+	Drop           float64 `json:"drop"`
+	Area           float64 `json:"area"`
+	Perimeter      float64 `json:"perimeter"`
+	Transition     float64 `json:"transition"`
+	TransitionArea float64 `json:"transition_area"`
+}
+
+// Polygon returns the polygon of the level outline.
+func (l *Level) Polygon() *Polygon {
+	return &Polygon{Points: l.Points}
+}
+
+// LabelPoint returns the point for placing a title of the level: the middle of its bounding box.
+func (l *Level) LabelPoint() *Point {
+	return l.Polygon().boundsCenter()
+}
+
+func (l *Level) ConvertToOne(measures *value.FigureMeasures) {
+	l.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) },
+		func(a *Arc) { a.ConvertToOne(measures) })
+}
+
+func (l *Level) ConvertFromOne(measures *value.FigureMeasures) {
+	l.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) },
+		func(a *Arc) { a.ConvertFromOne(measures) })
+}
+
+func (l *Level) convert(length func(float64) float64, arc func(*Arc)) {
+	l.Drop = length(l.Drop)
+	for _, p := range l.Points {
+		p.X, p.Y = length(p.X), length(p.Y)
+		if p.Arc != nil {
+			arc(p.Arc)
+		}
+	}
+}
+
+// check returns an error if the level doesn't have a name or its polygon is wrong.
+func (l *Level) check() error {
+	switch {
+	case l.Name == "" || l.Name == MainLevel:
+		return fmt.Errorf("%w: name of the level can't be empty or %q", ErrWrongMeasurement, MainLevel)
+	case l.Drop == 0:
+		return fmt.Errorf("%w: drop of the level %q can't be zero", ErrWrongMeasurement, l.Name)
+	case len(l.Points) < 3:
+		return fmt.Errorf("%w for the level %q (%d), must be at least 3", ErrNotEnoughPoints, l.Name, len(l.Points))
+	}
+	pol := l.Polygon()
+	if err := pol.checkArcs(); err != nil {
+		return err
+	}
+	if pol.Area() == 0 {
+		return fmt.Errorf("%w: level %q has zero area", ErrWrongMeasurement, l.Name)
+	}
+	return nil
+}
+
+// boundaryPoints returns vertexes and extreme points of arcs of the polygon.
+func (pol *Polygon) boundaryPoints() []*Point {
+	out := append([]*Point{}, pol.Points...)
+	for _, cs := range pol.CurvedSides() {
+		out = append(out, cs.ExtremePoints()...)
+	}
+	return out
+}
+
+// path returns the outline of the polygon by segments, where arcs are approximated by chords.
+func (pol *Polygon) path() []*Segment {
+	out := make([]*Segment, 0, pol.Len())
+	for i := range pol.Points {
+		out = append(out, pol.sidePath(i)...)
+	}
+	return out
+}
+
+// pathsCross returns true if any segments of the paths cross each other.
+func pathsCross(first, second []*Segment) bool {
+	for _, s1 := range first {
+		for _, s2 := range second {
+			if s1.Crosses(s2) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// strictlyContains returns true if the point lies inside of the polygon, but not on its border.
+func (pol *Polygon) strictlyContains(p *Point) bool {
+	if !pol.ContainsPoint(p) {
+		return false
+	}
+	for i, s := range pol.Sides() {
+		cs, _ := pol.CurvedSide(i)
+		if cs == nil && pointOnSegment(p, s) {
+			return false
+		}
+		if cs != nil && math.Abs((&Segment{A: cs.Center, B: p}).Distance()-cs.Radius) <= tolerance*math.Max(1, cs.Radius) &&
+			cs.containsDirection(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// levelInside returns true if the polygon inner lies inside of the polygon outer, borders can touch.
+func levelInside(inner, outer *Polygon) bool {
+	for _, p := range inner.boundaryPoints() {
+		if !outer.ContainsPoint(p) {
+			return false
+		}
+	}
+	return !pathsCross(inner.path(), outer.path())
+}
+
+// levelsOverlap returns true if the polygons have a common part, but none of them lies inside of the other.
+func levelsOverlap(first, second *Polygon) bool {
+	if pathsCross(first.path(), second.path()) {
+		return true
+	}
+	for _, pair := range [][2]*Polygon{{first, second}, {second, first}} {
+		for _, p := range pair[0].boundaryPoints() {
+			if pair[1].strictlyContains(p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckLevels returns an error if any level is wrong, lies out of the polygon, has a repeated name
+// or crosses another level. A level can lie inside of another one.
+func (pol *Polygon) CheckLevels(levels ...*Level) error {
+	names := make(map[string]bool)
+	for _, l := range levels {
+		if err := l.check(); err != nil {
+			return err
+		}
+		if names[l.Name] {
+			return fmt.Errorf("%w: level %q is repeated", ErrWrongMeasurement, l.Name)
+		}
+		names[l.Name] = true
+		if !levelInside(l.Polygon(), pol) {
+			return fmt.Errorf("%w: level %q is out of the polygon", ErrWrongMeasurement, l.Name)
+		}
+	}
+	for i, l1 := range levels {
+		for _, l2 := range levels[i+1:] {
+			p1, p2 := l1.Polygon(), l2.Polygon()
+			in1, in2 := levelInside(p1, p2), levelInside(p2, p1)
+			switch {
+			case in1 && in2:
+				return fmt.Errorf("%w: levels %q and %q coincide", ErrWrongMeasurement, l1.Name, l2.Name)
+			case !in1 && !in2 && levelsOverlap(p1, p2):
+				return fmt.Errorf("%w: levels %q and %q cross each other", ErrWrongMeasurement, l1.Name, l2.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// levelsParents returns indexes of the nearest levels, which contain levels, or -1 for levels on the main one.
+func levelsParents(levels []*Level) []int {
+	parents := make([]int, len(levels))
+	for i, l := range levels {
+		parents[i] = -1
+		for j, outer := range levels {
+			if i == j || !levelInside(l.Polygon(), outer.Polygon()) {
+				continue
+			}
+			if parents[i] == -1 || outer.Polygon().Area() < levels[parents[i]].Polygon().Area() {
+				parents[i] = j
+			}
+		}
+	}
+	return parents
+}
+
+// LevelsSummary returns calculations of the main level and levels in the order of nesting: the main level is the
+// first, surrounding levels go before nested ones. Levels are expected to be checked by CheckLevels.
+func (pol *Polygon) LevelsSummary(levels ...*Level) []*LevelSummary {
+	parents := levelsParents(levels)
+	main := &LevelSummary{Name: MainLevel, Area: pol.Area(), Perimeter: pol.Perimeter()}
+	summaries := make([]*LevelSummary, len(levels))
+	for i, l := range levels {
+		lp := l.Polygon()
+		summaries[i] = &LevelSummary{Name: l.Name, Drop: l.Drop, Area: lp.Area(), Perimeter: lp.Perimeter()}
+	}
+	for i, l := range levels {
+		parent, parentPol, parentDrop := main, pol, 0.0
+		if parents[i] >= 0 {
+			parent, parentPol, parentDrop = summaries[parents[i]], levels[parents[i]].Polygon(), levels[parents[i]].Drop
+		}
+		parent.Area -= l.Polygon().Area()
+		summaries[i].Parent = parent.Name
+		summaries[i].Transition = transitionLength(l.Polygon(), parentPol)
+		summaries[i].TransitionArea = summaries[i].Transition * math.Abs(l.Drop-parentDrop)
+	}
+	depth := func(i int) int {
+		d := 0
+		for j := parents[i]; j >= 0; j = parents[j] {
+			d++
+		}
+		return d
+	}
+	order := make([]int, len(levels))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return depth(order[a]) < depth(order[b]) })
+	out := []*LevelSummary{main}
+	for _, i := range order {
+		out = append(out, summaries[i])
+	}
+	return out
+}
+
+// transitionLength returns the length of the level border, which doesn't lie on the border of the surrounding one.
+func transitionLength(level, outer *Polygon) float64 {
+	outerPath, length := outer.path(), 0.0
+	for i, sideLength := range level.SidesLengths() {
+		path := level.sidePath(i)
+		pathLength, overlap := 0.0, 0.0
+		for _, s := range path {
+			pathLength += s.Distance()
+			for _, o := range outerPath {
+				overlap += collinearOverlap(s, o)
+			}
+		}
+		if pathLength > 0 {
+			length += sideLength * (1 - math.Min(1, overlap/pathLength))
+		}
+	}
+	return length
+}
+
+// collinearOverlap returns the length of the common part of the segments lying on one line.
+func collinearOverlap(s, o *Segment) float64 {
+	length := s.Distance()
+	if length == 0 {
+		return 0
+	}
+	if math.Abs(crossProduct(s.A, s.B, o.A))/length > levelTolerance ||
+		math.Abs(crossProduct(s.A, s.B, o.B))/length > levelTolerance {
+		return 0
+	}
+	project := func(p *Point) float64 { return ((p.X-s.A.X)*(s.B.X-s.A.X) + (p.Y-s.A.Y)*(s.B.Y-s.A.Y)) / length }
+	u1, u2 := project(o.A), project(o.B)
+	return math.Max(0, math.Min(length, math.Max(u1, u2))-math.Max(0, math.Min(u1, u2)))
+}
@@ -0,0 +1,105 @@
+package figure
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+func TestPolygon_CheckLevels(t *testing.T) {
+	outline := []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 0}}
+	island := &Level{Name: "Island", Drop: 0.2, Points: []*Point{{X: 2, Y: 1}, {X: 2, Y: 3}, {X: 5, Y: 3}, {X: 5, Y: 1}}}
+	tests := []struct {
+		name    string
+		levels  []*Level
+		wantErr error
+	}{
+		{
+			name: "Nested and touching walls",
+			levels: []*Level{
+				{Name: "Bulkhead", Drop: 0.1, Points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 1, Y: 4}, {X: 1, Y: 0}}},
+				island,
+				{Name: "Niche", Drop: -0.05, Points: []*Point{{X: 2, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 2}, {X: 3, Y: 1}}},
+			},
+		},
+		{
+			name: "Touching levels",
+			levels: []*Level{island,
+				{Name: "Side", Drop: 0.1, Points: []*Point{{X: 5, Y: 1}, {X: 5, Y: 3}, {X: 6, Y: 3}, {X: 6, Y: 1}}}},
+		},
+		{
+			name: "Crossing levels",
+			levels: []*Level{island,
+				{Name: "Cross", Drop: 0.1, Points: []*Point{{X: 3, Y: 0}, {X: 3, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 0}}}},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name: "Coinciding levels",
+			levels: []*Level{island,
+				{Name: "Copy", Drop: 0.1, Points: []*Point{{X: 2, Y: 1}, {X: 2, Y: 3}, {X: 5, Y: 3}, {X: 5, Y: 1}}}},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Out of the polygon",
+			levels:  []*Level{{Name: "Out", Drop: 0.1, Points: []*Point{{X: 5, Y: 1}, {X: 5, Y: 3}, {X: 7, Y: 3}, {X: 7, Y: 1}}}},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Repeated name",
+			levels:  []*Level{island, {Name: "Island", Drop: 0.1, Points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}}},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Main name",
+			levels:  []*Level{{Name: MainLevel, Drop: 0.1, Points: island.Points}},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Zero drop",
+			levels:  []*Level{{Name: "Flat", Points: island.Points}},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Not enough points",
+			levels:  []*Level{{Name: "Line", Drop: 0.1, Points: island.Points[:2]}},
+			wantErr: ErrNotEnoughPoints,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewPolygon(outline...).CheckLevels(tt.levels...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckLevels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolygon_LevelsSummary(t *testing.T) {
+	pol := NewPolygon(&Point{X: 0, Y: 0}, &Point{X: 0, Y: 4}, &Point{X: 6, Y: 4}, &Point{X: 6, Y: 0})
+	levels := []*Level{
+		{Name: "Niche", Drop: -0.05, Points: []*Point{{X: 3, Y: 1.5}, {X: 3, Y: 2.5}, {X: 4, Y: 2.5}, {X: 4, Y: 1.5}}},
+		{Name: "Bulkhead", Drop: 0.1, Points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 1, Y: 4}, {X: 1, Y: 0}}},
+		{Name: "Island", Drop: 0.2, Points: []*Point{{X: 2, Y: 1}, {X: 2, Y: 3}, {X: 5, Y: 3}, {X: 5, Y: 1}}},
+	}
+	want := []*LevelSummary{
+		{Name: MainLevel, Area: 14, Perimeter: 20},
+		{Name: "Bulkhead", Parent: MainLevel, Drop: 0.1, Area: 4, Perimeter: 10, Transition: 4, TransitionArea: 0.4},
+		{Name: "Island", Parent: MainLevel, Drop: 0.2, Area: 5, Perimeter: 10, Transition: 10, TransitionArea: 2},
+		{Name: "Niche", Parent: "Island", Drop: -0.05, Area: 1, Perimeter: 4, Transition: 4, TransitionArea: 1},
+	}
+	got := pol.LevelsSummary(levels...)
+	if len(got) != len(want) {
+		t.Fatalf("LevelsSummary() got %d levels, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		for _, v := range []*float64{&g.Drop, &g.Area, &g.Perimeter, &g.Transition, &g.TransitionArea} {
+			*v = value.Round(*v, 6)
+		}
+		if !reflect.DeepEqual(g, w) {
+			t.Errorf("LevelsSummary()[%d] = %+v, want %+v", i, g, w)
+		}
+	}
+}
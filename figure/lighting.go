@@ -0,0 +1,232 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// maxGridFixtures is the limit of fixtures in one lighting grid.
+const maxGridFixtures = 200
+
+// LightingGrid is parameters of evenly placed fixtures of the Type with the Diameter. The grid is set by Count of
+// fixtures or by Spacing between them. Clearance is the least distance from fixtures to walls and holes.
+type LightingGrid struct {
+	Type      FixtureType `json:"type"`
+	Diameter  float64     `json:"diameter,omitempty"`
+	Count     int         `json:"count,omitempty"`
+	Spacing   float64     `json:"spacing,omitempty"`
+	Clearance float64     `json:"clearance"`
+}
+
+func (g *LightingGrid) ConvertToOne(measures *value.FigureMeasures) {
+	g.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) })
+}
+
+func (g *LightingGrid) ConvertFromOne(measures *value.FigureMeasures) {
+	g.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) })
+}
+
+func (g *LightingGrid) convert(length func(float64) float64) {
+	g.Diameter, g.Spacing, g.Clearance = length(g.Diameter), length(g.Spacing), length(g.Clearance)
+}
+
+func (g *LightingGrid) check() error {
+	if g.Type == "" {
+		g.Type = FixtureLight
+	}
+	if err := (&Fixture{Type: g.Type, Diameter: g.Diameter}).check(); err != nil {
+		return err
+	}
+	switch {
+	case (g.Count == 0) == (g.Spacing == 0):
+		return fmt.Errorf("%w: the grid needs either count or spacing", ErrWrongMeasurement)
+	case g.Count < 0 || g.Spacing < 0:
+		return fmt.Errorf("%w: count and spacing of the grid must be positive", ErrWrongMeasurement)
+	case g.Count > maxGridFixtures:
+		return fmt.Errorf("%w: the grid can have at most %d fixtures, got %d", ErrTooMuchPoints, maxGridFixtures, g.Count)
+	case g.Clearance < 0:
+		return fmt.Errorf("%w: clearance can't be negative, got %v", ErrWrongMeasurement, g.Clearance)
+	}
+	return nil
+}
+
+// gridPlanner keeps the area of the polygon bounding box, which is farther than the clearance from walls.
+type gridPlanner struct {
+	pol                    *Polygon
+	holes                  []*Hole
+	clearance              float64
+	left, low, width, high float64
+}
+
+// fits returns true if the point lies inside of the polygon and out of holes not closer than the clearance.
+func (gp *gridPlanner) fits(p *Point) bool {
+	if !gp.pol.ContainsPoint(p) || gp.pol.minWallDistance(p) < gp.clearance-tolerance {
+		return false
+	}
+	for _, h := range gp.holes {
+		if h.IsCircle() {
+			if (&Segment{A: h.Center, B: p}).Distance() < h.Radius+gp.clearance-tolerance {
+				return false
+			}
+			continue
+		}
+		hp := h.Polygon()
+		if hp.ContainsPoint(p) || hp.minWallDistance(p) < gp.clearance-tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// grid returns fitting points of the grid with nx columns and ny rows, which starts from (x0;y0) with steps dx and dy.
+func (gp *gridPlanner) grid(x0, y0, dx, dy float64, nx, ny int) []*Point {
+	out := make([]*Point, 0, nx*ny)
+	for j := 0; j < ny; j++ {
+		for i := 0; i < nx; i++ {
+			if p := (&Point{X: x0 + dx*float64(i), Y: y0 + dy*float64(j)}); gp.fits(p) {
+				out = append(out, p)
+			}
+		}
+	}
+	return out
+}
+
+// bySpacing returns points of the grid with the spacing, which is centered in the bounding box.
+func (gp *gridPlanner) bySpacing(spacing float64) ([]*Point, error) {
+	nx, ny := int(gp.width/spacing+tolerance)+1, int(gp.high/spacing+tolerance)+1
+	if nx*ny > maxGridFixtures*4 {
+		return nil, fmt.Errorf("%w: the spacing %v is too small for the grid", ErrTooMuchPoints, spacing)
+	}
+	x0 := gp.left + (gp.width-float64(nx-1)*spacing)/2
+	y0 := gp.low + (gp.high-float64(ny-1)*spacing)/2
+	points := gp.grid(x0, y0, spacing, spacing, nx, ny)
+	if len(points) > maxGridFixtures {
+		return nil, fmt.Errorf("%w: the grid can have at most %d fixtures, got %d", ErrTooMuchPoints, maxGridFixtures, len(points))
+	}
+	return points, nil
+}
+
+// byCount returns points of the grid, where the number of fitting points is the nearest to the count.
+// Points are centers of cells of the bounding box. The grid with the most square cells is preferred among equal ones.
+// Only grids with about count/fill cells are tried, where fill is the share of fitting cells estimated by areas
+// and then corrected by the best grid found, so the search doesn't depend on the count quadratically.
+func (gp *gridPlanner) byCount(count int) []*Point {
+	var best []*Point
+	bestDiff, bestRatio, bestCells := math.MaxInt32, math.Inf(1), 0
+	tried := make(map[[2]int]bool)
+	fill := gp.fill()
+	for pass := 0; pass < gridCountPasses && bestDiff != 0; pass++ {
+		for _, size := range gp.gridSizes(float64(count)/fill, 4*count) {
+			if tried[size] {
+				continue
+			}
+			tried[size] = true
+			nx, ny := size[0], size[1]
+			dx, dy := gp.width/float64(nx), gp.high/float64(ny)
+			points := gp.grid(gp.left+dx/2, gp.low+dy/2, dx, dy, nx, ny)
+			diff, ratio := len(points)-count, math.Inf(1)
+			if diff < 0 {
+				diff = -diff
+			}
+			if dx != 0 && dy != 0 {
+				ratio = math.Abs(math.Log(dx / dy))
+			}
+			if best == nil || diff < bestDiff || diff == bestDiff && ratio < bestRatio-tolerance {
+				best, bestDiff, bestRatio, bestCells = points, diff, ratio, nx*ny
+			}
+		}
+		if len(best) == 0 {
+			break
+		}
+		fill = float64(len(best)) / float64(bestCells)
+	}
+	return best
+}
+
+// gridCountPasses is the number of corrections of the fill share in gridPlanner.byCount.
+const gridCountPasses = 3
+
+// fill returns the estimated share of cells of the bounding box grid, which fit: the area of the polygon
+// without holes divided by the area of its bounding box.
+func (gp *gridPlanner) fill() float64 {
+	box := gp.pol.Width() * gp.pol.Height()
+	if box == 0 {
+		return 1
+	}
+	area := gp.pol.Area()
+	for _, h := range gp.holes {
+		area -= h.Area()
+	}
+	return math.Max(math.Min(area/box, 1), 0.05)
+}
+
+// gridSizes returns numbers of columns and rows of grids with about cells cells, which have nearly square cells.
+// Grids with more than maxCells cells are skipped. A zero size of the box has only one column or row.
+func (gp *gridPlanner) gridSizes(cells float64, maxCells int) [][2]int {
+	var lo, hi int
+	switch {
+	case gp.width == 0:
+		lo, hi = 1, 1
+	case gp.high == 0:
+		lo, hi = int(math.Round(cells))-1, int(math.Round(cells))+1
+	default:
+		square := math.Sqrt(cells * gp.width / gp.high)
+		lo, hi = int(square/2), int(math.Ceil(square*2))+1
+	}
+	sizes := make([][2]int, 0)
+	for nx := int(math.Max(float64(lo), 1)); nx <= hi; nx++ {
+		rows := int(math.Round(cells / float64(nx)))
+		if gp.high == 0 {
+			rows = 1
+		}
+		for ny := rows - 1; ny <= rows+1; ny++ {
+			if ny < 1 || gp.high == 0 && ny != 1 || nx*ny > maxCells {
+				continue
+			}
+			sizes = append(sizes, [2]int{nx, ny})
+		}
+	}
+	return sizes
+}
+
+// LightingGrid returns fixtures evenly placed by the grid inside of the polygon and out of holes.
+// For irregular polygons the grid covers the bounding box and only points inside of the polygon are kept.
+func (pol *Polygon) LightingGrid(g *LightingGrid, holes ...*Hole) ([]*Fixture, error) {
+	if err := g.check(); err != nil {
+		return nil, err
+	}
+	if pol.Len() < 3 {
+		return nil, fmt.Errorf("%w for lighting grid (%d), must be at least 3", ErrNotEnoughPoints, pol.Len())
+	}
+	left, _ := pol.LeftPoint()
+	low, _ := pol.LowPoint()
+	gp := &gridPlanner{
+		pol: pol, holes: holes, clearance: g.Clearance,
+		left: left.X + g.Clearance, low: low.Y + g.Clearance,
+		width: pol.Width() - 2*g.Clearance, high: pol.Height() - 2*g.Clearance,
+	}
+	if gp.width < -tolerance || gp.high < -tolerance {
+		return nil, fmt.Errorf("%w: clearance %v is too big for the polygon", ErrWrongMeasurement, g.Clearance)
+	}
+	gp.width, gp.high = math.Max(gp.width, 0), math.Max(gp.high, 0)
+
+	var points []*Point
+	if g.Spacing > 0 {
+		var err error
+		if points, err = gp.bySpacing(g.Spacing); err != nil {
+			return nil, err
+		}
+	} else {
+		points = gp.byCount(g.Count)
+	}
+	if len(points) == 0 {
+		return nil, fmt.Errorf("%w: no place for fixtures with clearance %v", ErrWrongMeasurement, g.Clearance)
+	}
+	fixtures := make([]*Fixture, len(points))
+	for i, p := range points {
+		fixtures[i] = &Fixture{Type: g.Type, X: p.X, Y: p.Y, Diameter: g.Diameter}
+	}
+	return fixtures, nil
+}
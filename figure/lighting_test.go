@@ -0,0 +1,115 @@
+package figure
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolygon_LightingGrid(t *testing.T) {
+	square := []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 0}}
+	tests := []struct {
+		name      string
+		points    []*Point
+		holes     []*Hole
+		grid      LightingGrid
+		wantCount int
+		want      []*Point
+		wantErr   error
+	}{
+		{
+			name:      "Square by count",
+			points:    square,
+			grid:      LightingGrid{Count: 4, Clearance: 0.5},
+			wantCount: 4,
+			want:      []*Point{{X: 1.25, Y: 1.25}, {X: 2.75, Y: 1.25}, {X: 1.25, Y: 2.75}, {X: 2.75, Y: 2.75}},
+		},
+		{
+			name:      "Rectangle by spacing",
+			points:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 5, Y: 3}, {X: 5, Y: 0}},
+			grid:      LightingGrid{Spacing: 1, Clearance: 0.5},
+			wantCount: 15,
+			want:      []*Point{{X: 0.5, Y: 0.5}, {X: 1.5, Y: 0.5}},
+		},
+		{
+			name:      "L-shaped by spacing",
+			points:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}},
+			grid:      LightingGrid{Spacing: 1, Clearance: 0.5},
+			wantCount: 12,
+		},
+		{
+			name:      "L-shaped by count",
+			points:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}},
+			grid:      LightingGrid{Count: 6, Clearance: 0.3},
+			wantCount: 6,
+		},
+		{
+			name:      "L-shaped by the largest count",
+			points:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}},
+			holes:     []*Hole{{Center: &Point{X: 1, Y: 1}, Radius: 0.3}},
+			grid:      LightingGrid{Count: maxGridFixtures, Clearance: 0.1},
+			wantCount: maxGridFixtures,
+		},
+		{
+			name:      "Around the hole",
+			points:    square,
+			holes:     []*Hole{{Center: &Point{X: 2, Y: 2}, Radius: 0.5}},
+			grid:      LightingGrid{Spacing: 1, Clearance: 0.5},
+			wantCount: 12,
+		},
+		{
+			name:    "Count and spacing",
+			points:  square,
+			grid:    LightingGrid{Count: 4, Spacing: 1},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Unknown type",
+			points:  square,
+			grid:    LightingGrid{Type: "lamp", Count: 4},
+			wantErr: ErrInvalidType,
+		},
+		{
+			name:    "Too big clearance",
+			points:  square,
+			grid:    LightingGrid{Count: 4, Clearance: 3},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Too much fixtures",
+			points:  square,
+			grid:    LightingGrid{Spacing: 0.01},
+			wantErr: ErrTooMuchPoints,
+		},
+		{
+			name:    "Not enough points",
+			points:  square[:2],
+			grid:    LightingGrid{Count: 4},
+			wantErr: ErrNotEnoughPoints,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := NewPolygon(tt.points...)
+			got, err := pol.LightingGrid(&tt.grid, tt.holes...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LightingGrid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(got) != tt.wantCount {
+				t.Fatalf("LightingGrid() got %d fixtures, want %d", len(got), tt.wantCount)
+			}
+			for i, p := range tt.want {
+				if got[i].Type != FixtureLight || !compareFloats(got[i].X, p.X, 1e-9) || !compareFloats(got[i].Y, p.Y, 1e-9) {
+					t.Errorf("LightingGrid()[%d] = %v %v;%v, want light %v;%v", i, got[i].Type, got[i].X, got[i].Y, p.X, p.Y)
+				}
+			}
+			for _, f := range got {
+				if !pol.ContainsPoint(f.Center()) || pol.NearestWalls(f.Center(), 1)[0].Distance < tt.grid.Clearance-1e-9 {
+					t.Errorf("LightingGrid() fixture %v;%v is closer than the clearance", f.X, f.Y)
+				}
+			}
+		})
+	}
+}
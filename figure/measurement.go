@@ -0,0 +1,174 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	solverMaxIterations = 50
+	solverAccuracy      = 1e-10
+	solverDamping       = 1e-6
+)
+
+// Measurement is a measured distance between two vertices of the polygon, specified by their indexes.
+// Usually it's a diagonal, but it also can be a side, which hasn't been measured by points.
+type Measurement struct {
+	A      int     `json:"a"`
+	B      int     `json:"b"`
+	Length float64 `json:"length"`
+}
+
+// Residual is a difference between the measured length and the distance between the vertices on the polygon.
+type Residual struct {
+	Measurement
+	Residual float64 `json:"residual"`
+}
+
+// NewPolygonWithDiagonals creates new polygon with points, which give approximate coordinates and measured sides,
+// then adds diagonals and solves coordinates by all the measurements.
+// Returns residuals of measurements (look at Polygon.Solve).
+func NewPolygonWithDiagonals(points []*Point, diagonals ...*Measurement) (*Polygon, []*Residual, error) {
+	pol := &Polygon{Points: points}
+	if err := pol.CalculatePoints(); err != nil {
+		return nil, nil, err
+	}
+	if err := pol.AddDiagonals(diagonals...); err != nil {
+		return nil, nil, err
+	}
+	residuals, err := pol.Solve()
+	if err != nil {
+		return nil, nil, err
+	}
+	return pol, residuals, nil
+}
+
+// AddDiagonals checks and adds measurements of diagonals into the polygon, without solving.
+func (pol *Polygon) AddDiagonals(diagonals ...*Measurement) error {
+	n := pol.Len()
+	for _, d := range diagonals {
+		if d.A == d.B || d.A < 0 || d.B < 0 || d.A >= n || d.B >= n {
+			return fmt.Errorf("%w: wrong vertexes %d and %d of %d points", ErrWrongMeasurement, d.A, d.B, n)
+		}
+		if d.Length <= 0 {
+			return fmt.Errorf("%w: length must be positive, got %v", ErrWrongMeasurement, d.Length)
+		}
+	}
+	pol.Diagonals = append(pol.Diagonals, diagonals...)
+	return nil
+}
+
+// SideMeasurements returns sides, which are measured by the points: sides ending with a calculated point
+// and sides between two points with fixed coordinates.
+func (pol *Polygon) SideMeasurements() []*Measurement {
+	out := make([]*Measurement, 0)
+	n := pol.Len()
+	if n < 2 {
+		return out
+	}
+	for i, s := range pol.Sides() {
+		if s.B.Calculator == nil && s.A.Calculator != nil {
+			continue
+		}
+		out = append(out, &Measurement{A: i, B: (i + 1) % n, Length: s.Distance()})
+	}
+	return out
+}
+
+// Residuals returns residuals of diagonals for current coordinates of the points.
+func (pol *Polygon) Residuals() []*Residual {
+	return pol.residuals(pol.Diagonals)
+}
+
+func (pol *Polygon) residuals(measurements []*Measurement) []*Residual {
+	out := make([]*Residual, len(measurements))
+	for i, m := range measurements {
+		dist := (&Segment{A: pol.Points[m.A], B: pol.Points[m.B]}).Distance()
+		out[i] = &Residual{Measurement: *m, Residual: m.Length - dist}
+	}
+	return out
+}
+
+// Solve computes coordinates of the calculated points from measured sides and diagonals.
+// If measurements are redundant, they are adjusted by the least squares method. Free degrees of the polygon, which
+// aren't defined by measurements, stay close to current coordinates. Points with fixed coordinates aren't changed.
+// After solving calculators of the points are rebased to new coordinates.
+// Returns residuals of all measurements: measured sides, then diagonals.
+func (pol *Polygon) Solve() ([]*Residual, error) {
+	measurements := append(pol.SideMeasurements(), pol.Diagonals...)
+	columns := make(map[int]int)
+	for i, p := range pol.Points {
+		if i != 0 && p.Calculator != nil {
+			columns[i] = len(columns) * 2
+		}
+	}
+	if len(columns) == 0 {
+		return pol.residuals(measurements), nil
+	}
+	for iteration := 0; iteration < solverMaxIterations; iteration++ {
+		delta, err := pol.solveStep(measurements, columns)
+		if err != nil {
+			return nil, err
+		}
+		maxDelta := 0.0
+		for i, c := range columns {
+			p := pol.Points[i]
+			p.X, p.Y = p.X+delta[c], p.Y+delta[c+1]
+			maxDelta = math.Max(maxDelta, math.Max(math.Abs(delta[c]), math.Abs(delta[c+1])))
+		}
+		if maxDelta < solverAccuracy {
+			break
+		}
+	}
+	residuals := pol.residuals(measurements)
+	for i := range pol.Points {
+		if _, ok := columns[i]; !ok {
+			continue
+		}
+		if err := pol.rebasePoint(i); err != nil {
+			return nil, err
+		}
+	}
+	return residuals, nil
+}
+
+// solveStep makes one damped Gauss-Newton step of the least squares solving and returns changes of coordinates.
+// Damping keeps coordinates, which aren't defined by measurements, at their current values.
+func (pol *Polygon) solveStep(measurements []*Measurement, columns map[int]int) ([]float64, error) {
+	size := len(columns) * 2
+	normal, right := make([][]float64, size), make([]float64, size)
+	for i := range normal {
+		normal[i] = make([]float64, size)
+	}
+	for _, m := range measurements {
+		a, b := pol.Points[m.A], pol.Points[m.B]
+		dist := (&Segment{A: a, B: b}).Distance()
+		if dist == 0 {
+			continue
+		}
+		ux, uy := (a.X-b.X)/dist, (a.Y-b.Y)/dist
+		jacobian := map[int][2]float64{}
+		if c, ok := columns[m.A]; ok {
+			jacobian[c] = [2]float64{ux, uy}
+		}
+		if c, ok := columns[m.B]; ok {
+			jacobian[c] = [2]float64{-ux, -uy}
+		}
+		residual := m.Length - dist
+		for c1, j1 := range jacobian {
+			for c2, j2 := range jacobian {
+				normal[c1][c2] += j1[0] * j2[0]
+				normal[c1][c2+1] += j1[0] * j2[1]
+				normal[c1+1][c2] += j1[1] * j2[0]
+				normal[c1+1][c2+1] += j1[1] * j2[1]
+			}
+			right[c1] += j1[0] * residual
+			right[c1+1] += j1[1] * residual
+		}
+	}
+	for _, c := range columns {
+		normal[c][c] += solverDamping
+		normal[c+1][c+1] += solverDamping
+	}
+	return solveLinearSystem(normal, right)
+}
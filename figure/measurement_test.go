@@ -0,0 +1,125 @@
+package figure
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/maxsid/goCeilings/value"
+)
+
+// newMeasuredExample returns points of a rectangle 400x300 with wrong measured angle at the third point.
+func newMeasuredExample() []*Point {
+	return []*Point{
+		NewPoint(0, 0),
+		NewCalculatedPoint(&DirectionCalculator{Direction: ConvertToOne(Degree, 90), Distance: 300}),
+		NewCalculatedPoint(&AngleCalculator{Angle: ConvertToOne(Degree, 95), Distance: 400}),
+		NewCalculatedPoint(&AngleCalculator{Angle: ConvertToOne(Degree, 90), Distance: 300}),
+	}
+}
+
+func TestNewPolygonWithDiagonals(t *testing.T) {
+	tests := []struct {
+		name         string
+		points       []*Point
+		diagonals    []*Measurement
+		wantArea     float64
+		maxResidual  float64
+		residualsLen int
+		wantErr      error
+	}{
+		{
+			name:         "Exactly determined",
+			points:       newMeasuredExample(),
+			diagonals:    []*Measurement{{A: 3, B: 0, Length: 400}, {A: 0, B: 2, Length: 500}},
+			wantArea:     120000,
+			maxResidual:  1e-6,
+			residualsLen: 5,
+		},
+		{
+			name:   "Redundant",
+			points: newMeasuredExample(),
+			diagonals: []*Measurement{{A: 3, B: 0, Length: 400}, {A: 0, B: 2, Length: 500},
+				{A: 1, B: 3, Length: 500.4}},
+			wantArea:     120000,
+			maxResidual:  0.2,
+			residualsLen: 6,
+		},
+		{
+			name:      "Wrong vertex",
+			points:    newMeasuredExample(),
+			diagonals: []*Measurement{{A: 0, B: 4, Length: 500}},
+			wantErr:   ErrWrongMeasurement,
+		},
+		{
+			name:      "Wrong length",
+			points:    newMeasuredExample(),
+			diagonals: []*Measurement{{A: 0, B: 2, Length: -1}},
+			wantErr:   ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol, residuals, err := NewPolygonWithDiagonals(tt.points, tt.diagonals...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewPolygonWithDiagonals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(residuals) != tt.residualsLen {
+				t.Errorf("Got %d residuals, want %d", len(residuals), tt.residualsLen)
+			}
+			for _, r := range residuals {
+				if !compareFloats(r.Residual, 0, tt.maxResidual) {
+					t.Errorf("Residual of %d-%d is too big: %v", r.A, r.B, r.Residual)
+				}
+			}
+			if !compareFloats(pol.Area(), tt.wantArea, 60) {
+				t.Errorf("Area() = %v, want %v", pol.Area(), tt.wantArea)
+			}
+			want := make([]*Point, pol.Len())
+			for i, p := range pol.Points {
+				want[i] = NewPoint(p.X, p.Y)
+			}
+			if err := pol.CalculatePoints(); err != nil {
+				t.Error(err)
+			}
+			if err := comparePointsSlices(want, pol.Points, 1e-6); err != nil {
+				t.Errorf("After recalculating: %v", err)
+			}
+		})
+	}
+}
+
+func TestPolygon_SideMeasurements(t *testing.T) {
+	pol := NewPolygon(
+		NewPoint(0, 0),
+		NewPoint(0, 3),
+		NewCalculatedPoint(&DirectionCalculator{Direction: 0, Distance: 4}),
+		NewPoint(4, 0),
+	)
+	want := []*Measurement{{A: 0, B: 1, Length: 3}, {A: 1, B: 2, Length: 4}, {A: 3, B: 0, Length: 4}}
+	got := pol.SideMeasurements()
+	if len(got) != len(want) {
+		t.Errorf("SideMeasurements() got %d measurements, want %d", len(got), len(want))
+		return
+	}
+	for i := range want {
+		if *got[i] != *want[i] {
+			t.Errorf("SideMeasurements()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPolygon_Residuals(t *testing.T) {
+	pol := NewPolygon(NewPoint(0, 0), NewPoint(0, 3), NewPoint(4, 3), NewPoint(4, 0))
+	if err := pol.AddDiagonals(&Measurement{A: 0, B: 2, Length: 5.1}); err != nil {
+		t.Error(err)
+		return
+	}
+	got := pol.Residuals()
+	if len(got) != 1 || !compareFloats(got[0].Residual, 0.1, 1e-9) {
+		t.Errorf("Residuals() got %+v, want one residual 0.1", got)
+	}
+}
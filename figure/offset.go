@@ -0,0 +1,166 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// OffsetJoin is a way of joining offset sides at corners, where they part.
+type OffsetJoin string
+
+const (
+	OffsetMitre OffsetJoin = "mitre"
+	OffsetRound OffsetJoin = "round"
+)
+
+// Offset is parameters of the outline going at the Distance from the polygon outline, e.g. the mounting profile
+// inside of the room or a cornice outside. Positive Distance moves the outline outwards, negative one moves it
+// inwards. Sides are joined by Join at corners, where they part, mitre is by default.
+type Offset struct {
+	Distance float64    `json:"distance"`
+	Join     OffsetJoin `json:"join,omitempty"`
+}
+
+func (o *Offset) ConvertToOne(measures *value.FigureMeasures) {
+	o.Distance = value.ConvertToOne(measures.Length, o.Distance)
+}
+
+func (o *Offset) ConvertFromOne(measures *value.FigureMeasures) {
+	o.Distance = value.ConvertFromOne(measures.Length, o.Distance)
+}
+
+// check sets the join to mitre if it's empty and returns an error if the join is unknown or the distance is zero.
+func (o *Offset) check() error {
+	if o.Join == "" {
+		o.Join = OffsetMitre
+	}
+	if o.Join != OffsetMitre && o.Join != OffsetRound {
+		return fmt.Errorf("%w: unknown join of the offset %q", ErrWrongMeasurement, o.Join)
+	}
+	if o.Distance == 0 {
+		return fmt.Errorf("%w: distance of the offset can't be zero", ErrWrongMeasurement)
+	}
+	return nil
+}
+
+// offsetLine is a side of the polygon moved by the offset distance: the line through Point in the direction
+// (UX;UY) with the outer normal (NX;NY). Vertex is the start of the side if it's a vertex of the polygon and nil
+// for chords of arcs, Index is the number of the side in the outline.
+type offsetLine struct {
+	Point, Vertex *Point
+	UX, UY        float64
+	NX, NY        float64
+	Index         int
+}
+
+// intersection returns the point, where the line crosses the next one. The start of the next line is returned
+// for lines going in the same direction and nil for lines going in opposite directions.
+func (l *offsetLine) intersection(next *offsetLine) *Point {
+	cross := l.UX*next.UY - l.UY*next.UX
+	if math.Abs(cross) < tolerance {
+		if l.UX*next.UX+l.UY*next.UY < 0 {
+			return nil
+		}
+		return &Point{X: next.Point.X, Y: next.Point.Y}
+	}
+	t := ((next.Point.X-l.Point.X)*next.UY - (next.Point.Y-l.Point.Y)*next.UX) / cross
+	return &Point{X: l.Point.X + l.UX*t, Y: l.Point.Y + l.UY*t}
+}
+
+// Offset returns the polygon, which outline goes at the offset distance from the outline of the polygon.
+// Curved sides are approximated by chords, round joins are arcs. Sides, which disappear in narrow parts of concave
+// rooms, are removed. Returns an error if the offset outline falls apart or crosses itself, e.g. if the inward
+// distance is more than a half of the room width.
+func (pol *Polygon) Offset(o *Offset) (*Polygon, error) {
+	if err := o.check(); err != nil {
+		return nil, err
+	}
+	if pol.Len() < 3 {
+		return nil, fmt.Errorf("%w for offset (%d), must be at least 3", ErrNotEnoughPoints, pol.Len())
+	}
+	area := pol.signedArea()
+	if area == 0 {
+		return nil, fmt.Errorf("%w: polygon has zero area", ErrWrongMeasurement)
+	}
+	orientation := math.Copysign(1, area)
+	path := make([]*Segment, 0, pol.Len())
+	lines := make([]*offsetLine, 0, pol.Len())
+	for i := range pol.Points {
+		for k, s := range pol.sidePath(i) {
+			length := s.Distance()
+			if length < tolerance {
+				continue
+			}
+			ux, uy := (s.B.X-s.A.X)/length, (s.B.Y-s.A.Y)/length
+			// the outer side is on the left for the clockwise order
+			nx, ny := uy*orientation, -ux*orientation
+			l := &offsetLine{Point: &Point{X: s.A.X + nx*o.Distance, Y: s.A.Y + ny*o.Distance},
+				UX: ux, UY: uy, NX: nx, NY: ny, Index: len(lines)}
+			if k == 0 {
+				l.Vertex = s.A
+			}
+			path, lines = append(path, s), append(lines, l)
+		}
+	}
+
+	// sides turning back or shrinking to a point are removed one by one, starting with the most reversed one
+	for {
+		if len(lines) < 3 {
+			return nil, fmt.Errorf("%w: offset %v is too large for the polygon", ErrWrongMeasurement, o.Distance)
+		}
+		m, worst, worstLength := len(lines), -1, tolerance
+		for k, l := range lines {
+			length := math.Inf(-1)
+			if a, b := lines[(k+m-1)%m].intersection(l), l.intersection(lines[(k+1)%m]); a != nil && b != nil {
+				length = (b.X-a.X)*l.UX + (b.Y-a.Y)*l.UY
+			}
+			if length < worstLength {
+				worst, worstLength = k, length
+			}
+		}
+		if worst < 0 {
+			break
+		}
+		lines = append(lines[:worst], lines[worst+1:]...)
+	}
+
+	total, m, points := len(path), len(lines), make([]*Point, 0, len(lines))
+	for k, l := range lines {
+		prev := lines[(k+m-1)%m]
+		// corners, where sides part, are the convex ones for the outward offset and the reflex ones for the inward
+		turn := (prev.UX*l.UY - prev.UY*l.UX) * orientation
+		parting := math.Abs(turn) > straightTurn && (turn > 0) == (o.Distance > 0)
+		adjacent := l.Vertex != nil && (prev.Index+1)%total == l.Index
+		if math.Abs(prev.UX*l.UY-prev.UY*l.UX) < tolerance {
+			// lines going one after another on the same line don't make a corner
+			continue
+		}
+		if o.Join != OffsetRound || !parting || !adjacent {
+			points = append(points, prev.intersection(l))
+			continue
+		}
+		v, d := l.Vertex, o.Distance
+		a := &Point{X: v.X + prev.NX*d, Y: v.Y + prev.NY*d}
+		b := &Point{X: v.X + l.NX*d, Y: v.Y + l.NY*d}
+		theta := math.Acos(math.Max(-1, math.Min(1, prev.NX*l.NX+prev.NY*l.NY)))
+		// the arc bulges from the vertex
+		b.Arc = &Arc{Sagitta: math.Abs(d) * (1 - math.Cos(theta/2)), Right: crossProduct(a, b, v) > 0}
+		points = append(points, a, b)
+	}
+
+	out := &Polygon{Points: points}
+	if out.Len() < 3 || math.Copysign(1, out.signedArea()) != orientation || len(out.intersectionsProblems()) > 0 {
+		return nil, fmt.Errorf("%w: offset %v is too large for the polygon", ErrWrongMeasurement, o.Distance)
+	}
+	// every point of the outline must be not nearer to walls than the distance
+	for _, p := range out.Points {
+		for _, s := range path {
+			if (&Segment{A: p, B: s.nearestPoint(p)}).Distance() < math.Abs(o.Distance)*(1-straightTurn) {
+				return nil, fmt.Errorf("%w: offset %v is too large for the polygon", ErrWrongMeasurement, o.Distance)
+			}
+		}
+	}
+	return out, nil
+}
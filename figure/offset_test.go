@@ -0,0 +1,127 @@
+package figure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPolygon_Offset(t *testing.T) {
+	square := []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 0}}
+	lShaped := []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}}
+	alcove := []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2, Y: 4}, {X: 2, Y: 5}, {X: 2.4, Y: 5}, {X: 2.4, Y: 4},
+		{X: 6, Y: 4}, {X: 6, Y: 0}}
+	tests := []struct {
+		name          string
+		points        []*Point
+		offset        Offset
+		wantPerimeter float64
+		wantArea      float64
+		wantLen       int
+		accuracy      float64
+		wantErr       error
+	}{
+		{
+			name:          "Outward mitre",
+			points:        square,
+			offset:        Offset{Distance: 1},
+			wantPerimeter: 24,
+			wantArea:      36,
+			wantLen:       4,
+		},
+		{
+			name:          "Outward round",
+			points:        square,
+			offset:        Offset{Distance: 1, Join: OffsetRound},
+			wantPerimeter: 16 + 2*math.Pi,
+			wantArea:      32 + math.Pi,
+			wantLen:       8,
+		},
+		{
+			name:          "Inward round of convex polygon",
+			points:        square,
+			offset:        Offset{Distance: -1, Join: OffsetRound},
+			wantPerimeter: 8,
+			wantArea:      4,
+			wantLen:       4,
+		},
+		{
+			name:          "Inward mitre of L-shaped room",
+			points:        lShaped,
+			offset:        Offset{Distance: -0.5},
+			wantPerimeter: 12,
+			wantArea:      5,
+			wantLen:       6,
+		},
+		{
+			name:          "Inward round of L-shaped room",
+			points:        lShaped,
+			offset:        Offset{Distance: -0.5, Join: OffsetRound},
+			wantPerimeter: 11 + math.Pi/4,
+			wantArea:      5.25 - math.Pi/16,
+			wantLen:       7,
+		},
+		{
+			name:          "Narrow alcove disappears",
+			points:        alcove,
+			offset:        Offset{Distance: -0.5},
+			wantPerimeter: 16,
+			wantArea:      15,
+			wantLen:       4,
+		},
+		{
+			name: "Curved wall",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 4, Y: 4},
+				{X: 4, Y: 0, Arc: &Arc{Radius: 2}}},
+			offset:        Offset{Distance: -0.5},
+			wantPerimeter: 3 + 3.5*2 + math.Pi*1.5,
+			wantArea:      3*3.5 + math.Pi*1.5*1.5/2,
+			wantLen:       3 + arcValidationSteps,
+			accuracy:      0.05,
+		},
+		{
+			name:    "Too large inward distance",
+			points:  square,
+			offset:  Offset{Distance: -2.5},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Zero distance",
+			points:  square,
+			offset:  Offset{},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Unknown join",
+			points:  square,
+			offset:  Offset{Distance: 1, Join: "bevel"},
+			wantErr: ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPolygon(tt.points...).Offset(&tt.offset)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Offset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Len() != tt.wantLen {
+				t.Errorf("Offset() got %d points, want %d", got.Len(), tt.wantLen)
+			}
+			if tt.accuracy == 0 {
+				tt.accuracy = 1e-9
+			}
+			if !compareFloats(got.Perimeter(), tt.wantPerimeter, tt.accuracy) {
+				t.Errorf("Offset() perimeter = %v, want %v", got.Perimeter(), tt.wantPerimeter)
+			}
+			if !compareFloats(got.Area(), tt.wantArea, tt.accuracy) {
+				t.Errorf("Offset() area = %v, want %v", got.Area(), tt.wantArea)
+			}
+			if len(got.intersectionsProblems()) > 0 || got.signedArea() > 0 {
+				t.Errorf("Offset() got a wrong outline %v", got.Problems())
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package figure
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PointsUpdateMode is the way of updating calculated points of the polygon, when points before them are changed.
+type PointsUpdateMode string
+
+const (
+	// KeepCoordinates keeps coordinates of points, their calculators are rebased to new previous points.
+	KeepCoordinates PointsUpdateMode = "keep"
+	// RecomputeCoordinates calculates points by their calculators again from new previous points.
+	RecomputeCoordinates PointsUpdateMode = "recompute"
+)
+
+// check returns an error if the mode is unknown.
+func (m PointsUpdateMode) check() error {
+	if m != KeepCoordinates && m != RecomputeCoordinates {
+		return fmt.Errorf("%w: unknown mode of updating points %q", ErrWrongMeasurement, m)
+	}
+	return nil
+}
+
+// InsertPoint inserts the point before the point by index i, so the new point gets the index i. The index equal to
+// the number of points adds the point to the end. The inserted point is calculated, if it has a calculator,
+// then other calculated points are updated by the mode. The arc of the point by index i curves the side
+// from the inserted point then.
+func (pol *Polygon) InsertPoint(i int, p *Point, mode PointsUpdateMode) error {
+	n := pol.Len()
+	if i < 0 || i > n {
+		return fmt.Errorf("could not insert the point %d: %w (%d)", i, ErrNotEnoughPoints, n)
+	}
+	order := make([]int, 0, n+1)
+	for k := 0; k <= n; k++ {
+		switch {
+		case k < i:
+			order = append(order, k)
+		case k == i:
+			order = append(order, -1)
+		default:
+			order = append(order, k-1)
+		}
+	}
+	return pol.reorderPoints(order, p, mode)
+}
+
+// DeletePoint removes the point by index and its diagonals like RemovePoint, then calculated points are updated
+// by the mode. Points calculated by trilateration from the removed point lose their calculators in the keep mode,
+// because they can't be recomputed.
+func (pol *Polygon) DeletePoint(i int, mode PointsUpdateMode) error {
+	n := pol.Len()
+	if i < 0 || i >= n {
+		return fmt.Errorf("could not remove the point %d: %w (%d)", i, ErrNotEnoughPoints, n)
+	}
+	order := make([]int, 0, n-1)
+	for k := 0; k < n; k++ {
+		if k != i {
+			order = append(order, k)
+		}
+	}
+	return pol.reorderPoints(order, nil, mode)
+}
+
+// RotatePoints changes the order of points, so the point by index first becomes the first one and the direction
+// of going around the polygon is kept. Calculated points are computed again.
+func (pol *Polygon) RotatePoints(first int) error {
+	n := pol.Len()
+	if first < 0 || first >= n {
+		return fmt.Errorf("could not start from the point %d: %w (%d)", first, ErrNotEnoughPoints, n)
+	}
+	order := make([]int, n)
+	for j := range order {
+		order[j] = (first + j) % n
+	}
+	return pol.reorderPoints(order, nil, RecomputeCoordinates)
+}
+
+// ReversePoints reverses the direction of going around the polygon, the first point stays the first one.
+// Arcs are moved to other ends of their sides and turned to the other side of the new direction, so the outline
+// isn't changed. Calculators are rebased, because directions and angles of sides are changed.
+func (pol *Polygon) ReversePoints() error {
+	n := pol.Len()
+	if n < 2 {
+		return nil
+	}
+	order, arcs := make([]int, n), make([]*Arc, n)
+	for j := range order {
+		order[j] = (n - j) % n
+	}
+	for k, p := range pol.Points {
+		if p.Arc != nil {
+			arcs[k] = &Arc{Sagitta: p.Arc.Sagitta, Radius: p.Arc.Radius, Right: !p.Arc.Right}
+		}
+	}
+	// the side ending with the point by new index j started with the point ending it before
+	for j, k := range order {
+		pol.Points[k].Arc = arcs[order[(j+n-1)%n]]
+	}
+	return pol.reorderPoints(order, nil, KeepCoordinates)
+}
+
+// reorderPoints sets points of the polygon in the order of their old indexes, where -1 is the inserted point.
+// Points counted back by trilateration calculators and ends of diagonals are moved with their points, diagonals
+// of missing points are removed. Then calculated points are updated by the mode.
+func (pol *Polygon) reorderPoints(order []int, inserted *Point, mode PointsUpdateMode) error {
+	if err := mode.check(); err != nil {
+		return err
+	}
+	n, m := pol.Len(), len(order)
+	newIndexes := make([]int, n)
+	for k := range newIndexes {
+		newIndexes[k] = -1
+	}
+	for j, k := range order {
+		if k >= 0 {
+			newIndexes[k] = j
+		}
+	}
+	points, calculators := make([]*Point, m), make([]PointCoordinatesCalculator, m)
+	for j, k := range order {
+		if k < 0 {
+			points[j] = inserted
+			continue
+		}
+		points[j], calculators[j] = pol.Points[k], pol.Points[k].Calculator
+		tc, ok := calculators[j].(*TrilaterationCalculator)
+		if !ok {
+			continue
+		}
+		a, b := newIndexes[((k-tc.A)%n+n)%n], newIndexes[((k-tc.B)%n+n)%n]
+		if a < 0 || b < 0 {
+			if mode == RecomputeCoordinates {
+				return fmt.Errorf("%w: the point %d is calculated by trilateration from the removed point",
+					ErrWrongMeasurement, k)
+			}
+			calculators[j] = nil
+			continue
+		}
+		calculators[j] = &TrilaterationCalculator{
+			A:         (j - a + m) % m,
+			B:         (j - b + m) % m,
+			DistanceA: tc.DistanceA,
+			DistanceB: tc.DistanceB,
+			Right:     tc.Right,
+		}
+	}
+
+	diagonals := make([]*Measurement, 0, len(pol.Diagonals))
+	for _, d := range pol.Diagonals {
+		if newIndexes[d.A] < 0 || newIndexes[d.B] < 0 {
+			continue
+		}
+		d.A, d.B = newIndexes[d.A], newIndexes[d.B]
+		diagonals = append(diagonals, d)
+	}
+	for j, k := range order {
+		if k >= 0 {
+			points[j].Calculator = calculators[j]
+		}
+	}
+	pol.Points, pol.Diagonals = points, diagonals
+
+	if mode == RecomputeCoordinates {
+		if err := pol.CalculatePoints(); err != nil {
+			return err
+		}
+		return pol.checkArcs()
+	}
+	for j, k := range order {
+		if k < 0 {
+			if err := pol.calculatePoint(j); err != nil && !errors.Is(err, ErrPointDoesNotHaveCalculator) {
+				return err
+			}
+		}
+	}
+	for j, k := range order {
+		if k < 0 || points[j].Calculator == nil {
+			continue
+		}
+		if err := pol.rebasePoint(j); err != nil {
+			return err
+		}
+	}
+	return pol.checkArcs()
+}
@@ -0,0 +1,313 @@
+package figure
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// newCalculatedSquare returns the square 2x2 with points calculated by direction and angle calculators.
+func newCalculatedSquare(t *testing.T) *Polygon {
+	pol := NewPolygon(NewPoint(0, 0))
+	err := pol.AddPoints(
+		NewCalculatedPoint(&DirectionCalculator{Direction: math.Pi / 2, Distance: 2}),
+		NewCalculatedPoint(&DirectionCalculator{Direction: 0, Distance: 2}),
+		NewCalculatedPoint(&AngleCalculator{Angle: math.Pi / 2, Distance: 2}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pol
+}
+
+// newTrilaterationTriangle returns the polygon, which last point is calculated by trilateration from the third
+// and the first points.
+func newTrilaterationTriangle(t *testing.T) *Polygon {
+	pol := NewPolygon(NewPoint(0, 0), NewPoint(0, 2), NewPoint(2, 2))
+	err := pol.AddPoints(NewCalculatedPoint(&TrilaterationCalculator{A: 1, B: 3, DistanceA: 2, DistanceB: 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pol
+}
+
+// checkCalculatorsKeepPoints returns false if calculators of the polygon give other coordinates than current ones.
+func checkCalculatorsKeepPoints(pol *Polygon) bool {
+	points := make([]*Point, pol.Len())
+	for i, p := range pol.Points {
+		points[i] = NewPoint(p.X, p.Y)
+	}
+	if err := pol.CalculatePoints(); err != nil {
+		return false
+	}
+	return comparePointsSlices(points, pol.Points, 1e-9) == nil
+}
+
+func TestPolygon_InsertPoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   int
+		point   *Point
+		mode    PointsUpdateMode
+		want    []*Point
+		wantErr error
+	}{
+		{
+			name:  "Keep coordinates",
+			index: 2,
+			point: NewPoint(1, 3),
+			mode:  KeepCoordinates,
+			want:  []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 1, Y: 3}, {X: 2, Y: 2}, {X: 2, Y: 0}},
+		},
+		{
+			name:  "Recompute coordinates",
+			index: 2,
+			point: NewPoint(1, 3),
+			mode:  RecomputeCoordinates,
+			want:  []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 1, Y: 3}, {X: 3, Y: 3}, {X: 3, Y: 1}},
+		},
+		{
+			name:  "Calculated point",
+			index: 1,
+			point: NewCalculatedPoint(&DirectionCalculator{Direction: math.Pi / 2, Distance: 1}),
+			mode:  KeepCoordinates,
+			want:  []*Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 0}},
+		},
+		{
+			name:  "To the end",
+			index: 4,
+			point: NewPoint(1, -1),
+			mode:  RecomputeCoordinates,
+			want:  []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 0}, {X: 1, Y: -1}},
+		},
+		{
+			name:    "Wrong index",
+			index:   5,
+			point:   NewPoint(1, 3),
+			mode:    KeepCoordinates,
+			wantErr: ErrNotEnoughPoints,
+		},
+		{
+			name:    "Unknown mode",
+			index:   2,
+			point:   NewPoint(1, 3),
+			mode:    "move",
+			wantErr: ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := newCalculatedSquare(t)
+			err := pol.InsertPoint(tt.index, tt.point, tt.mode)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("InsertPoint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if err := comparePointsSlices(tt.want, pol.Points, 1e-9); err != nil {
+				t.Errorf("InsertPoint() %v", err)
+			}
+			if !checkCalculatorsKeepPoints(pol) {
+				t.Errorf("InsertPoint() calculators don't give points %v", pol.Points)
+			}
+		})
+	}
+}
+
+func TestPolygon_DeletePoint(t *testing.T) {
+	tests := []struct {
+		name          string
+		pol           func(t *testing.T) *Polygon
+		index         int
+		mode          PointsUpdateMode
+		want          []*Point
+		wantDiagonals []*Measurement
+		wantErr       error
+	}{
+		{
+			name:          "Keep coordinates",
+			pol:           newCalculatedSquare,
+			index:         1,
+			mode:          KeepCoordinates,
+			want:          []*Point{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 0}},
+			wantDiagonals: []*Measurement{{A: 0, B: 1, Length: 2.83}},
+		},
+		{
+			name:          "Recompute coordinates",
+			pol:           newCalculatedSquare,
+			index:         1,
+			mode:          RecomputeCoordinates,
+			want:          []*Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: -2}},
+			wantDiagonals: []*Measurement{{A: 0, B: 1, Length: 2.83}},
+		},
+		{
+			name:          "Trilateration is moved",
+			pol:           newTrilaterationTriangle,
+			index:         1,
+			mode:          RecomputeCoordinates,
+			want:          []*Point{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 0}},
+			wantDiagonals: []*Measurement{{A: 0, B: 1, Length: 2.83}},
+		},
+		{
+			name:          "Trilateration from the removed point is dropped",
+			pol:           newTrilaterationTriangle,
+			index:         0,
+			mode:          KeepCoordinates,
+			want:          []*Point{{X: 0, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 0}},
+			wantDiagonals: []*Measurement{{A: 0, B: 2, Length: 2.83}},
+		},
+		{
+			name:    "Trilateration from the removed point can't be recomputed",
+			pol:     newTrilaterationTriangle,
+			index:   0,
+			mode:    RecomputeCoordinates,
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Wrong index",
+			pol:     newCalculatedSquare,
+			index:   4,
+			mode:    KeepCoordinates,
+			wantErr: ErrNotEnoughPoints,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := tt.pol(t)
+			pol.Diagonals = []*Measurement{{A: 0, B: 2, Length: 2.83}, {A: 1, B: 3, Length: 2.83}}
+			err := pol.DeletePoint(tt.index, tt.mode)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeletePoint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if err := comparePointsSlices(tt.want, pol.Points, 1e-9); err != nil {
+				t.Errorf("DeletePoint() %v", err)
+			}
+			if !reflect.DeepEqual(pol.Diagonals, tt.wantDiagonals) {
+				t.Errorf("DeletePoint() diagonals = %v, want %v", pol.Diagonals, tt.wantDiagonals)
+			}
+			if !checkCalculatorsKeepPoints(pol) {
+				t.Errorf("DeletePoint() calculators don't give points %v", pol.Points)
+			}
+		})
+	}
+}
+
+func TestPolygon_RotatePoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		pol            func(t *testing.T) *Polygon
+		first          int
+		want           []*Point
+		wantCalculator PointCoordinatesCalculator
+		wantErr        error
+	}{
+		{
+			name:           "Calculated square",
+			pol:            newCalculatedSquare,
+			first:          2,
+			want:           []*Point{{X: 2, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: 2}},
+			wantCalculator: &DirectionCalculator{Direction: 0, Distance: 2},
+		},
+		{
+			name:           "Trilateration",
+			pol:            newTrilaterationTriangle,
+			first:          3,
+			want:           []*Point{{X: 2, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}},
+			wantCalculator: &TrilaterationCalculator{A: 1, B: 3, DistanceA: 2, DistanceB: 2},
+		},
+		{
+			name:    "Wrong index",
+			pol:     newCalculatedSquare,
+			first:   4,
+			wantErr: ErrNotEnoughPoints,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := tt.pol(t)
+			err := pol.RotatePoints(tt.first)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RotatePoints() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if err := comparePointsSlices(tt.want, pol.Points, 1e-9); err != nil {
+				t.Errorf("RotatePoints() %v", err)
+			}
+			if !reflect.DeepEqual(pol.Points[0].Calculator, tt.wantCalculator) {
+				t.Errorf("RotatePoints() calculator = %v, want %v", pol.Points[0].Calculator, tt.wantCalculator)
+			}
+		})
+	}
+}
+
+func TestPolygon_ReversePoints(t *testing.T) {
+	tests := []struct {
+		name          string
+		pol           func(t *testing.T) *Polygon
+		want          []*Point
+		wantDiagonals []*Measurement
+	}{
+		{
+			name:          "Calculated square",
+			pol:           newCalculatedSquare,
+			want:          []*Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}},
+			wantDiagonals: []*Measurement{},
+		},
+		{
+			name:          "Trilateration",
+			pol:           newTrilaterationTriangle,
+			want:          []*Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}},
+			wantDiagonals: []*Measurement{},
+		},
+		{
+			name: "Arc and diagonals",
+			pol: func(t *testing.T) *Polygon {
+				pol := NewPolygon(NewPoint(0, 0), &Point{X: 0, Y: 2, Arc: &Arc{Sagitta: 0.5}}, NewPoint(2, 2),
+					NewPoint(2, 0))
+				pol.Diagonals = []*Measurement{{A: 0, B: 2, Length: 2.83}, {A: 1, B: 3, Length: 2.83}}
+				return pol
+			},
+			want: []*Point{{X: 0, Y: 0, Arc: &Arc{Sagitta: 0.5, Right: true}}, {X: 2, Y: 0}, {X: 2, Y: 2},
+				{X: 0, Y: 2}},
+			wantDiagonals: []*Measurement{{A: 0, B: 2, Length: 2.83}, {A: 3, B: 1, Length: 2.83}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := tt.pol(t)
+			area, perimeter := pol.Area(), pol.Perimeter()
+			if err := pol.ReversePoints(); err != nil {
+				t.Errorf("ReversePoints() error = %v", err)
+				return
+			}
+			if err := comparePointsSlices(tt.want, pol.Points, 1e-9); err != nil {
+				t.Errorf("ReversePoints() %v", err)
+			}
+			for i, p := range pol.Points {
+				if !reflect.DeepEqual(p.Arc, tt.want[i].Arc) {
+					t.Errorf("ReversePoints() arc of %d = %v, want %v", i, p.Arc, tt.want[i].Arc)
+				}
+			}
+			if !reflect.DeepEqual(pol.Diagonals, tt.wantDiagonals) {
+				t.Errorf("ReversePoints() diagonals = %v, want %v", pol.Diagonals, tt.wantDiagonals)
+			}
+			if !compareFloats(pol.Area(), area, 1e-9) || !compareFloats(pol.Perimeter(), perimeter, 1e-9) {
+				t.Errorf("ReversePoints() area %v and perimeter %v, want %v and %v", pol.Area(), pol.Perimeter(),
+					area, perimeter)
+			}
+			if !checkCalculatorsKeepPoints(pol) {
+				t.Errorf("ReversePoints() calculators don't give points %v", pol.Points)
+			}
+		})
+	}
+}
@@ -10,10 +10,12 @@ import (
 
 // Point is a minimal element of any figure. Contains Coordinates on the drawing and Calculator, which calculates
 // coordinates via CalculateCoordinates function and previous points of the figure.
+// Arc makes curved the side from the previous point to this one.
 type Point struct {
 	X          float64                    `json:"x"`
 	Y          float64                    `json:"y"`
 	Calculator PointCoordinatesCalculator `json:"calculator,omitempty"`
+	Arc        *Arc                       `json:"arc,omitempty"`
 }
 
 // NewPoint creates new Point object only with Coordinates without Calculator.
@@ -40,18 +42,27 @@ func (p *Point) CalculateCoordinates(previousPoints ...*Point) error {
 	return ErrPointDoesNotHaveCalculator
 }
 
+// RebaseCalculator changes values of the Calculator, so that it gives current coordinates of the point.
+func (p *Point) RebaseCalculator(previousPoints ...*Point) error {
+	if p.Calculator != nil {
+		return p.Calculator.Rebase(p, previousPoints...)
+	}
+	return ErrPointDoesNotHaveCalculator
+}
+
 func (p *Point) RoundCoordinates(round int) {
 	p.X, p.Y = value.Round(p.X, round), value.Round(p.Y, round)
 }
 
 func (p *Point) NewRoundedPoint(round int) *Point {
-	np := &Point{p.X, p.Y, p.Calculator}
+	np := &Point{X: p.X, Y: p.Y, Calculator: p.Calculator, Arc: p.Arc}
 	np.RoundCoordinates(round)
 	return np
 }
 
 type PointCoordinatesCalculator interface {
 	Calculate(point *Point, previousPoints ...*Point) error
+	Rebase(point *Point, previousPoints ...*Point) error
 	ConvertToOne(measures *value.FigureMeasures)
 	ConvertFromOne(measures *value.FigureMeasures)
 	json.Unmarshaler
@@ -99,6 +110,16 @@ func (d *DirectionCalculator) Calculate(point *Point, previousPoints ...*Point)
 	return nil
 }
 
+// Rebase sets Distance and Direction from the previous point to the current coordinates of the point.
+func (d *DirectionCalculator) Rebase(point *Point, previousPoints ...*Point) error {
+	if len(previousPoints) < 1 {
+		return fmt.Errorf("%w for rebasing direction (must have at least 1)", ErrNotEnoughPoints)
+	}
+	pp := previousPoints[len(previousPoints)-1]
+	d.Direction, d.Distance = pointDirection(pp, point), (&Segment{A: pp, B: point}).Distance()
+	return nil
+}
+
 // AngleCalculator is a calculator for calculating coordinates with Distance and Angle from previous two points
 // (segment), creating angle with specified Angle value.
 type AngleCalculator struct {
@@ -144,6 +165,115 @@ func (ac AngleCalculator) Calculate(point *Point, previousPoints ...*Point) erro
 	return nil
 }
 
+// Rebase sets Angle and Distance, so that the calculator gives the current coordinates of the point.
+func (ac *AngleCalculator) Rebase(point *Point, previousPoints ...*Point) error {
+	if len(previousPoints) < 2 {
+		return fmt.Errorf("%w for rebasing an angle (must have at least 2)", ErrNotEnoughPoints)
+	}
+	a, b := previousPoints[len(previousPoints)-2], previousPoints[len(previousPoints)-1]
+	angle := pointDirection(b, point) - pointDirection(b, a)
+	if angle < 0 {
+		angle += math.Pi * 2
+	}
+	ac.Angle, ac.Distance = angle, (&Segment{A: b, B: point}).Distance()
+	return nil
+}
+
+// trilaterationTolerance is a relative shortage of distances, which is taken as a touch of circles.
+const trilaterationTolerance = 1e-6
+
+// TrilaterationCalculator is a calculator for calculating coordinates with distances from two previous points.
+// A and B are numbers of the points counted back from the calculated one: 1 is the previous point,
+// 2 is the point before the previous one and so on. Two points with such distances exist, so the point is chosen
+// on the left or on the right side of the line from A to B by Right flag.
+type TrilaterationCalculator struct {
+	A         int     `json:"a"`
+	B         int     `json:"b"`
+	DistanceA float64 `json:"distance_a"`
+	DistanceB float64 `json:"distance_b"`
+	Right     bool    `json:"right"`
+}
+
+func (tc *TrilaterationCalculator) UnmarshalJSON(data []byte) error {
+	var m map[string]interface{}
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{"a", "b", "distance_a", "distance_b"} {
+		if _, ok := m[key].(float64); !ok {
+			return fmt.Errorf("%w. Expected %T", ErrInvalidType, tc)
+		}
+	}
+	tc.A, tc.B = int(m["a"].(float64)), int(m["b"].(float64))
+	tc.DistanceA, tc.DistanceB = m["distance_a"].(float64), m["distance_b"].(float64)
+	tc.Right, _ = m["right"].(bool)
+	return nil
+}
+
+func (tc *TrilaterationCalculator) ConvertToOne(measures *value.FigureMeasures) {
+	tc.DistanceA = value.ConvertToOne(measures.Length, tc.DistanceA)
+	tc.DistanceB = value.ConvertToOne(measures.Length, tc.DistanceB)
+}
+
+func (tc *TrilaterationCalculator) ConvertFromOne(measures *value.FigureMeasures) {
+	tc.DistanceA = value.ConvertFromOne(measures.Length, tc.DistanceA)
+	tc.DistanceB = value.ConvertFromOne(measures.Length, tc.DistanceB)
+}
+
+// basePoints returns points A and B from previousPoints.
+func (tc *TrilaterationCalculator) basePoints(previousPoints []*Point) (*Point, *Point, error) {
+	n := len(previousPoints)
+	if tc.A < 1 || tc.B < 1 || tc.A == tc.B {
+		return nil, nil, fmt.Errorf("%w: wrong points %d and %d for trilateration", ErrWrongMeasurement, tc.A, tc.B)
+	}
+	if tc.A > n || tc.B > n {
+		return nil, nil, fmt.Errorf("%w for trilateration (%d), must be at least %d",
+			ErrNotEnoughPoints, n, int(math.Max(float64(tc.A), float64(tc.B))))
+	}
+	return previousPoints[n-tc.A], previousPoints[n-tc.B], nil
+}
+
+// Calculate calculates point coordinates by distances in metres from points A and B.
+func (tc *TrilaterationCalculator) Calculate(point *Point, previousPoints ...*Point) error {
+	a, b, err := tc.basePoints(previousPoints)
+	if err != nil {
+		return err
+	}
+	base := (&Segment{A: a, B: b}).Distance()
+	if base == 0 {
+		return fmt.Errorf("%w: points of trilateration coincide", ErrUnsolvable)
+	}
+	along := (tc.DistanceA*tc.DistanceA - tc.DistanceB*tc.DistanceB + base*base) / (2 * base)
+	h := tc.DistanceA*tc.DistanceA - along*along
+	if h < 0 {
+		// distances, which are a bit less than needed for touching, are measurement errors
+		if h < -trilaterationTolerance*tc.DistanceA*tc.DistanceA {
+			return fmt.Errorf("%w: distances %v and %v don't meet with the base %v",
+				ErrUnsolvable, tc.DistanceA, tc.DistanceB, base)
+		}
+		h = 0
+	}
+	h = math.Sqrt(h)
+	if tc.Right {
+		h = -h
+	}
+	ux, uy := (b.X-a.X)/base, (b.Y-a.Y)/base
+	point.X, point.Y = a.X+ux*along-uy*h, a.Y+uy*along+ux*h
+	return nil
+}
+
+// Rebase sets distances from points A and B and the side, so that the calculator gives the current coordinates.
+func (tc *TrilaterationCalculator) Rebase(point *Point, previousPoints ...*Point) error {
+	a, b, err := tc.basePoints(previousPoints)
+	if err != nil {
+		return err
+	}
+	tc.DistanceA, tc.DistanceB = (&Segment{A: a, B: point}).Distance(), (&Segment{A: b, B: point}).Distance()
+	tc.Right = (b.X-a.X)*(point.Y-a.Y)-(b.Y-a.Y)*(point.X-a.X) < 0
+	return nil
+}
+
 func (p *Point) UnmarshalJSON(data []byte) error {
 	var m map[string]interface{}
 	if err := json.Unmarshal(data, &m); err != nil {
@@ -155,6 +285,16 @@ func (p *Point) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("%w, got %v, must be Point", ErrInvalidType, m)
 	}
 	p.X, p.Y = m["x"].(float64), m["y"].(float64)
+	if _, ok := m["arc"]; ok {
+		arcBytes, err := json.Marshal(m["arc"])
+		if err != nil {
+			return err
+		}
+		p.Arc = &Arc{}
+		if err := json.Unmarshal(arcBytes, p.Arc); err != nil {
+			return fmt.Errorf("%w of arc: %v", ErrInvalidType, err)
+		}
+	}
 	if _, ok := m["calculator"]; !ok {
 		return nil
 	}
@@ -165,6 +305,7 @@ func (p *Point) UnmarshalJSON(data []byte) error {
 	calculators := []PointCoordinatesCalculator{
 		&DirectionCalculator{},
 		&AngleCalculator{},
+		&TrilaterationCalculator{},
 	}
 	for _, calc := range calculators {
 		if err := json.Unmarshal(calcBytes, calc); err == nil {
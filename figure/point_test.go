@@ -1,6 +1,7 @@
 package figure
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -161,6 +162,75 @@ func TestAngleCalculator(t *testing.T) {
 	}
 }
 
+func TestTrilaterationCalculator(t *testing.T) {
+	previous := []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 4, Y: 3}}
+	tests := []struct {
+		name       string
+		calculator *TrilaterationCalculator
+		want       *Point
+		wantErr    error
+	}{
+		{
+			name:       "Left",
+			calculator: &TrilaterationCalculator{A: 3, B: 1, DistanceA: 4, DistanceB: 3},
+			want:       &Point{X: 1.12, Y: 3.84},
+		},
+		{
+			name:       "Right",
+			calculator: &TrilaterationCalculator{A: 3, B: 1, DistanceA: 4, DistanceB: 3, Right: true},
+			want:       &Point{X: 4, Y: 0},
+		},
+		{
+			name:       "Touching circles",
+			calculator: &TrilaterationCalculator{A: 2, B: 1, DistanceA: 1, DistanceB: 3},
+			want:       &Point{X: 1, Y: 3},
+		},
+		{
+			name:       "Too short distances",
+			calculator: &TrilaterationCalculator{A: 3, B: 1, DistanceA: 1, DistanceB: 1},
+			wantErr:    ErrUnsolvable,
+		},
+		{
+			name:       "The same points",
+			calculator: &TrilaterationCalculator{A: 1, B: 1, DistanceA: 1, DistanceB: 1},
+			wantErr:    ErrWrongMeasurement,
+		},
+		{
+			name:       "Not enough points",
+			calculator: &TrilaterationCalculator{A: 4, B: 1, DistanceA: 4, DistanceB: 3},
+			wantErr:    ErrNotEnoughPoints,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCalculatedPoint(tt.calculator)
+			if err := got.CalculateCoordinates(previous...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("TrilaterationCalculator error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !comparePoints(tt.want, got, 1e-9) {
+				t.Errorf("TrilaterationCalculator = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrilaterationCalculator_Convert(t *testing.T) {
+	measures := &FigureMeasures{Angle: Degree, Length: Centimetre}
+	tc := &TrilaterationCalculator{A: 2, B: 1, DistanceA: 421.5, DistanceB: 300}
+	tc.ConvertToOne(measures)
+	if !compareFloats(tc.DistanceA, 4.215, 1e-9) || !compareFloats(tc.DistanceB, 3, 1e-9) {
+		t.Errorf("ConvertToOne() got %+v", tc)
+	}
+	tc.ConvertFromOne(measures)
+	if !compareFloats(tc.DistanceA, 421.5, 1e-9) || !compareFloats(tc.DistanceB, 300, 1e-9) || tc.A != 2 || tc.B != 1 {
+		t.Errorf("ConvertFromOne() got %+v", tc)
+	}
+}
+
 func TestAngleCalculator_ConvertToOne(t *testing.T) {
 	type fields struct {
 		Angle    float64
@@ -344,6 +414,31 @@ func TestPoint_UnmarshalJSON(t *testing.T) {
 			args: args{bytes: []byte(`{"x":0.27,"y":1.71,"calculator":{"angle":4.7123889,"distance":0.46}}`)},
 			want: &Point{X: 0.27, Y: 1.71, Calculator: &AngleCalculator{4.7123889, 0.46}},
 		},
+		{
+			name: "Trilateration calculator",
+			args: args{bytes: []byte(`{"x":4,"y":0,"calculator":{"a":3,"b":1,"distance_a":4,"distance_b":3,"right":true}}`)},
+			want: &Point{X: 4, Y: 0, Calculator: &TrilaterationCalculator{A: 3, B: 1, DistanceA: 4, DistanceB: 3, Right: true}},
+		},
+		{
+			name: "Trilateration calculator without side",
+			args: args{bytes: []byte(`{"x":1.12,"y":3.84,"calculator":{"a":3,"b":1,"distance_a":4,"distance_b":3}}`)},
+			want: &Point{X: 1.12, Y: 3.84, Calculator: &TrilaterationCalculator{A: 3, B: 1, DistanceA: 4, DistanceB: 3}},
+		},
+		{
+			name: "Arc with calculator",
+			args: args{bytes: []byte(`{"x":0.27,"y":1.25,"calculator":{"direction":0,"distance":0.27},"arc":{"radius":0.3,"right":true}}`)},
+			want: &Point{X: 0.27, Y: 1.25, Calculator: &DirectionCalculator{0, 0.27}, Arc: &Arc{Radius: 0.3, Right: true}},
+		},
+		{
+			name: "Arc without calculator",
+			args: args{bytes: []byte(`{"x":0.27,"y":1.25,"arc":{"sagitta":0.1}}`)},
+			want: &Point{X: 0.27, Y: 1.25, Arc: &Arc{Sagitta: 0.1}},
+		},
+		{
+			name:    "Wrong arc",
+			args:    args{bytes: []byte(`{"x":0.27,"y":1.71,"arc":123}`)},
+			wantErr: true,
+		},
 		{
 			name:    "Wrong calculator",
 			args:    args{bytes: []byte(`{"x":0.27,"y":1.71,"calculator":123}`)},
@@ -376,3 +471,77 @@ func TestPoint_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculators_Rebase(t *testing.T) {
+	a, b := NewPoint(0, 0), NewPoint(0, 1.25)
+	tests := []struct {
+		name       string
+		calculator PointCoordinatesCalculator
+		previous   []*Point
+		point      *Point
+		wantErr    bool
+	}{
+		{
+			name:       "Direction",
+			calculator: &DirectionCalculator{},
+			previous:   []*Point{a, b},
+			point:      NewPoint(0.27, 1.71),
+		},
+		{
+			name:       "Angle",
+			calculator: &AngleCalculator{},
+			previous:   []*Point{a, b},
+			point:      NewPoint(0.27, 1.71),
+		},
+		{
+			name:       "Angle with reflex angle",
+			calculator: &AngleCalculator{},
+			previous:   []*Point{a, b},
+			point:      NewPoint(-0.4, 0.9),
+		},
+		{
+			name:       "Trilateration on the left",
+			calculator: &TrilaterationCalculator{A: 2, B: 1},
+			previous:   []*Point{a, b},
+			point:      NewPoint(-0.4, 0.9),
+		},
+		{
+			name:       "Trilateration on the right",
+			calculator: &TrilaterationCalculator{A: 2, B: 1},
+			previous:   []*Point{a, b},
+			point:      NewPoint(0.27, 1.71),
+		},
+		{
+			name:       "Direction without previous points",
+			calculator: &DirectionCalculator{},
+			point:      NewPoint(0.27, 1.71),
+			wantErr:    true,
+		},
+		{
+			name:       "Angle with one previous point",
+			calculator: &AngleCalculator{},
+			previous:   []*Point{a},
+			point:      NewPoint(0.27, 1.71),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.calculator.Rebase(tt.point, tt.previous...); (err != nil) != tt.wantErr {
+				t.Errorf("Rebase() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got := NewCalculatedPoint(tt.calculator)
+			if err := got.CalculateCoordinates(tt.previous...); err != nil {
+				t.Error(err)
+				return
+			}
+			if !comparePoints(tt.point, got, 1e-9) {
+				t.Errorf("Rebase() calculator gives %v, want %v", got, tt.point)
+			}
+		})
+	}
+}
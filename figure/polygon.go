@@ -7,7 +7,8 @@ import (
 )
 
 type Polygon struct {
-	Points []*Point
+	Points    []*Point
+	Diagonals []*Measurement `json:"diagonals,omitempty"`
 }
 
 // NewPolygon creates new polygon with points, then calculates coordinates.
@@ -56,7 +57,7 @@ func (pol *Polygon) DiagonalsLen() int {
 
 func (pol *Polygon) Area() float64 {
 	n := pol.Len()
-	if n < 3 {
+	if n < 2 {
 		return 0
 	}
 	var sum1, sum2 float64
@@ -66,8 +67,11 @@ func (pol *Polygon) Area() float64 {
 		sum1 += p1.X * p2.Y
 		sum2 += p1.Y * p2.X
 	}
-	sum := sum1 - sum2
-	return 0.5 * math.Abs(sum)
+	sum := 0.5 * (sum1 - sum2)
+	for _, cs := range pol.CurvedSides() {
+		sum += cs.SegmentArea()
+	}
+	return math.Abs(sum)
 }
 
 func (pol *Polygon) Perimeter() float64 {
@@ -75,12 +79,99 @@ func (pol *Polygon) Perimeter() float64 {
 		return 0
 	}
 	var sum float64
-	for _, s := range pol.Sides() {
-		sum += s.Distance()
+	for _, l := range pol.SidesLengths() {
+		sum += l
 	}
 	return sum
 }
 
+// SidesLengths returns lengths of all sides, for curved sides it's length of the arc.
+func (pol *Polygon) SidesLengths() []float64 {
+	sides := pol.Sides()
+	out := make([]float64, len(sides))
+	for i, s := range sides {
+		out[i] = s.Distance()
+		if cs, _ := pol.CurvedSide(i); cs != nil {
+			out[i] = cs.Length()
+		}
+	}
+	return out
+}
+
+// CurvedSide returns the side by index curved by the arc of its end point.
+// Returns nil if the side is straight.
+func (pol *Polygon) CurvedSide(i int) (*CurvedSide, error) {
+	n := pol.Len()
+	if n < 2 {
+		return nil, ErrNotEnoughPoints
+	}
+	a, b := pol.Points[i], pol.Points[(i+1)%n]
+	if b.Arc == nil {
+		return nil, nil
+	}
+	return NewCurvedSide(a, b, b.Arc)
+}
+
+// CurvedSides returns all curved sides of the polygon. Sides with wrong arcs are skipped.
+func (pol *Polygon) CurvedSides() []*CurvedSide {
+	out := make([]*CurvedSide, 0)
+	if pol.Len() < 2 {
+		return out
+	}
+	for i := range pol.Points {
+		if cs, err := pol.CurvedSide(i); err == nil && cs != nil {
+			out = append(out, cs)
+		}
+	}
+	return out
+}
+
+// ContainsPoint returns true if the point lies inside of the polygon or on its border.
+// Curved sides are taken into account.
+func (pol *Polygon) ContainsPoint(p *Point) bool {
+	n := pol.Len()
+	if n < 2 {
+		return false
+	}
+	inside := false
+	for i, s := range pol.Sides() {
+		a, b := s.A, s.B
+		if pointOnSegment(p, s) {
+			if cs, _ := pol.CurvedSide(i); cs == nil {
+				return true
+			}
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	// area between a chord and its arc is added to the polygon, if the arc bulges outside, or cut from it otherwise,
+	// so the point in this area changes the result
+	for _, cs := range pol.CurvedSides() {
+		dist := (&Segment{A: cs.Center, B: p}).Distance()
+		if math.Abs(dist-cs.Radius) <= tolerance*math.Max(1, cs.Radius) && cs.containsDirection(p) {
+			return true
+		}
+		if dist < cs.Radius && cs.bulgeSide(p) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// checkArcs returns an error, if any point has an arc, which can't be built on its side.
+func (pol *Polygon) checkArcs() error {
+	if pol.Len() < 2 {
+		return nil
+	}
+	for i := range pol.Points {
+		if _, err := pol.CurvedSide(i); err != nil {
+			return fmt.Errorf("side %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 func (pol *Polygon) Sides() []*Segment {
 	lines := make([]*Segment, 0)
 	n := pol.Len()
@@ -105,7 +196,7 @@ func (pol *Polygon) AddPoints(points ...*Point) error {
 			return err
 		}
 	}
-	return nil
+	return pol.checkArcs()
 }
 
 // AddPoint adds points with coordinates, without calculating.
@@ -121,6 +212,30 @@ func (pol *Polygon) SetPoint(i int, point *Point) error {
 	return pol.AddPoints(endOfPoints...)
 }
 
+// RemovePoint removes the point by index without calculating of other points.
+// Diagonals of the removed point are removed too, indexes of other diagonals are shifted.
+func (pol *Polygon) RemovePoint(i int) error {
+	if i < 0 || i >= pol.Len() {
+		return fmt.Errorf("could not remove the point %d: %w (%d)", i, ErrNotEnoughPoints, pol.Len())
+	}
+	pol.Points = append(pol.Points[:i], pol.Points[i+1:]...)
+	diagonals := make([]*Measurement, 0, len(pol.Diagonals))
+	for _, d := range pol.Diagonals {
+		if d.A == i || d.B == i {
+			continue
+		}
+		if d.A > i {
+			d.A--
+		}
+		if d.B > i {
+			d.B--
+		}
+		diagonals = append(diagonals, d)
+	}
+	pol.Diagonals = diagonals
+	return nil
+}
+
 // AddPointByDirection adds new point with DirectionCalculator and calculates new coordinates.
 func (pol *Polygon) AddPointByDirection(distance float64, direction float64) error {
 	p := NewCalculatedPoint(&DirectionCalculator{Direction: direction, Distance: distance})
@@ -136,6 +251,19 @@ func (pol *Polygon) AddPointByAngle(distance float64, angle float64) error {
 	return pol.AddPoints(p)
 }
 
+// AddPointByTrilateration adds new point with TrilaterationCalculator by distances from points with indexes a and b
+// and calculates new coordinates. The point is placed on the right side of the line from a to b if right is true.
+func (pol *Polygon) AddPointByTrilateration(a, b int, distanceA, distanceB float64, right bool) error {
+	p := NewCalculatedPoint(&TrilaterationCalculator{
+		A:         pol.Len() - a,
+		B:         pol.Len() - b,
+		DistanceA: distanceA,
+		DistanceB: distanceB,
+		Right:     right,
+	})
+	return pol.AddPoints(p)
+}
+
 func (pol *Polygon) findPoint(compFunc func(prev, cur *Point) bool) (*Point, error) {
 	if pol.Len() == 0 {
 		return nil, fmt.Errorf("could not find a point: %w (0), must be at least 1", ErrNotEnoughPoints)
@@ -146,6 +274,13 @@ func (pol *Polygon) findPoint(compFunc func(prev, cur *Point) bool) (*Point, err
 			out = p
 		}
 	}
+	for _, cs := range pol.CurvedSides() {
+		for _, p := range cs.ExtremePoints() {
+			if compFunc(out, p) {
+				out = p
+			}
+		}
+	}
 	return out, nil
 }
 
@@ -173,6 +308,15 @@ func (pol *Polygon) RightPoint() (*Point, error) {
 	})
 }
 
+// boundsCenter returns the middle of the bounding box of the polygon.
+func (pol *Polygon) boundsCenter() *Point {
+	left, _ := pol.LeftPoint()
+	right, _ := pol.RightPoint()
+	top, _ := pol.TopPoint()
+	low, _ := pol.LowPoint()
+	return &Point{X: (left.X + right.X) / 2, Y: (top.Y + low.Y) / 2}
+}
+
 func (pol *Polygon) Width() float64 {
 	if pol.Len() < 2 {
 		return 0
@@ -191,16 +335,10 @@ func (pol *Polygon) Height() float64 {
 	return math.Abs(top.Y - low.Y)
 }
 
-// Rotation rotates the polygon where o Points is the central point and a is an angle of rotation in radians.
-func (pol *Polygon) Rotation(o *Point, a float64) {
-	for _, p := range pol.Points {
-		if o == p {
-			continue
-		}
-		x, y := p.X, p.Y
-		p.X = x*math.Cos(a) - y*math.Sin(a)
-		p.Y = x*math.Sin(a) + y*math.Cos(a)
-	}
+// Rotation rotates the polygon around the point o by the angle a in radians. Calculators of points are rebased
+// to new coordinates.
+func (pol *Polygon) Rotation(o *Point, a float64) error {
+	return pol.Transform(&Transform{PivotX: o.X, PivotY: o.Y, Angle: a})
 }
 
 // calculatePoint calculates coordinates of the point by index in Polygon.Points.
@@ -215,6 +353,15 @@ func (pol *Polygon) calculatePoint(index int) error {
 	return nil
 }
 
+// rebasePoint changes the calculator of the point by index in Polygon.Points to its current coordinates.
+func (pol *Polygon) rebasePoint(index int) error {
+	previous, err := sliceOfForwardElements(index, pol.Points)
+	if err != nil {
+		return err
+	}
+	return pol.Points[index].RebaseCalculator(previous.([]*Point)...)
+}
+
 // CalculatePoints calculates all points coordinates in the polygon.
 func (pol *Polygon) CalculatePoints() error {
 	for i := range pol.Points {
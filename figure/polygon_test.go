@@ -287,7 +287,9 @@ func TestPolygon_Rotation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			wPol := NewPolygon(tt.wantS...)
 			pol := NewPolygon(tt.fields.Points...)
-			pol.Rotation(tt.args.o, tt.args.a)
+			if err := pol.Rotation(tt.args.o, tt.args.a); err != nil {
+				t.Fatalf("Rotation() error = %v", err)
+			}
 			if wp, p := pol.Perimeter(), wPol.Perimeter(); !compareFloats(wp, p, 0.2) {
 				t.Errorf("Incorrect Perimeter. Got %f, want %f", p, wp)
 			}
@@ -473,6 +475,57 @@ func TestPolygon_AddPointByAngle(t *testing.T) {
 	}
 }
 
+func TestPolygon_AddPointByTrilateration(t *testing.T) {
+	type args struct {
+		a, b                 int
+		distanceA, distanceB float64
+		right                bool
+	}
+	tests := []struct {
+		name    string
+		points  []*Point
+		args    args
+		want    []*Point
+		wantErr bool
+	}{
+		{
+			name:    "1 point error",
+			points:  []*Point{{X: 0, Y: 0}},
+			args:    args{a: 0, b: 1, distanceA: 125, distanceB: 27},
+			wantErr: true,
+		},
+		{
+			name:   "Two points",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 125}},
+			args:   args{a: 0, b: 1, distanceA: 127.88, distanceB: 27, right: true},
+			want:   []*Point{{X: 0, Y: 0}, {X: 0, Y: 125}, {X: 27, Y: 125}},
+		},
+		{
+			name:   "Three points",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 125}, {X: 27, Y: 125}},
+			args:   args{a: 2, b: 0, distanceA: 89, distanceB: 45},
+			want:   []*Point{{X: 0, Y: 0}, {X: 0, Y: 125}, {X: 27, Y: 125}, {X: 27, Y: 36}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := &Polygon{Points: tt.points}
+			err := pol.AddPointByTrilateration(tt.args.a, tt.args.b, tt.args.distanceA, tt.args.distanceB, tt.args.right)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AddPointByTrilateration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, want := notPointerPoints(pol.Points), notPointerPoints(tt.want)
+			if diff := deep.Equal(got, want); diff != nil {
+				t.Errorf("AddPointByTrilateration() -> %v", diff)
+			}
+		})
+	}
+}
+
 func notPointerPoints(points []*Point) []Point {
 	out := make([]Point, len(points))
 	for i, p := range points {
@@ -981,3 +1034,83 @@ func TestPolygon_findPoint(t *testing.T) {
 		})
 	}
 }
+
+func TestPolygon_RemovePoint(t *testing.T) {
+	tests := []struct {
+		name          string
+		index         int
+		wantPoints    []*Point
+		wantDiagonals []*Measurement
+		wantErr       bool
+	}{
+		{
+			name:          "Middle",
+			index:         1,
+			wantPoints:    []*Point{{X: 0, Y: 0}, {X: 4, Y: 3}, {X: 4, Y: 0}, {X: 2, Y: -1}},
+			wantDiagonals: []*Measurement{{A: 0, B: 1, Length: 5}, {A: 1, B: 3, Length: 2.8}},
+		},
+		{
+			name:          "Diagonal point",
+			index:         2,
+			wantPoints:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 4, Y: 0}, {X: 2, Y: -1}},
+			wantDiagonals: []*Measurement{{A: 1, B: 2, Length: 5}},
+		},
+		{
+			name:    "Out of range",
+			index:   5,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := NewPolygon(NewPoint(0, 0), NewPoint(0, 3), NewPoint(4, 3), NewPoint(4, 0), NewPoint(2, -1))
+			pol.Diagonals = []*Measurement{{A: 0, B: 2, Length: 5}, {A: 1, B: 3, Length: 5}, {A: 2, B: 4, Length: 2.8}}
+			if err := pol.RemovePoint(tt.index); (err != nil) != tt.wantErr {
+				t.Errorf("RemovePoint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := deep.Equal(notPointerPoints(pol.Points), notPointerPoints(tt.wantPoints)); diff != nil {
+				t.Errorf("RemovePoint() points -> %v", diff)
+			}
+			if diff := deep.Equal(pol.Diagonals, tt.wantDiagonals); diff != nil {
+				t.Errorf("RemovePoint() diagonals -> %v", diff)
+			}
+		})
+	}
+}
+
+// newNotchExample returns a polygon with a notch in the left top corner.
+func newNotchExample() *Polygon {
+	return NewPolygon(NewPoint(0, 0), NewPoint(0, 125), NewPoint(27, 125), NewPoint(27, 171), NewPoint(222, 170),
+		NewPoint(225, 0))
+}
+
+func TestPolygon_ContainsPoint(t *testing.T) {
+	tests := []struct {
+		name  string
+		pol   *Polygon
+		point *Point
+		want  bool
+	}{
+		{name: "Inside", pol: newNotchExample(), point: &Point{X: 100, Y: 100}, want: true},
+		{name: "Vertex", pol: newNotchExample(), point: &Point{X: 27, Y: 125}, want: true},
+		{name: "Side", pol: newNotchExample(), point: &Point{X: 0, Y: 50}, want: true},
+		{name: "Notch", pol: newNotchExample(), point: &Point{X: 10, Y: 150}},
+		{name: "Outside", pol: newNotchExample(), point: &Point{X: -10, Y: 50}},
+		{name: "Outer arc", pol: newArcExample(&Arc{Sagitta: 2, Right: true}), point: &Point{X: 2, Y: 3.9}, want: true},
+		{name: "On outer arc", pol: newArcExample(&Arc{Sagitta: 2, Right: true}), point: &Point{X: 2, Y: 4}, want: true},
+		{name: "Out of outer arc", pol: newArcExample(&Arc{Sagitta: 2, Right: true}), point: &Point{X: 3.9, Y: 3.9}},
+		{name: "Inner arc", pol: newArcExample(&Arc{Sagitta: 1}), point: &Point{X: 2, Y: 1.5}},
+		{name: "Under inner arc", pol: newArcExample(&Arc{Sagitta: 1}), point: &Point{X: 2, Y: 0.5}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pol.ContainsPoint(tt.point); got != tt.want {
+				t.Errorf("ContainsPoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
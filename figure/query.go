@@ -0,0 +1,81 @@
+package figure
+
+import (
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// PointLocation is the location of a point relatively to the polygon.
+type PointLocation string
+
+const (
+	PointInside  PointLocation = "inside"
+	PointOutside PointLocation = "outside"
+	PointOnWall  PointLocation = "wall"
+)
+
+// PointQuery is the location of the Point relatively to the polygon, the nearest Vertex by its index with the distance
+// to it and distances to all Walls sorted by the distance, so the first wall is the nearest one.
+type PointQuery struct {
+	Point          *Point          `json:"point"`
+	Location       PointLocation   `json:"location"`
+	Vertex         int             `json:"vertex"`
+	VertexDistance float64         `json:"vertex_distance"`
+	Walls          []*WallDistance `json:"walls"`
+}
+
+func (q *PointQuery) ConvertToOne(measures *value.FigureMeasures) {
+	q.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) })
+}
+
+func (q *PointQuery) ConvertFromOne(measures *value.FigureMeasures) {
+	q.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) })
+}
+
+func (q *PointQuery) convert(length func(float64) float64) {
+	q.Point.X, q.Point.Y = length(q.Point.X), length(q.Point.Y)
+	q.VertexDistance = length(q.VertexDistance)
+	for _, w := range q.Walls {
+		w.Distance = length(w.Distance)
+		w.Foot = &Point{X: length(w.Foot.X), Y: length(w.Foot.Y)}
+	}
+}
+
+// Locate returns the location of the point relatively to the polygon. The point lies on a wall if it's not farther
+// than tol from the wall, so a point measured with an error isn't taken as lying outside.
+func (pol *Polygon) Locate(p *Point, tol float64) PointLocation {
+	if walls := pol.NearestWalls(p, 1); len(walls) > 0 && walls[0].Distance <= math.Max(tol, tolerance) {
+		return PointOnWall
+	}
+	if pol.ContainsPoint(p) {
+		return PointInside
+	}
+	return PointOutside
+}
+
+// NearestVertex returns the index of the vertex of the polygon nearest to the point and the distance to it.
+// Returns -1 if the polygon has no points.
+func (pol *Polygon) NearestVertex(p *Point) (int, float64) {
+	vertex, distance := -1, math.Inf(1)
+	for i, v := range pol.Points {
+		if d := (&Segment{A: p, B: v}).Distance(); d < distance {
+			vertex, distance = i, d
+		}
+	}
+	if vertex < 0 {
+		return vertex, 0
+	}
+	return vertex, distance
+}
+
+// Query returns the location of the point relatively to the polygon (look at Locate), the nearest vertex
+// and distances to all walls.
+func (pol *Polygon) Query(p *Point, tol float64) (*PointQuery, error) {
+	if pol.Len() < 3 {
+		return nil, ErrNotEnoughPoints
+	}
+	q := &PointQuery{Point: &Point{X: p.X, Y: p.Y}, Location: pol.Locate(p, tol), Walls: pol.NearestWalls(p, pol.Len())}
+	q.Vertex, q.VertexDistance = pol.NearestVertex(p)
+	return q, nil
+}
@@ -0,0 +1,86 @@
+package figure
+
+import "testing"
+
+func TestPolygon_Query(t *testing.T) {
+	pol := &Polygon{Points: []*Point{{X: 0, Y: 0, Arc: &Arc{Sagitta: 1}}, {X: 0, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 0}}}
+	tests := []struct {
+		name           string
+		p              *Point
+		tol            float64
+		wantLocation   PointLocation
+		wantVertex     int
+		wantVertexDist float64
+		wantWall       int
+		wantWallDist   float64
+	}{
+		{
+			name:           "Inside",
+			p:              &Point{X: 1, Y: 3},
+			wantLocation:   PointInside,
+			wantVertex:     1,
+			wantVertexDist: 1.4142135623730951,
+			wantWall:       0,
+			wantWallDist:   1,
+		},
+		{
+			name:           "Inside of the arc",
+			p:              &Point{X: 3, Y: -0.5},
+			wantLocation:   PointInside,
+			wantVertex:     0,
+			wantVertexDist: 3.0413812651491097,
+			wantWall:       3,
+			wantWallDist:   0.5,
+		},
+		{
+			name:           "Outside",
+			p:              &Point{X: 7, Y: 2},
+			wantLocation:   PointOutside,
+			wantVertex:     2,
+			wantVertexDist: 2.23606797749979,
+			wantWall:       2,
+			wantWallDist:   1,
+		},
+		{
+			name:           "On the wall within the tolerance",
+			p:              &Point{X: 6.01, Y: 2},
+			tol:            0.02,
+			wantLocation:   PointOnWall,
+			wantVertex:     2,
+			wantVertexDist: 2.000024999843751,
+			wantWall:       2,
+			wantWallDist:   0.01,
+		},
+		{
+			name:           "On the vertex",
+			p:              &Point{X: 0, Y: 4},
+			wantLocation:   PointOnWall,
+			wantVertex:     1,
+			wantVertexDist: 0,
+			wantWall:       0,
+			wantWallDist:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pol.Query(tt.p, tt.tol)
+			if err != nil {
+				t.Errorf("Query() error = %v", err)
+				return
+			}
+			if got.Location != tt.wantLocation {
+				t.Errorf("Query() location = %v, want %v", got.Location, tt.wantLocation)
+			}
+			if got.Vertex != tt.wantVertex || !compareFloats(got.VertexDistance, tt.wantVertexDist, 1e-9) {
+				t.Errorf("Query() vertex = %d (%v), want %d (%v)", got.Vertex, got.VertexDistance, tt.wantVertex, tt.wantVertexDist)
+			}
+			if len(got.Walls) != pol.Len() {
+				t.Errorf("Query() got %d walls, want %d", len(got.Walls), pol.Len())
+				return
+			}
+			if got.Walls[0].Side != tt.wantWall || !compareFloats(got.Walls[0].Distance, tt.wantWallDist, 1e-9) {
+				t.Errorf("Query() nearest wall = %d (%v), want %d (%v)", got.Walls[0].Side, got.Walls[0].Distance, tt.wantWall, tt.wantWallDist)
+			}
+		})
+	}
+}
@@ -14,3 +14,48 @@ func (l *Segment) Distance() float64 {
 	dist := math.Sqrt(math.Pow(ax-bx, 2) + math.Pow(ay-by, 2))
 	return dist
 }
+
+// Intersects returns true if the segment has a common point with s, including touching by ends.
+func (l *Segment) Intersects(s *Segment) bool {
+	if l.Crosses(s) {
+		return true
+	}
+	return pointOnSegment(l.A, s) || pointOnSegment(l.B, s) || pointOnSegment(s.A, l) || pointOnSegment(s.B, l)
+}
+
+// Crosses returns true if the segment and s cross each other in a point, which isn't an end of any of them.
+func (l *Segment) Crosses(s *Segment) bool {
+	d1, d2 := crossProduct(s.A, s.B, l.A), crossProduct(s.A, s.B, l.B)
+	d3, d4 := crossProduct(l.A, l.B, s.A), crossProduct(l.A, l.B, s.B)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+// Intersection returns the common point of the segment and s. If the segments lie on the same line and overlap,
+// the common point nearest to the start of the segment is returned. Returns false if there's no common point.
+func (l *Segment) Intersection(s *Segment) (*Point, bool) {
+	rx, ry := l.B.X-l.A.X, l.B.Y-l.A.Y
+	sx, sy := s.B.X-s.A.X, s.B.Y-s.A.Y
+	denom := rx*sy - ry*sx
+	if math.Abs(denom) <= tolerance*math.Max(1, l.Distance()*s.Distance()) {
+		var nearest *Point
+		for _, p := range []*Point{l.A, s.A, s.B, l.B} {
+			if !pointOnSegment(p, l) || !pointOnSegment(p, s) {
+				continue
+			}
+			if nearest == nil || (&Segment{A: l.A, B: p}).Distance() < (&Segment{A: l.A, B: nearest}).Distance() {
+				nearest = p
+			}
+		}
+		if nearest == nil {
+			return nil, false
+		}
+		return &Point{X: nearest.X, Y: nearest.Y}, true
+	}
+	qx, qy := s.A.X-l.A.X, s.A.Y-l.A.Y
+	t, u := (qx*sy-qy*sx)/denom, (qx*ry-qy*rx)/denom
+	p := &Point{X: l.A.X + rx*t, Y: l.A.Y + ry*t}
+	if (t < 0 || t > 1 || u < 0 || u > 1) && (!pointOnSegment(p, l) || !pointOnSegment(p, s)) {
+		return nil, false
+	}
+	return p, true
+}
@@ -57,3 +57,56 @@ func TestLine_Distance(t *testing.T) {
 		})
 	}
 }
+
+func TestSegment_Intersection(t *testing.T) {
+	tests := []struct {
+		name   string
+		l, s   *Segment
+		want   *Point
+		wantOk bool
+	}{
+		{
+			name:   "Crossing",
+			l:      &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 4, Y: 4}},
+			s:      &Segment{A: &Point{X: 0, Y: 4}, B: &Point{X: 4, Y: 0}},
+			want:   &Point{X: 2, Y: 2},
+			wantOk: true,
+		},
+		{
+			name:   "Touching by the end",
+			l:      &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 2, Y: 0}},
+			s:      &Segment{A: &Point{X: 1, Y: 0}, B: &Point{X: 1, Y: 3}},
+			want:   &Point{X: 1, Y: 0},
+			wantOk: true,
+		},
+		{
+			name: "Apart",
+			l:    &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 1, Y: 1}},
+			s:    &Segment{A: &Point{X: 3, Y: 0}, B: &Point{X: 2, Y: 1}},
+		},
+		{
+			name: "Parallel",
+			l:    &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 4, Y: 0}},
+			s:    &Segment{A: &Point{X: 0, Y: 1}, B: &Point{X: 4, Y: 1}},
+		},
+		{
+			name:   "Overlapping",
+			l:      &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 4, Y: 0}},
+			s:      &Segment{A: &Point{X: 5, Y: 0}, B: &Point{X: 2, Y: 0}},
+			want:   &Point{X: 2, Y: 0},
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.l.Intersection(tt.s)
+			if ok != tt.wantOk {
+				t.Errorf("Intersection() ok = %v, want %v", ok, tt.wantOk)
+				return
+			}
+			if ok && (!compareFloats(got.X, tt.want.X, 1e-9) || !compareFloats(got.Y, tt.want.Y, 1e-9)) {
+				t.Errorf("Intersection() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+)
+
+// Shrinkage is a model of the stretch ceiling canvas shrinkage. The canvas is cut smaller than the room and fits it
+// after stretching. X and Y are parts of the size (0.07 is 7%), which the canvas is reduced by along and across
+// the roll. Direction is the direction of the roll in radians.
+type Shrinkage struct {
+	X, Y      float64
+	Direction float64
+}
+
+func (s *Shrinkage) check() error {
+	if s.X < 0 || s.X >= 1 || s.Y < 0 || s.Y >= 1 {
+		return fmt.Errorf("%w: shrinkage must be from 0 to 1, got %v and %v", ErrWrongMeasurement, s.X, s.Y)
+	}
+	return nil
+}
+
+// transform returns the point p reduced by the shrinkage about the point o.
+func (s *Shrinkage) transform(o, p *Point) *Point {
+	sin, cos := math.Sincos(s.Direction)
+	dx, dy := p.X-o.X, p.Y-o.Y
+	// coordinates along and across the roll
+	u, v := (dx*cos+dy*sin)*(1-s.X), (-dx*sin+dy*cos)*(1-s.Y)
+	return &Point{X: o.X + u*cos - v*sin, Y: o.Y + u*sin + v*cos}
+}
+
+// Pattern returns the cut pattern polygon: pol reduced by the shrinkage about its first point.
+// Points of the pattern have only coordinates, arcs are converted by the middle point of the arc.
+func (s *Shrinkage) Pattern(pol *Polygon) (*Polygon, error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	pattern := NewPolygon()
+	if pol.Len() == 0 {
+		return pattern, nil
+	}
+	o := pol.Points[0]
+	for _, p := range pol.Points {
+		pattern.Points = append(pattern.Points, s.transform(o, p))
+	}
+	n := pol.Len()
+	for i := range pol.Points {
+		cs, err := pol.CurvedSide(i)
+		if err != nil {
+			return nil, err
+		}
+		if cs == nil {
+			continue
+		}
+		a, b := pattern.Points[i], pattern.Points[(i+1)%n]
+		middle := s.transform(o, cs.PointAt(0.5))
+		sagitta := crossProduct(a, b, middle) / (&Segment{A: a, B: b}).Distance()
+		b.Arc = &Arc{Sagitta: math.Abs(sagitta), Right: sagitta < 0}
+	}
+	return pattern, nil
+}
@@ -0,0 +1,67 @@
+package figure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestShrinkage_Pattern(t *testing.T) {
+	tests := []struct {
+		name      string
+		shrinkage Shrinkage
+		points    []*Point
+		want      []*Point
+		wantErr   error
+	}{
+		{
+			name:      "Along axes",
+			shrinkage: Shrinkage{X: 0.1, Y: 0.05},
+			points:    []*Point{{X: 1, Y: 1}, {X: 1, Y: 3}, {X: 5, Y: 3}, {X: 5, Y: 1}},
+			want:      []*Point{{X: 1, Y: 1}, {X: 1, Y: 2.9}, {X: 4.6, Y: 2.9}, {X: 4.6, Y: 1}},
+		},
+		{
+			name:      "Roll along Y axis",
+			shrinkage: Shrinkage{X: 0.1, Y: 0.05, Direction: math.Pi / 2},
+			points:    []*Point{{X: 1, Y: 1}, {X: 1, Y: 3}, {X: 5, Y: 3}, {X: 5, Y: 1}},
+			want:      []*Point{{X: 1, Y: 1}, {X: 1, Y: 2.8}, {X: 4.8, Y: 2.8}, {X: 4.8, Y: 1}},
+		},
+		{
+			name:      "Arc",
+			shrinkage: Shrinkage{X: 0.1, Y: 0.1},
+			points:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0, Arc: &Arc{Sagitta: 1}}},
+			want: []*Point{{X: 0, Y: 0}, {X: 0, Y: 1.8}, {X: 3.6, Y: 1.8},
+				{X: 3.6, Y: 0, Arc: &Arc{Sagitta: 0.9}}},
+		},
+		{
+			name:      "Wrong shrinkage",
+			shrinkage: Shrinkage{X: 1.2},
+			points:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}},
+			wantErr:   ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.shrinkage.Pattern(&Polygon{Points: tt.points})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Pattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got.Points) != len(tt.want) {
+				t.Fatalf("Pattern() got %d points, want %d", len(got.Points), len(tt.want))
+			}
+			for i, p := range got.Points {
+				w := tt.want[i]
+				if !compareFloats(p.X, w.X, 1e-9) || !compareFloats(p.Y, w.Y, 1e-9) {
+					t.Errorf("Pattern() point %d = (%v;%v), want (%v;%v)", i, p.X, p.Y, w.X, w.Y)
+				}
+				if (p.Arc == nil) != (w.Arc == nil) || (p.Arc != nil && (!compareFloats(p.Arc.Sagitta, w.Arc.Sagitta, 1e-9) ||
+					p.Arc.Right != w.Arc.Right)) {
+					t.Errorf("Pattern() arc of point %d = %v, want %v", i, p.Arc, w.Arc)
+				}
+			}
+		})
+	}
+}
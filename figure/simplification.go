@@ -0,0 +1,195 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// Simplification is parameters of removing redundant points of the polygon, e.g. of the outline imported from
+// a laser measurer or CAD. Points closer than MergeDistance to the previous point are merged into it, then points
+// deviating from the outline by at most Tolerance are removed by the Douglas–Peucker algorithm. Both are lengths,
+// a zero value turns the step off.
+type Simplification struct {
+	Tolerance     float64 `json:"tolerance"`
+	MergeDistance float64 `json:"merge_distance"`
+}
+
+// SimplificationReport is a result of the simplification. Merged and Removed are indexes of points removed
+// by merging and by the Douglas–Peucker algorithm in the order of points before the simplification. MaxDeviation is
+// the largest distance from removed points to the new outline and AreaChange is the new area minus the old one.
+type SimplificationReport struct {
+	Merged       []int   `json:"merged"`
+	Removed      []int   `json:"removed"`
+	MaxDeviation float64 `json:"max_deviation"`
+	AreaChange   float64 `json:"area_change"`
+}
+
+func (s *Simplification) ConvertToOne(measures *value.FigureMeasures) {
+	s.Tolerance = value.ConvertToOne(measures.Length, s.Tolerance)
+	s.MergeDistance = value.ConvertToOne(measures.Length, s.MergeDistance)
+}
+
+func (s *Simplification) ConvertFromOne(measures *value.FigureMeasures) {
+	s.Tolerance = value.ConvertFromOne(measures.Length, s.Tolerance)
+	s.MergeDistance = value.ConvertFromOne(measures.Length, s.MergeDistance)
+}
+
+func (r *SimplificationReport) ConvertFromOne(measures *value.FigureMeasures) {
+	r.MaxDeviation = value.ConvertFromOne(measures.Length, r.MaxDeviation)
+	r.AreaChange = value.ConvertFromOne(measures.Area, r.AreaChange)
+}
+
+// check returns an error if the tolerance or the merge distance is negative or both of them are zero.
+func (s *Simplification) check() error {
+	if s.Tolerance < 0 || s.MergeDistance < 0 {
+		return fmt.Errorf("%w: tolerance and merge distance of the simplification can't be negative", ErrWrongMeasurement)
+	}
+	if s.Tolerance == 0 && s.MergeDistance == 0 {
+		return fmt.Errorf("%w: tolerance or merge distance of the simplification must be set", ErrWrongMeasurement)
+	}
+	return nil
+}
+
+// Simplify merges close points and removes points lying nearly on straight lines between other points
+// (look at Simplification). The first point and ends of curved sides are always kept, so arcs aren't changed.
+// Diagonals of removed points are removed, calculators of other points are rebased to their coordinates.
+// Returns an error if less than three points are left.
+func (pol *Polygon) Simplify(s *Simplification) (*SimplificationReport, error) {
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	n := pol.Len()
+	if n < 3 {
+		return nil, fmt.Errorf("%w for the simplification (%d), must be at least 3", ErrNotEnoughPoints, n)
+	}
+	original, area := append([]*Point{}, pol.Points...), pol.Area()
+	fixed, keep := make([]bool, n), make([]bool, n)
+	for i, p := range pol.Points {
+		fixed[i] = i == 0 || p.Arc != nil || pol.Points[(i+1)%n].Arc != nil
+		keep[i] = true
+	}
+	report := &SimplificationReport{Merged: make([]int, 0), Removed: make([]int, 0)}
+	if s.MergeDistance > 0 {
+		report.Merged = pol.mergeVertices(s.MergeDistance, fixed, keep)
+	}
+	if s.Tolerance > 0 {
+		report.Removed = pol.douglasPeucker(s.Tolerance, fixed, keep)
+	}
+	if kept := n - len(report.Merged) - len(report.Removed); kept < 3 {
+		return nil, fmt.Errorf("%w after the simplification (%d), must be at least 3", ErrNotEnoughPoints, kept)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		if keep[i] {
+			continue
+		}
+		if err := pol.RemovePoint(i); err != nil {
+			return nil, err
+		}
+	}
+	for i, p := range pol.Points {
+		if p.Calculator == nil {
+			continue
+		}
+		if err := pol.rebasePoint(i); err != nil {
+			return nil, err
+		}
+	}
+	for i := range original {
+		if !keep[i] {
+			report.MaxDeviation = math.Max(report.MaxDeviation, pol.NearestWalls(original[i], 1)[0].Distance)
+		}
+	}
+	report.AreaChange = pol.Area() - area
+	return report, nil
+}
+
+// mergeVertices marks points closer than the distance to the previous kept point as removed and returns
+// their indexes. Fixed points aren't removed.
+func (pol *Polygon) mergeVertices(distance float64, fixed, keep []bool) []int {
+	merged, last := make([]int, 0), 0
+	for i := 1; i < pol.Len(); i++ {
+		if !fixed[i] && (&Segment{A: pol.Points[last], B: pol.Points[i]}).Distance() < distance {
+			keep[i] = false
+			merged = append(merged, i)
+			continue
+		}
+		last = i
+	}
+	// the last points can be close to the first one
+	for i := pol.Len() - 1; i > 0; i-- {
+		if !keep[i] {
+			continue
+		}
+		if fixed[i] || (&Segment{A: pol.Points[i], B: pol.Points[0]}).Distance() >= distance {
+			break
+		}
+		keep[i] = false
+		merged = append(merged, i)
+	}
+	sort.Ints(merged)
+	return merged
+}
+
+// douglasPeucker marks kept points, which deviate from the line between neighbouring kept points by at most
+// the tolerance, as removed and returns their indexes. Chains of points between fixed points are simplified
+// separately, if only the first point is fixed, then the most distant point from it is fixed too.
+func (pol *Polygon) douglasPeucker(tol float64, fixed, keep []bool) []int {
+	indexes, anchors := make([]int, 0, pol.Len()), make([]int, 0)
+	farthest, distance := 0, 0.0
+	for i := range pol.Points {
+		if !keep[i] {
+			continue
+		}
+		if fixed[i] {
+			anchors = append(anchors, len(indexes))
+		}
+		if d := (&Segment{A: pol.Points[0], B: pol.Points[i]}).Distance(); d > distance {
+			farthest, distance = len(indexes), d
+		}
+		indexes = append(indexes, i)
+	}
+	if len(anchors) == 1 && farthest != 0 {
+		anchors = append(anchors, farthest)
+	}
+	removed := make([]int, 0)
+	var simplify func(from, to int)
+	simplify = func(from, to int) {
+		if to-from < 2 {
+			return
+		}
+		a, b := pol.Points[indexes[from]], pol.Points[indexes[to%len(indexes)]]
+		chord, worst, deviation := &Segment{A: a, B: b}, -1, 0.0
+		for k := from + 1; k < to; k++ {
+			p, foot := pol.Points[indexes[k]], a
+			if chord.Distance() >= tolerance {
+				foot = chord.nearestPoint(p)
+			}
+			d := (&Segment{A: p, B: foot}).Distance()
+			if d > deviation {
+				worst, deviation = k, d
+			}
+		}
+		if deviation > tol {
+			simplify(from, worst)
+			simplify(worst, to)
+			return
+		}
+		for k := from + 1; k < to; k++ {
+			keep[indexes[k]] = false
+			removed = append(removed, indexes[k])
+		}
+	}
+	for k, from := range anchors {
+		to := len(indexes)
+		if k+1 < len(anchors) {
+			to = anchors[k+1]
+		}
+		simplify(from, to)
+	}
+	sort.Ints(removed)
+	return removed
+}
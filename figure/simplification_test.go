@@ -0,0 +1,84 @@
+package figure
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPolygon_Simplify(t *testing.T) {
+	tests := []struct {
+		name          string
+		points        []*Point
+		s             *Simplification
+		wantPoints    []*Point
+		wantMerged    []int
+		wantRemoved   []int
+		wantDeviation float64
+		wantErr       error
+	}{
+		{
+			name: "Nearly collinear points",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0.01, Y: 2}, {X: 0, Y: 3}, {X: 2, Y: 3}, {X: 4, Y: 3.01},
+				{X: 4, Y: 0}, {X: 2, Y: 0}},
+			s:             &Simplification{Tolerance: 0.02},
+			wantPoints:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 4, Y: 3.01}, {X: 4, Y: 0}},
+			wantMerged:    []int{},
+			wantRemoved:   []int{1, 2, 4, 7},
+			wantDeviation: 0.01,
+		},
+		{
+			name: "Close points",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 0.005, Y: 3.005}, {X: 4, Y: 3}, {X: 4, Y: 0},
+				{X: 0.003, Y: 0}},
+			s:             &Simplification{MergeDistance: 0.01},
+			wantPoints:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 4, Y: 3}, {X: 4, Y: 0}},
+			wantMerged:    []int{2, 5},
+			wantRemoved:   []int{},
+			wantDeviation: 0.005,
+		},
+		{
+			name: "Arcs are kept",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 4, Arc: &Arc{Sagitta: 0.001}}, {X: 2, Y: 4}, {X: 4, Y: 4},
+				{X: 4, Y: 0}},
+			s:           &Simplification{Tolerance: 0.1},
+			wantPoints:  []*Point{{X: 0, Y: 0}, {X: 0, Y: 4, Arc: &Arc{Sagitta: 0.001}}, {X: 4, Y: 4}, {X: 4, Y: 0}},
+			wantMerged:  []int{},
+			wantRemoved: []int{2},
+		},
+		{
+			name:    "Too few points left",
+			points:  []*Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0.01, Y: 2}, {X: 0, Y: 3}},
+			s:       &Simplification{Tolerance: 0.1},
+			wantErr: ErrNotEnoughPoints,
+		},
+		{
+			name:    "Nothing to do",
+			points:  []*Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}},
+			s:       &Simplification{},
+			wantErr: ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := &Polygon{Points: tt.points}
+			got, err := pol.Simplify(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Simplify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(pol.Points, tt.wantPoints) {
+				t.Errorf("Simplify() points = %v, want %v", pol.Points, tt.wantPoints)
+			}
+			if !reflect.DeepEqual(got.Merged, tt.wantMerged) || !reflect.DeepEqual(got.Removed, tt.wantRemoved) {
+				t.Errorf("Simplify() merged %v, removed %v, want %v, %v", got.Merged, got.Removed, tt.wantMerged, tt.wantRemoved)
+			}
+			if !compareFloats(got.MaxDeviation, tt.wantDeviation, 1e-9) {
+				t.Errorf("Simplify() deviation = %v, want %v", got.MaxDeviation, tt.wantDeviation)
+			}
+		})
+	}
+}
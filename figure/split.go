@@ -0,0 +1,138 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// SplitLine is the straight line, along which the polygon is split into separate canvases, e.g. at a partition beam.
+// The line goes through points A and B, or through the vertex by the index Vertex in the Direction in radians
+// if A is nil.
+type SplitLine struct {
+	A         *Point  `json:"a,omitempty"`
+	B         *Point  `json:"b,omitempty"`
+	Vertex    int     `json:"vertex,omitempty"`
+	Direction float64 `json:"direction,omitempty"`
+}
+
+func (l *SplitLine) ConvertToOne(measures *value.FigureMeasures) {
+	l.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) })
+	l.Direction = value.ConvertToOne(measures.Angle, l.Direction)
+}
+
+func (l *SplitLine) ConvertFromOne(measures *value.FigureMeasures) {
+	l.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) })
+	l.Direction = value.ConvertFromOne(measures.Angle, l.Direction)
+}
+
+func (l *SplitLine) convert(length func(float64) float64) {
+	for _, p := range []*Point{l.A, l.B} {
+		if p != nil {
+			p.X, p.Y = length(p.X), length(p.Y)
+		}
+	}
+}
+
+// origin returns the point of the line and the unit vector of its direction.
+func (l *SplitLine) origin(pol *Polygon) (p *Point, ux, uy float64, err error) {
+	if l.A == nil {
+		if l.Vertex < 0 || l.Vertex >= pol.Len() {
+			return nil, 0, 0, fmt.Errorf("%w: vertex %d of the split line doesn't exist", ErrWrongMeasurement, l.Vertex)
+		}
+		uy, ux = math.Sincos(l.Direction)
+		return pol.Points[l.Vertex], ux, uy, nil
+	}
+	if l.B == nil {
+		return nil, 0, 0, fmt.Errorf("%w: the split line needs two points", ErrWrongMeasurement)
+	}
+	length := (&Segment{A: l.A, B: l.B}).Distance()
+	if length < tolerance {
+		return nil, 0, 0, fmt.Errorf("%w: points of the split line are the same", ErrWrongMeasurement)
+	}
+	return l.A, (l.B.X - l.A.X) / length, (l.B.Y - l.A.Y) / length, nil
+}
+
+// Split returns parts of the polygon cut by the line. Parts to the left of the line go first, then parts to the right,
+// each in the order of decreasing area. A concave polygon can fall apart into more than two parts. Curved sides
+// crossed by the line are approximated by chords, other curved sides are kept. Returns an error if the line doesn't
+// cross the polygon.
+func (pol *Polygon) Split(line *SplitLine) ([]*Polygon, error) {
+	p, ux, uy, err := line.origin(pol)
+	if err != nil {
+		return nil, err
+	}
+	ring, err := pol.booleanRing()
+	if err != nil {
+		return nil, err
+	}
+	// half-planes are rectangles on both sides of the line, which are larger than the polygon
+	size := 1.0
+	for _, r := range ring {
+		size = math.Max(size, 2*(&Segment{A: p, B: r}).Distance())
+	}
+	parts := make([]*Polygon, 0, 2)
+	for _, side := range []float64{1, -1} {
+		nx, ny := -uy*side*size, ux*side*size
+		half := booleanRing{
+			{X: p.X - ux*size, Y: p.Y - uy*size},
+			{X: p.X + ux*size, Y: p.Y + uy*size},
+			{X: p.X + ux*size + nx, Y: p.Y + uy*size + ny},
+			{X: p.X - ux*size + nx, Y: p.Y - uy*size + ny},
+		}
+		if half.signedArea() < 0 {
+			half.reverse()
+		}
+		for _, r := range ringsRegions(booleanOverlay(BooleanIntersection, []booleanRing{ring}, []booleanRing{half})) {
+			parts = append(parts, r.Outline.withArcsOf(pol))
+		}
+	}
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("%w: the line doesn't split the polygon", ErrWrongMeasurement)
+	}
+	return parts, nil
+}
+
+// withArcsOf returns the polygon, where chords of whole curved sides of the source polygon are replaced by arcs.
+func (pol *Polygon) withArcsOf(source *Polygon) *Polygon {
+	n, removed := pol.Len(), make(map[int]bool)
+	same := func(i int, p *Point) bool {
+		return (&Segment{A: pol.Points[(i+n)%n], B: p}).Distance() <= booleanTolerance
+	}
+	for i := range source.Points {
+		end := source.Points[(i+1)%source.Len()]
+		chords := source.sidePath(i)
+		if end.Arc == nil || len(chords) < 2 {
+			continue
+		}
+		m := len(chords)
+		for k := range pol.Points {
+			forward, backward := true, true
+			for c, s := range chords {
+				forward = forward && same(k+c, s.A) && same(k+c+1, s.B)
+				backward = backward && same(k+m-c, s.A) && same(k+m-c-1, s.B)
+			}
+			if !forward && !backward {
+				continue
+			}
+			arc := *end.Arc
+			if backward {
+				// the side goes in the opposite direction, so the arc bulges to the other side
+				arc.Right = !arc.Right
+			}
+			pol.Points[(k+m)%n].Arc = &arc
+			for c := 1; c < m; c++ {
+				removed[(k+c)%n] = true
+			}
+			break
+		}
+	}
+	points := make([]*Point, 0, n-len(removed))
+	for i, p := range pol.Points {
+		if !removed[i] {
+			points = append(points, p)
+		}
+	}
+	return &Polygon{Points: points}
+}
@@ -0,0 +1,98 @@
+package figure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPolygon_Split(t *testing.T) {
+	rectangle := []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}}
+	tests := []struct {
+		name      string
+		points    []*Point
+		line      SplitLine
+		wantAreas []float64
+		wantLens  []int
+		wantErr   error
+	}{
+		{
+			name:      "By two points",
+			points:    rectangle,
+			line:      SplitLine{A: &Point{X: 1, Y: 0}, B: &Point{X: 1, Y: 1}},
+			wantAreas: []float64{2, 6},
+			wantLens:  []int{4, 4},
+		},
+		{
+			name:      "By the vertex and the direction",
+			points:    rectangle,
+			line:      SplitLine{Vertex: 0, Direction: math.Pi / 4},
+			wantAreas: []float64{2, 6},
+			wantLens:  []int{3, 4},
+		},
+		{
+			name: "Concave polygon falls apart",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 1, Y: 3}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 3},
+				{X: 3, Y: 3}, {X: 3, Y: 0}},
+			line:      SplitLine{A: &Point{X: 0, Y: 2}, B: &Point{X: 1, Y: 2}},
+			wantAreas: []float64{1, 1, 5},
+			wantLens:  []int{4, 4, 8},
+		},
+		{
+			name:      "Curved side is kept",
+			points:    []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 0, Arc: &Arc{Sagitta: 1}}},
+			line:      SplitLine{A: &Point{X: 1, Y: 4}, B: &Point{X: 1, Y: 0}},
+			wantAreas: []float64{12 + (2.5*2.5*math.Asin(0.8) - 4*1.5/2), 4},
+			wantLens:  []int{4, 4},
+		},
+		{
+			name:    "Line along the side",
+			points:  rectangle,
+			line:    SplitLine{A: &Point{X: 0, Y: 0}, B: &Point{X: 0, Y: 1}},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Line misses the polygon",
+			points:  rectangle,
+			line:    SplitLine{A: &Point{X: 10, Y: 0}, B: &Point{X: 10, Y: 1}},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Same points",
+			points:  rectangle,
+			line:    SplitLine{A: &Point{X: 1, Y: 0}, B: &Point{X: 1, Y: 0}},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Vertex doesn't exist",
+			points:  rectangle,
+			line:    SplitLine{Vertex: 4},
+			wantErr: ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPolygon(tt.points...).Split(&tt.line)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(got) != len(tt.wantAreas) {
+				t.Fatalf("Split() got %d parts, want %d", len(got), len(tt.wantAreas))
+			}
+			for i, part := range got {
+				if !compareFloats(part.Area(), tt.wantAreas[i], 1e-9) {
+					t.Errorf("Split() part %d area = %v, want %v", i, part.Area(), tt.wantAreas[i])
+				}
+				if part.Len() != tt.wantLens[i] {
+					t.Errorf("Split() part %d got %d points, want %d", i, part.Len(), tt.wantLens[i])
+				}
+				if part.signedArea() > 0 || len(part.intersectionsProblems()) > 0 {
+					t.Errorf("Split() part %d got a wrong outline %v", i, part.Problems())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// squaringDamping keeps the system of equations solvable if constraints of sides are redundant.
+const squaringDamping = 1e-12
+
+// Squaring is parameters of snapping nearly right angles of the polygon. Directions of straight sides, which differ
+// from multiples of Step by at most Tolerance, are made exact multiples of Step. Step is 90 or 45 degrees, Step and
+// Tolerance are in radians. Directions are counted from the longest straight side.
+type Squaring struct {
+	Step      float64 `json:"step"`
+	Tolerance float64 `json:"tolerance"`
+}
+
+// SquaringReport is a result of the squaring. Shifts are distances, which points have moved by, in the order
+// of points.
+type SquaringReport struct {
+	SnappedSides int       `json:"snapped_sides"`
+	Shifts       []float64 `json:"shifts"`
+	MaxShift     float64   `json:"max_shift"`
+	TotalShift   float64   `json:"total_shift"`
+}
+
+func (sq *Squaring) ConvertToOne(measures *value.FigureMeasures) {
+	sq.Step = value.ConvertToOne(measures.Angle, sq.Step)
+	sq.Tolerance = value.ConvertToOne(measures.Angle, sq.Tolerance)
+}
+
+func (sq *Squaring) ConvertFromOne(measures *value.FigureMeasures) {
+	sq.Step = value.ConvertFromOne(measures.Angle, sq.Step)
+	sq.Tolerance = value.ConvertFromOne(measures.Angle, sq.Tolerance)
+}
+
+func (r *SquaringReport) ConvertFromOne(measures *value.FigureMeasures) {
+	for i, s := range r.Shifts {
+		r.Shifts[i] = value.ConvertFromOne(measures.Length, s)
+	}
+	r.MaxShift = value.ConvertFromOne(measures.Length, r.MaxShift)
+	r.TotalShift = value.ConvertFromOne(measures.Length, r.TotalShift)
+}
+
+// check sets the step to 90 degrees if it's zero and returns an error if the step or the tolerance is wrong.
+func (sq *Squaring) check() error {
+	if sq.Step == 0 {
+		sq.Step = math.Pi / 2
+	}
+	if math.Abs(sq.Step-math.Pi/2) > tolerance && math.Abs(sq.Step-math.Pi/4) > tolerance {
+		return fmt.Errorf("%w: step of squaring must be 90 or 45 degrees, got %v radians", ErrWrongMeasurement, sq.Step)
+	}
+	if sq.Tolerance <= 0 || sq.Tolerance >= sq.Step/2 {
+		return fmt.Errorf("%w: tolerance of squaring must be from 0 to a half of the step, got %v radians",
+			ErrWrongMeasurement, sq.Tolerance)
+	}
+	return nil
+}
+
+// Square snaps directions of straight sides within the tolerance to multiples of the step and moves points as little
+// as possible: the sum of squared shifts of points is minimal. Snapped directions are rotated by the average
+// deviation of sides weighted by their lengths. Curved sides aren't snapped, arcs are kept.
+// Calculators of points are rebased to new coordinates.
+func (pol *Polygon) Square(sq *Squaring) (*SquaringReport, error) {
+	if err := sq.check(); err != nil {
+		return nil, err
+	}
+	n := pol.Len()
+	if n < 3 {
+		return nil, fmt.Errorf("%w for squaring (%d), must be at least 3", ErrNotEnoughPoints, n)
+	}
+	directions := pol.squaringDirections(sq)
+	report := &SquaringReport{SnappedSides: len(directions), Shifts: make([]float64, n)}
+	if len(directions) == 0 {
+		return report, nil
+	}
+
+	// constraints are normals of snapped sides, their projections of sides must be zero
+	rows := make([][]float64, 0, len(directions))
+	for i := range pol.Points {
+		direction, ok := directions[i]
+		if !ok {
+			continue
+		}
+		j := (i + 1) % n
+		row := make([]float64, n*2)
+		nx, ny := -math.Sin(direction), math.Cos(direction)
+		row[i*2], row[i*2+1] = -nx, -ny
+		row[j*2], row[j*2+1] = nx, ny
+		rows = append(rows, row)
+	}
+	coordinates := make([]float64, n*2)
+	for i, p := range pol.Points {
+		coordinates[i*2], coordinates[i*2+1] = p.X, p.Y
+	}
+	// the least shifts are -Aᵀλ, where (AAᵀ)λ = A*coordinates
+	m := len(rows)
+	normal, right := make([][]float64, m), make([]float64, m)
+	for r1 := range rows {
+		normal[r1] = make([]float64, m)
+		for r2 := range rows {
+			for c := range coordinates {
+				normal[r1][r2] += rows[r1][c] * rows[r2][c]
+			}
+		}
+		normal[r1][r1] += squaringDamping
+		for c, v := range coordinates {
+			right[r1] += rows[r1][c] * v
+		}
+	}
+	lambda, err := solveLinearSystem(normal, right)
+	if err != nil {
+		return nil, err
+	}
+	for r, row := range rows {
+		for c, v := range row {
+			coordinates[c] -= v * lambda[r]
+		}
+	}
+	for i, p := range pol.Points {
+		x, y := coordinates[i*2], coordinates[i*2+1]
+		shift := math.Hypot(x-p.X, y-p.Y)
+		p.X, p.Y = x, y
+		report.Shifts[i] = shift
+		report.MaxShift = math.Max(report.MaxShift, shift)
+		report.TotalShift += shift
+	}
+	for i, p := range pol.Points {
+		if p.Calculator == nil {
+			continue
+		}
+		if err := pol.rebasePoint(i); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// squaringDirections returns snapped directions of straight sides by their indexes. Sides, which can't be snapped
+// within the tolerance, are skipped.
+func (pol *Polygon) squaringDirections(sq *Squaring) map[int]float64 {
+	sides := pol.Sides()
+	reference, longest := 0.0, 0.0
+	for i, s := range sides {
+		if cs, _ := pol.CurvedSide(i); cs == nil && s.Distance() > longest {
+			reference, longest = pointDirection(s.A, s.B), s.Distance()
+		}
+	}
+	if longest == 0 {
+		return nil
+	}
+	steps := make(map[int]float64)
+	deviation, weight := 0.0, 0.0
+	for i, s := range sides {
+		if cs, _ := pol.CurvedSide(i); cs != nil || s.Distance() < tolerance {
+			continue
+		}
+		turn := normalizeAngle(pointDirection(s.A, s.B) - reference)
+		k := math.Round(turn / sq.Step)
+		if d := turn - k*sq.Step; math.Abs(d) <= sq.Tolerance {
+			steps[i] = k
+			deviation, weight = deviation+d*s.Distance(), weight+s.Distance()
+		}
+	}
+	directions := make(map[int]float64, len(steps))
+	for i, k := range steps {
+		directions[i] = reference + deviation/weight + k*sq.Step
+	}
+	return directions
+}
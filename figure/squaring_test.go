@@ -0,0 +1,101 @@
+package figure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPolygon_Square(t *testing.T) {
+	degree := math.Pi / 180
+	tests := []struct {
+		name        string
+		points      []*Point
+		squaring    Squaring
+		wantSnapped int
+		wantAngles  []float64
+		wantErr     error
+	}{
+		{
+			name:        "Nearly rectangular room",
+			points:      []*Point{{X: 0, Y: 0}, {X: 0.03, Y: 3}, {X: 4, Y: 3.02}, {X: 4.01, Y: -0.02}},
+			squaring:    Squaring{Tolerance: 2 * degree},
+			wantSnapped: 4,
+			wantAngles:  []float64{90, 90, 90, 90},
+		},
+		{
+			name: "L-shaped room",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 4}, {X: 2.02, Y: 4.01}, {X: 2, Y: 2}, {X: 6, Y: 2.05},
+				{X: 5.98, Y: 0}},
+			squaring:    Squaring{Step: math.Pi / 2, Tolerance: 2 * degree},
+			wantSnapped: 6,
+			wantAngles:  []float64{90, 90, 90, 270, 90, 90},
+		},
+		{
+			name:        "Chamfered corner by 45 degrees",
+			points:      []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 2, Y: 3}, {X: 3.02, Y: 1.99}, {X: 3, Y: 0}},
+			squaring:    Squaring{Step: math.Pi / 4, Tolerance: 2 * degree},
+			wantSnapped: 5,
+			wantAngles:  []float64{90, 90, 135, 135, 90},
+		},
+		{
+			name:        "Side out of the tolerance",
+			points:      []*Point{{X: 0, Y: 0}, {X: 0.02, Y: 3}, {X: 3, Y: 3}, {X: 4, Y: 0}},
+			squaring:    Squaring{Tolerance: 2 * degree},
+			wantSnapped: 3,
+		},
+		{
+			name:     "Wrong step",
+			points:   []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 4, Y: 3}},
+			squaring: Squaring{Step: math.Pi / 3, Tolerance: degree},
+			wantErr:  ErrWrongMeasurement,
+		},
+		{
+			name:     "Wrong tolerance",
+			points:   []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 4, Y: 3}},
+			squaring: Squaring{Tolerance: math.Pi / 4},
+			wantErr:  ErrWrongMeasurement,
+		},
+		{
+			name:     "Not enough points",
+			points:   []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}},
+			squaring: Squaring{Tolerance: degree},
+			wantErr:  ErrNotEnoughPoints,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := NewPolygon(tt.points...)
+			original := make([]Point, len(tt.points))
+			for i, p := range tt.points {
+				original[i] = *p
+			}
+			got, err := pol.Square(&tt.squaring)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Square() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.SnappedSides != tt.wantSnapped {
+				t.Errorf("Square() snapped %d sides, want %d", got.SnappedSides, tt.wantSnapped)
+			}
+			for i, c := range pol.Corners() {
+				if i < len(tt.wantAngles) && !compareFloats(c.Angle/degree, tt.wantAngles[i], 1e-6) {
+					t.Errorf("Square() angle %d = %v, want %v", i, c.Angle/degree, tt.wantAngles[i])
+				}
+			}
+			total := 0.0
+			for i, p := range pol.Points {
+				shift := math.Hypot(p.X-original[i].X, p.Y-original[i].Y)
+				if !compareFloats(shift, got.Shifts[i], 1e-9) || shift > got.MaxShift+1e-9 || shift > 0.05 {
+					t.Errorf("Square() shift of point %d = %v, reported %v, max %v", i, shift, got.Shifts[i], got.MaxShift)
+				}
+				total += shift
+			}
+			if !compareFloats(total, got.TotalShift, 1e-9) {
+				t.Errorf("Square() total shift = %v, want %v", got.TotalShift, total)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// maxTilesCells is the limit of grid cells covering the polygon bounding box.
+const maxTilesCells = 100000
+
+// Tiles is a grid of a suspended ceiling: tiles of Width across main runners and Length along them (600x600,
+// 600x1200 and others). Main runners go in the Direction in radians and the grid lines go through the origin point.
+type Tiles struct {
+	Width     float64 `json:"width"`
+	Length    float64 `json:"length"`
+	OriginX   float64 `json:"origin_x"`
+	OriginY   float64 `json:"origin_y"`
+	Direction float64 `json:"direction"`
+}
+
+func (t *Tiles) ConvertToOne(measures *value.FigureMeasures) {
+	t.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) })
+	t.Direction = value.ConvertToOne(measures.Angle, t.Direction)
+}
+
+func (t *Tiles) ConvertFromOne(measures *value.FigureMeasures) {
+	t.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) })
+	t.Direction = value.ConvertFromOne(measures.Angle, t.Direction)
+}
+
+func (t *Tiles) convert(length func(float64) float64) {
+	t.Width, t.Length = length(t.Width), length(t.Length)
+	t.OriginX, t.OriginY = length(t.OriginX), length(t.OriginY)
+}
+
+func (t *Tiles) check() error {
+	if t.Width <= 0 || t.Length <= 0 {
+		return fmt.Errorf("%w: size of a tile must be positive, got %vx%v", ErrWrongMeasurement, t.Width, t.Length)
+	}
+	return nil
+}
+
+// TileCut is a tile cut by walls, Points are the shape of the part covering the ceiling.
+type TileCut struct {
+	Points []*Point `json:"points"`
+	Area   float64  `json:"area"`
+}
+
+// TileLayout is the suspended ceiling grid over the polygon. MainRunners and CrossTees are total lengths of grid
+// lines inside of the polygon along and across the direction of the grid. Grid lines on walls aren't included,
+// there are wall angles there.
+type TileLayout struct {
+	FullTiles   int        `json:"full_tiles"`
+	CutTiles    int        `json:"cut_tiles"`
+	MainRunners float64    `json:"main_runners"`
+	CrossTees   float64    `json:"cross_tees"`
+	Cuts        []*TileCut `json:"cuts"`
+	Runners     []*Segment `json:"-"`
+	Tees        []*Segment `json:"-"`
+}
+
+// TileLayout places the grid of tiles over the polygon and counts full and cut tiles. Curved sides are
+// approximated by chords. Holes aren't considered.
+func (pol *Polygon) TileLayout(t *Tiles) (*TileLayout, error) {
+	if err := t.check(); err != nil {
+		return nil, err
+	}
+	if pol.Len() < 3 {
+		return nil, fmt.Errorf("%w for tile layout (%d), must be at least 3", ErrNotEnoughPoints, pol.Len())
+	}
+	sin, cos := math.Sincos(t.Direction)
+	rotate := func(p *Point) *Point { return &Point{X: p.X*cos + p.Y*sin, Y: -p.X*sin + p.Y*cos} }
+	rotateBack := func(p *Point) *Point { return &Point{X: p.X*cos - p.Y*sin, Y: p.X*sin + p.Y*cos} }
+
+	// the outline in the grid coordinates, main runners go along X axis
+	outline, sides, crossSides := make([]*Point, 0), make([]*Segment, 0), make([]*Segment, 0)
+	uMin, uMax, vMin, vMax := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for i := range pol.Points {
+		for _, s := range pol.sidePath(i) {
+			rs := &Segment{A: rotate(s.A), B: rotate(s.B)}
+			outline, sides = append(outline, rs.A), append(sides, rs)
+			crossSides = append(crossSides, &Segment{A: &Point{X: rs.A.Y, Y: rs.A.X}, B: &Point{X: rs.B.Y, Y: rs.B.X}})
+			uMin, uMax = math.Min(uMin, rs.A.X), math.Max(uMax, rs.A.X)
+			vMin, vMax = math.Min(vMin, rs.A.Y), math.Max(vMax, rs.A.Y)
+		}
+	}
+	origin := rotate(&Point{X: t.OriginX, Y: t.OriginY})
+	// the tolerance excludes slivers of cells produced by rounding errors
+	i0, i1 := int(math.Floor((uMin-origin.X)/t.Length+tolerance)), int(math.Ceil((uMax-origin.X)/t.Length-tolerance))
+	j0, j1 := int(math.Floor((vMin-origin.Y)/t.Width+tolerance)), int(math.Ceil((vMax-origin.Y)/t.Width-tolerance))
+	if (i1-i0)*(j1-j0) > maxTilesCells {
+		return nil, fmt.Errorf("%w: the grid has more than %d tiles", ErrTooMuchPoints, maxTilesCells)
+	}
+
+	layout, tileArea := &TileLayout{Cuts: make([]*TileCut, 0)}, t.Width*t.Length
+	for j := j0; j < j1; j++ {
+		for i := i0; i < i1; i++ {
+			u, v := origin.X+float64(i)*t.Length, origin.Y+float64(j)*t.Width
+			part := clipByRect(outline, u, v, u+t.Length, v+t.Width)
+			area := math.Abs((&Polygon{Points: part}).signedArea())
+			switch {
+			case area >= tileArea*(1-tolerance):
+				layout.FullTiles++
+			case area > tileArea*tolerance:
+				layout.CutTiles++
+				cut := &TileCut{Points: make([]*Point, len(part)), Area: area}
+				for k, p := range part {
+					cut.Points[k] = rotateBack(p)
+				}
+				layout.Cuts = append(layout.Cuts, cut)
+			}
+		}
+	}
+	for j := j0 + 1; j < j1; j++ {
+		if v := origin.Y + float64(j)*t.Width; v > vMin+tolerance && v < vMax-tolerance {
+			for _, s := range chords(sides, v) {
+				layout.MainRunners += s.Distance()
+				layout.Runners = append(layout.Runners, &Segment{A: rotateBack(s.A), B: rotateBack(s.B)})
+			}
+		}
+	}
+	for i := i0 + 1; i < i1; i++ {
+		if u := origin.X + float64(i)*t.Length; u > uMin+tolerance && u < uMax-tolerance {
+			for _, s := range chords(crossSides, u) {
+				layout.CrossTees += s.Distance()
+				layout.Tees = append(layout.Tees, &Segment{
+					A: rotateBack(&Point{X: s.A.Y, Y: s.A.X}),
+					B: rotateBack(&Point{X: s.B.Y, Y: s.B.X}),
+				})
+			}
+		}
+	}
+	return layout, nil
+}
+
+// clipByRect returns the part of the polygon by points inside of the rectangle from (x0;y0) to (x1;y1)
+// by Sutherland-Hodgman algorithm.
+func clipByRect(points []*Point, x0, y0, x1, y1 float64) []*Point {
+	edges := []struct {
+		inside func(p *Point) bool
+		cross  func(a, b *Point) *Point
+	}{
+		{func(p *Point) bool { return p.X >= x0 }, func(a, b *Point) *Point { return crossX(a, b, x0) }},
+		{func(p *Point) bool { return p.X <= x1 }, func(a, b *Point) *Point { return crossX(a, b, x1) }},
+		{func(p *Point) bool { return p.Y >= y0 }, func(a, b *Point) *Point { return crossY(a, b, y0) }},
+		{func(p *Point) bool { return p.Y <= y1 }, func(a, b *Point) *Point { return crossY(a, b, y1) }},
+	}
+	out := points
+	for _, e := range edges {
+		in := out
+		out = make([]*Point, 0, len(in)+1)
+		for k, b := range in {
+			a := in[(k+len(in)-1)%len(in)]
+			switch {
+			case e.inside(b) && !e.inside(a):
+				out = append(out, e.cross(a, b), b)
+			case e.inside(b):
+				out = append(out, b)
+			case e.inside(a):
+				out = append(out, e.cross(a, b))
+			}
+		}
+		if len(out) == 0 {
+			return out
+		}
+	}
+	return out
+}
+
+// crossX returns the point of the segment ab, where it crosses the vertical line x.
+func crossX(a, b *Point, x float64) *Point {
+	return &Point{X: x, Y: a.Y + (b.Y-a.Y)*(x-a.X)/(b.X-a.X)}
+}
+
+// crossY returns the point of the segment ab, where it crosses the horizontal line y.
+func crossY(a, b *Point, y float64) *Point {
+	return &Point{X: a.X + (b.X-a.X)*(y-a.Y)/(b.Y-a.Y), Y: y}
+}
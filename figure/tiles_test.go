@@ -0,0 +1,105 @@
+package figure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPolygon_TileLayout(t *testing.T) {
+	rectangle := func(w, h float64) []*Point {
+		return []*Point{{X: 0, Y: 0}, {X: 0, Y: h}, {X: w, Y: h}, {X: w, Y: 0}}
+	}
+	tests := []struct {
+		name                  string
+		points                []*Point
+		tiles                 Tiles
+		wantFull, wantCut     int
+		wantRunners, wantTees float64
+		wantCutArea           float64
+		wantErr               error
+	}{
+		{
+			name:        "Full tiles only",
+			points:      rectangle(3, 1.8),
+			tiles:       Tiles{Width: 0.6, Length: 0.6},
+			wantFull:    15,
+			wantRunners: 6,
+			wantTees:    7.2,
+		},
+		{
+			name:        "Cut tiles",
+			points:      rectangle(2, 1),
+			tiles:       Tiles{Width: 0.6, Length: 0.6},
+			wantFull:    3,
+			wantCut:     5,
+			wantRunners: 2,
+			wantTees:    3,
+			wantCutArea: 2 - 3*0.36,
+		},
+		{
+			name:        "Rotated 600x1200",
+			points:      rectangle(3, 1.8),
+			tiles:       Tiles{Width: 0.6, Length: 1.2, Direction: math.Pi / 2},
+			wantFull:    5,
+			wantCut:     5,
+			wantRunners: 7.2,
+			wantTees:    3,
+			wantCutArea: 5 * 0.6 * 0.6,
+		},
+		{
+			name:        "Shifted origin",
+			points:      rectangle(1.2, 1.2),
+			tiles:       Tiles{Width: 0.6, Length: 0.6, OriginX: 0.3, OriginY: 0.3},
+			wantFull:    1,
+			wantCut:     8,
+			wantRunners: 2.4,
+			wantTees:    2.4,
+			wantCutArea: 1.44 - 0.36,
+		},
+		{
+			name:        "L-shaped",
+			points:      []*Point{{X: 0, Y: 0}, {X: 0, Y: 1.2}, {X: 0.6, Y: 1.2}, {X: 0.6, Y: 0.6}, {X: 1.2, Y: 0.6}, {X: 1.2, Y: 0}},
+			tiles:       Tiles{Width: 0.6, Length: 0.6},
+			wantFull:    3,
+			wantRunners: 0.6,
+			wantTees:    0.6,
+		},
+		{
+			name:    "Wrong size",
+			points:  rectangle(2, 1),
+			tiles:   Tiles{Width: 0, Length: 0.6},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Not enough points",
+			points:  rectangle(2, 1)[:2],
+			tiles:   Tiles{Width: 0.6, Length: 0.6},
+			wantErr: ErrNotEnoughPoints,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPolygon(tt.points...).TileLayout(&tt.tiles)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TileLayout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.FullTiles != tt.wantFull || got.CutTiles != tt.wantCut || len(got.Cuts) != tt.wantCut {
+				t.Errorf("TileLayout() got %d full and %d cut tiles, want %d and %d", got.FullTiles, got.CutTiles, tt.wantFull, tt.wantCut)
+			}
+			if !compareFloats(got.MainRunners, tt.wantRunners, 1e-9) || !compareFloats(got.CrossTees, tt.wantTees, 1e-9) {
+				t.Errorf("TileLayout() runners = %v, tees = %v, want %v and %v", got.MainRunners, got.CrossTees, tt.wantRunners, tt.wantTees)
+			}
+			cutArea := 0.0
+			for _, c := range got.Cuts {
+				cutArea += c.Area
+			}
+			if !compareFloats(cutArea, tt.wantCutArea, 1e-9) {
+				t.Errorf("TileLayout() area of cuts = %v, want %v", cutArea, tt.wantCutArea)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// Transform is an affine transformation about the pivot point (PivotX;PivotY). Points are mirrored across the line
+// going through the pivot in the MirrorAxis direction if Mirror is true, then scaled by Scale, rotated by Angle
+// and moved by DX and DY. Angles are in radians, zero Scale is taken as 1.
+type Transform struct {
+	PivotX     float64 `json:"pivot_x"`
+	PivotY     float64 `json:"pivot_y"`
+	Mirror     bool    `json:"mirror,omitempty"`
+	MirrorAxis float64 `json:"mirror_axis,omitempty"`
+	Scale      float64 `json:"scale,omitempty"`
+	Angle      float64 `json:"angle,omitempty"`
+	DX         float64 `json:"dx,omitempty"`
+	DY         float64 `json:"dy,omitempty"`
+}
+
+func (t *Transform) ConvertToOne(measures *value.FigureMeasures) {
+	t.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) })
+	t.MirrorAxis = value.ConvertToOne(measures.Angle, t.MirrorAxis)
+	t.Angle = value.ConvertToOne(measures.Angle, t.Angle)
+}
+
+func (t *Transform) ConvertFromOne(measures *value.FigureMeasures) {
+	t.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) })
+	t.MirrorAxis = value.ConvertFromOne(measures.Angle, t.MirrorAxis)
+	t.Angle = value.ConvertFromOne(measures.Angle, t.Angle)
+}
+
+func (t *Transform) convert(length func(float64) float64) {
+	t.PivotX, t.PivotY = length(t.PivotX), length(t.PivotY)
+	t.DX, t.DY = length(t.DX), length(t.DY)
+}
+
+// check sets the scale to 1 if it's zero and returns an error if the scale is negative.
+func (t *Transform) check() error {
+	if t.Scale == 0 {
+		t.Scale = 1
+	}
+	if t.Scale < 0 {
+		return fmt.Errorf("%w: scale must be positive, got %v, use mirroring for flipping", ErrWrongMeasurement, t.Scale)
+	}
+	return nil
+}
+
+// Point returns the transformed copy of the point p without the calculator and the arc.
+func (t *Transform) Point(p *Point) *Point {
+	x, y := p.X-t.PivotX, p.Y-t.PivotY
+	if t.Mirror {
+		sin, cos := math.Sincos(2 * t.MirrorAxis)
+		x, y = x*cos+y*sin, x*sin-y*cos
+	}
+	x, y = x*t.scale(), y*t.scale()
+	sin, cos := math.Sincos(t.Angle)
+	x, y = x*cos-y*sin, x*sin+y*cos
+	return &Point{X: t.PivotX + t.DX + x, Y: t.PivotY + t.DY + y}
+}
+
+// Direction returns the transformed direction a in radians.
+func (t *Transform) Direction(a float64) float64 {
+	if t.Mirror {
+		a = 2*t.MirrorAxis - a
+	}
+	return normalizeAngle(a + t.Angle)
+}
+
+// Length returns the transformed length v.
+func (t *Transform) Length(v float64) float64 {
+	return v * t.scale()
+}
+
+// Arc returns the transformed copy of the arc. The mirroring changes the side, which the arc bulges to.
+func (t *Transform) Arc(a *Arc) *Arc {
+	return &Arc{Sagitta: t.Length(a.Sagitta), Radius: t.Length(a.Radius), Right: a.Right != t.Mirror}
+}
+
+// scale returns the scale of the transformation, zero is taken as 1.
+func (t *Transform) scale() float64 {
+	if t.Scale == 0 {
+		return 1
+	}
+	return t.Scale
+}
+
+// Transform applies the transformation to points and arcs of the polygon. Lengths of diagonals are scaled and
+// calculators of points are rebased to new coordinates. The mirroring keeps the order of points, so it changes
+// the orientation of the polygon.
+func (pol *Polygon) Transform(t *Transform) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	t.points(pol.Points)
+	for _, d := range pol.Diagonals {
+		d.Length = t.Length(d.Length)
+	}
+	for i, p := range pol.Points {
+		if p.Calculator == nil {
+			continue
+		}
+		if err := pol.rebasePoint(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// points transforms coordinates and arcs of points in place.
+func (t *Transform) points(points []*Point) {
+	for _, p := range points {
+		np := t.Point(p)
+		p.X, p.Y = np.X, np.Y
+		if p.Arc != nil {
+			p.Arc = t.Arc(p.Arc)
+		}
+	}
+}
+
+// Transform applies the transformation to the outline or the circle of the hole.
+func (h *Hole) Transform(t *Transform) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	t.points(h.Points)
+	if h.Center != nil {
+		h.Center = t.Point(h.Center)
+		h.Radius = t.Length(h.Radius)
+	}
+	return nil
+}
+
+// Transform applies the transformation to the outline of the level. The drop isn't changed.
+func (l *Level) Transform(t *Transform) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	t.points(l.Points)
+	return nil
+}
+
+// Transform applies the transformation to the position, the diameter and offsets from walls of the fixture.
+// Walls keep their indexes, so offsets are only scaled.
+func (f *Fixture) Transform(t *Transform) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	c := t.Point(f.Center())
+	f.X, f.Y, f.Diameter = c.X, c.Y, t.Length(f.Diameter)
+	for _, o := range f.Offsets {
+		o.Distance = t.Length(o.Distance)
+	}
+	return nil
+}
+
+// Transform applies the transformation to the misclosure vector and lengths. The mirroring changes the sign
+// of the angular misclosure, the ratio isn't changed.
+func (c *Closure) Transform(t *Transform) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	start, end := t.Point(&Point{X: t.PivotX, Y: t.PivotY}), t.Point(&Point{X: t.PivotX + c.DX, Y: t.PivotY + c.DY})
+	c.DX, c.DY = end.X-start.X, end.Y-start.Y
+	c.Linear, c.Length = t.Length(c.Linear), t.Length(c.Length)
+	if t.Mirror {
+		c.Angular = -c.Angular
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package figure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPolygon_Transform(t *testing.T) {
+	rectangle := func() []*Point {
+		return []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}}
+	}
+	tests := []struct {
+		name       string
+		points     []*Point
+		transform  Transform
+		wantPoints []*Point
+		wantRight  bool
+		wantErr    error
+	}{
+		{
+			name:       "Translation",
+			points:     rectangle(),
+			transform:  Transform{DX: 1, DY: -1},
+			wantPoints: []*Point{{X: 1, Y: -1}, {X: 1, Y: 1}, {X: 5, Y: 1}, {X: 5, Y: -1}},
+		},
+		{
+			name:       "Mirror across the vertical line",
+			points:     rectangle(),
+			transform:  Transform{PivotX: 1, Mirror: true, MirrorAxis: math.Pi / 2},
+			wantPoints: []*Point{{X: 2, Y: 0}, {X: 2, Y: 2}, {X: -2, Y: 2}, {X: -2, Y: 0}},
+		},
+		{
+			name:       "Scale about the pivot",
+			points:     rectangle(),
+			transform:  Transform{PivotX: 2, PivotY: 1, Scale: 2},
+			wantPoints: []*Point{{X: -2, Y: -1}, {X: -2, Y: 3}, {X: 6, Y: 3}, {X: 6, Y: -1}},
+		},
+		{
+			name:       "Rotation about the corner",
+			points:     rectangle(),
+			transform:  Transform{PivotX: 4, Angle: math.Pi / 2},
+			wantPoints: []*Point{{X: 4, Y: -4}, {X: 2, Y: -4}, {X: 2, Y: 0}, {X: 4, Y: 0}},
+		},
+		{
+			name: "Mirrored arc",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2},
+				{X: 4, Y: 0, Arc: &Arc{Sagitta: 0.5, Right: true}}},
+			transform:  Transform{Mirror: true, Scale: 2},
+			wantPoints: []*Point{{X: 0, Y: 0}, {X: 0, Y: -4}, {X: 8, Y: -4}, {X: 8, Y: 0}},
+			wantRight:  false,
+		},
+		{
+			name:      "Negative scale",
+			points:    rectangle(),
+			transform: Transform{Scale: -1},
+			wantErr:   ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := NewPolygon(tt.points...)
+			area := pol.Area()
+			err := pol.Transform(&tt.transform)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			for i, p := range pol.Points {
+				if !compareFloats(p.X, tt.wantPoints[i].X, 1e-9) || !compareFloats(p.Y, tt.wantPoints[i].Y, 1e-9) {
+					t.Errorf("Transform() point %d = (%v;%v), want (%v;%v)", i, p.X, p.Y, tt.wantPoints[i].X, tt.wantPoints[i].Y)
+				}
+			}
+			if want := area * tt.transform.Scale * tt.transform.Scale; !compareFloats(pol.Area(), want, 1e-9) {
+				t.Errorf("Transform() area = %v, want %v", pol.Area(), want)
+			}
+			if last := pol.Points[pol.Len()-1]; last.Arc != nil && last.Arc.Right != tt.wantRight {
+				t.Errorf("Transform() arc is right = %v, want %v", last.Arc.Right, tt.wantRight)
+			}
+		})
+	}
+}
+
+func TestPolygon_TransformCalculators(t *testing.T) {
+	pol := NewPolygon(NewPoint(0, 0), NewCalculatedPoint(&DirectionCalculator{Direction: math.Pi / 2, Distance: 3}),
+		NewPoint(4, 3), NewPoint(4, 0))
+	if err := pol.Rotation(&Point{X: 1, Y: 1}, math.Pi/2); err != nil {
+		t.Fatalf("Rotation() error = %v", err)
+	}
+	calculator := pol.Points[1].Calculator.(*DirectionCalculator)
+	if !compareFloats(calculator.Direction, math.Pi, 1e-9) || !compareFloats(calculator.Distance, 3, 1e-9) {
+		t.Errorf("Rotation() calculator = %+v, want direction %v and distance 3", calculator, math.Pi)
+	}
+	if err := pol.CalculatePoints(); err != nil {
+		t.Fatalf("CalculatePoints() error = %v", err)
+	}
+	if p := pol.Points[1]; !compareFloats(p.X, -1, 1e-9) || !compareFloats(p.Y, 0, 1e-9) {
+		t.Errorf("CalculatePoints() after rotation = (%v;%v), want (-1;0)", p.X, p.Y)
+	}
+}
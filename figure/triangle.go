@@ -1,17 +1,125 @@
 package figure
 
+import (
+	"fmt"
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
 type Triangle struct {
 	*Polygon
 }
 
+// TriangleSolution is the solved triangle ABC. The side i is opposite to the vertex i with the angle i
+// (a is BC, b is CA and c is AB), angles are in radians.
+type TriangleSolution struct {
+	Sides  [3]float64 `json:"sides"`
+	Angles [3]float64 `json:"angles"`
+	Area   float64    `json:"area"`
+}
+
+func (s *TriangleSolution) ConvertToOne(measures *value.FigureMeasures) {
+	for i := range s.Sides {
+		s.Sides[i] = value.ConvertToOne(measures.Length, s.Sides[i])
+		s.Angles[i] = value.ConvertToOne(measures.Angle, s.Angles[i])
+	}
+	s.Area = value.ConvertToOne(measures.Area, s.Area)
+}
+
+func (s *TriangleSolution) ConvertFromOne(measures *value.FigureMeasures) {
+	for i := range s.Sides {
+		s.Sides[i] = value.ConvertFromOne(measures.Length, s.Sides[i])
+		s.Angles[i] = value.ConvertFromOne(measures.Angle, s.Angles[i])
+	}
+	s.Area = value.ConvertFromOne(measures.Area, s.Area)
+}
+
 func NewTriangle(points ...*Point) (*Triangle, error) {
-	t := Triangle{&Polygon{[]*Point{}}}
+	t := Triangle{&Polygon{Points: []*Point{}}}
 	if err := t.AddPoints(points...); err != nil {
 		return nil, err
 	}
 	return &t, nil
 }
 
+// NewTriangleSSS returns the triangle ABC by its sides a (BC), b (CA) and c (AB). A is at (0;0), B lies up
+// the Y axis and C lies to the right of AB, so points go clockwise.
+func NewTriangleSSS(a, b, c float64) (*Triangle, error) {
+	if a <= 0 || b <= 0 || c <= 0 {
+		return nil, fmt.Errorf("%w: sides must be positive", ErrImpossibleTriangle)
+	}
+	if longest := math.Max(a, math.Max(b, c)); longest >= a+b+c-longest-tolerance {
+		return nil, fmt.Errorf("%w: side %v isn't shorter than the sum of other sides", ErrImpossibleTriangle, longest)
+	}
+	alpha := math.Acos(math.Max(-1, math.Min(1, (b*b+c*c-a*a)/(2*b*c))))
+	sin, cos := math.Sincos(alpha)
+	return NewTriangle(&Point{X: 0, Y: 0}, &Point{X: 0, Y: c}, &Point{X: b * sin, Y: b * cos})
+}
+
+// NewTriangleSAS returns the triangle ABC by sides a (BC), b (CA) and the angle gamma at C between them in radians.
+func NewTriangleSAS(a, b, gamma float64) (*Triangle, error) {
+	if gamma <= 0 || gamma >= math.Pi {
+		return nil, fmt.Errorf("%w: angle %v must be between 0 and Pi", ErrImpossibleTriangle, gamma)
+	}
+	return NewTriangleSSS(a, b, math.Sqrt(math.Max(0, a*a+b*b-2*a*b*math.Cos(gamma))))
+}
+
+// NewTriangleASA returns the triangle ABC by the angle alpha at A, the side c (AB) and the angle beta at B
+// in radians.
+func NewTriangleASA(alpha, c, beta float64) (*Triangle, error) {
+	gamma := math.Pi - alpha - beta
+	if alpha <= 0 || beta <= 0 || gamma <= tolerance {
+		return nil, fmt.Errorf("%w: angles must be positive and their sum must be less than Pi", ErrImpossibleTriangle)
+	}
+	return NewTriangleSSS(c*math.Sin(alpha)/math.Sin(gamma), c*math.Sin(beta)/math.Sin(gamma), c)
+}
+
+// SolveTriangle returns the triangle ABC by known sides (a, b, c) and angles (A, B, C) in radians, where unknown
+// values are zero. The triangle is solved by three sides, two sides and the angle between them or two angles
+// and any side. Other values must be unknown, so the triangle isn't overdetermined. Two sides and the angle
+// not between them can give two triangles, so they aren't solved.
+func SolveTriangle(sides, angles [3]float64) (*Triangle, error) {
+	knownSides, knownAngles := make([]int, 0, 3), make([]int, 0, 3)
+	for i := range sides {
+		if sides[i] < 0 || angles[i] < 0 {
+			return nil, fmt.Errorf("%w: sides and angles must be positive", ErrImpossibleTriangle)
+		}
+		if sides[i] > 0 {
+			knownSides = append(knownSides, i)
+		}
+		if angles[i] > 0 {
+			knownAngles = append(knownAngles, i)
+		}
+	}
+	switch {
+	case len(knownSides) == 3 && len(knownAngles) == 0:
+		return NewTriangleSSS(sides[0], sides[1], sides[2])
+	case len(knownSides) == 2 && len(knownAngles) == 1 && sides[knownAngles[0]] == 0:
+		k := knownAngles[0]
+		a, b := sides[(k+1)%3], sides[(k+2)%3]
+		if angles[k] >= math.Pi {
+			return nil, fmt.Errorf("%w: angle %v must be less than Pi", ErrImpossibleTriangle, angles[k])
+		}
+		sides[k] = math.Sqrt(math.Max(0, a*a+b*b-2*a*b*math.Cos(angles[k])))
+		return NewTriangleSSS(sides[0], sides[1], sides[2])
+	case len(knownSides) == 1 && len(knownAngles) == 2:
+		unknown := 3 - knownAngles[0] - knownAngles[1]
+		angles[unknown] = math.Pi - angles[knownAngles[0]] - angles[knownAngles[1]]
+		if angles[unknown] <= tolerance {
+			return nil, fmt.Errorf("%w: the sum of angles must be less than Pi", ErrImpossibleTriangle)
+		}
+		// the law of sines
+		ratio := sides[knownSides[0]] / math.Sin(angles[knownSides[0]])
+		for i := range sides {
+			sides[i] = ratio * math.Sin(angles[i])
+		}
+		return NewTriangleSSS(sides[0], sides[1], sides[2])
+	}
+	return nil, fmt.Errorf("%w: three sides, two sides and the angle between them or two angles and a side are needed",
+		ErrWrongMeasurement)
+}
+
 func (t *Triangle) AddPoints(points ...*Point) error {
 	if t.Len()+len(points) > 3 {
 		return ErrTooMuchPoints
@@ -25,3 +133,22 @@ func (t *Triangle) AddPointByDirection(d float64, a float64) error {
 	}
 	return t.Polygon.AddPointByDirection(d, a)
 }
+
+// Solution returns sides, angles and the area of the triangle ABC by its points.
+func (t *Triangle) Solution() (*TriangleSolution, error) {
+	if t.Len() != 3 {
+		return nil, fmt.Errorf("%w for the triangle (%d), must be 3", ErrNotEnoughPoints, t.Len())
+	}
+	s := &TriangleSolution{Area: t.Area()}
+	for i := range s.Sides {
+		s.Sides[i] = (&Segment{A: t.Points[(i+1)%3], B: t.Points[(i+2)%3]}).Distance()
+	}
+	for i := range s.Angles {
+		a, b, c := s.Sides[i], s.Sides[(i+1)%3], s.Sides[(i+2)%3]
+		if b == 0 || c == 0 {
+			continue
+		}
+		s.Angles[i] = math.Acos(math.Max(-1, math.Min(1, (b*b+c*c-a*a)/(2*b*c))))
+	}
+	return s, nil
+}
@@ -1 +1,93 @@
 package figure
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSolveTriangle(t *testing.T) {
+	right := &TriangleSolution{Sides: [3]float64{3, 4, 5}, Angles: [3]float64{math.Asin(0.6), math.Asin(0.8), math.Pi / 2}, Area: 6}
+	tests := []struct {
+		name    string
+		sides   [3]float64
+		angles  [3]float64
+		want    *TriangleSolution
+		wantErr error
+	}{
+		{
+			name:  "SSS",
+			sides: [3]float64{3, 4, 5},
+			want:  right,
+		},
+		{
+			name:   "SAS",
+			sides:  [3]float64{3, 4, 0},
+			angles: [3]float64{0, 0, math.Pi / 2},
+			want:   right,
+		},
+		{
+			name:   "ASA",
+			sides:  [3]float64{0, 0, 5},
+			angles: [3]float64{math.Asin(0.6), math.Asin(0.8), 0},
+			want:   right,
+		},
+		{
+			name:   "AAS",
+			sides:  [3]float64{3, 0, 0},
+			angles: [3]float64{0, math.Asin(0.8), math.Pi / 2},
+			want:   right,
+		},
+		{
+			name:    "Too long side",
+			sides:   [3]float64{1, 2, 3},
+			wantErr: ErrImpossibleTriangle,
+		},
+		{
+			name:    "Too large angles",
+			sides:   [3]float64{0, 0, 5},
+			angles:  [3]float64{math.Pi / 2, math.Pi / 2, 0},
+			wantErr: ErrImpossibleTriangle,
+		},
+		{
+			name:    "SSA",
+			sides:   [3]float64{3, 4, 0},
+			angles:  [3]float64{math.Asin(0.6), 0, 0},
+			wantErr: ErrWrongMeasurement,
+		},
+		{
+			name:    "Overdetermined",
+			sides:   [3]float64{3, 4, 5},
+			angles:  [3]float64{0, 0, math.Pi / 2},
+			wantErr: ErrWrongMeasurement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := SolveTriangle(tt.sides, tt.angles)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SolveTriangle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if tr.signedArea() > 0 {
+				t.Errorf("SolveTriangle() points go counterclockwise")
+			}
+			got, err := tr.Solution()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range got.Sides {
+				if !compareFloats(got.Sides[i], tt.want.Sides[i], 1e-9) || !compareFloats(got.Angles[i], tt.want.Angles[i], 1e-9) {
+					t.Errorf("Solution() = %+v, want %+v", got, tt.want)
+					break
+				}
+			}
+			if !compareFloats(got.Area, tt.want.Area, 1e-9) {
+				t.Errorf("Solution() area = %v, want %v", got.Area, tt.want.Area)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maxsid/goCeilings/value"
+)
+
+// TriangleReport is a triangle of the triangulation by indexes of Vertices of the polygon, lengths of its Sides
+// (the side i goes from the vertex i to the next one) and the Area by Heron's formula, so it can be checked by hand.
+type TriangleReport struct {
+	Vertices [3]int     `json:"vertices"`
+	Sides    [3]float64 `json:"sides"`
+	Area     float64    `json:"area"`
+}
+
+// TriangulationReport is triangles of the polygon and their total Area, which is the area of the polygon
+// without segments of curved sides.
+type TriangulationReport struct {
+	Triangles []*TriangleReport `json:"triangles"`
+	Area      float64           `json:"area"`
+}
+
+func (r *TriangulationReport) ConvertToOne(measures *value.FigureMeasures) {
+	r.convert(func(v float64) float64 { return value.ConvertToOne(measures.Length, v) },
+		func(v float64) float64 { return value.ConvertToOne(measures.Area, v) })
+}
+
+func (r *TriangulationReport) ConvertFromOne(measures *value.FigureMeasures) {
+	r.convert(func(v float64) float64 { return value.ConvertFromOne(measures.Length, v) },
+		func(v float64) float64 { return value.ConvertFromOne(measures.Area, v) })
+}
+
+func (r *TriangulationReport) convert(length, area func(float64) float64) {
+	for _, t := range r.Triangles {
+		for i := range t.Sides {
+			t.Sides[i] = length(t.Sides[i])
+		}
+		t.Area = area(t.Area)
+	}
+	r.Area = area(r.Area)
+}
+
+// Triangulation returns triangles of the polygon by indexes of their vertices in the order of the polygon.
+// Triangles are cut by the ear clipping, the ear with the largest minimal angle is cut first to avoid thin
+// triangles. Curved sides are taken as chords.
+func (pol *Polygon) Triangulation() ([][3]int, error) {
+	if pol.Len() < 3 {
+		return nil, fmt.Errorf("%w for triangulation (%d), must be at least 3", ErrNotEnoughPoints, pol.Len())
+	}
+	// the ear clipping goes counterclockwise
+	orientation := math.Copysign(1, pol.signedArea())
+	left := make([]int, 0, pol.Len())
+	for i := range pol.Points {
+		if k := len(left); k == 0 || !pol.pointsEqual(left[k-1], i) {
+			left = append(left, i)
+		}
+	}
+	if len(left) > 1 && pol.pointsEqual(left[0], left[len(left)-1]) {
+		left = left[:len(left)-1]
+	}
+	if len(left) < 3 {
+		return nil, fmt.Errorf("%w for triangulation (%d), must be at least 3", ErrNotEnoughPoints, len(left))
+	}
+	if orientation < 0 {
+		for i, j := 0, len(left)-1; i < j; i, j = i+1, j-1 {
+			left[i], left[j] = left[j], left[i]
+		}
+	}
+
+	triangles := make([][3]int, 0, pol.Len()-2)
+	for len(left) > 3 {
+		ear, best := -1, -1.0
+		for k := range left {
+			a, b, c := pol.earVertices(left, k)
+			if crossProduct(a, b, c) <= tolerance || !pol.isEar(left, k) {
+				continue
+			}
+			if m := minAngle(a, b, c); m > best {
+				ear, best = k, m
+			}
+		}
+		if ear < 0 {
+			// only vertices lying on straight sides can be left without ears, they don't make triangles
+			for k := range left {
+				if a, b, c := pol.earVertices(left, k); math.Abs(crossProduct(a, b, c)) <= tolerance {
+					ear = k
+					break
+				}
+			}
+			if ear < 0 {
+				return nil, fmt.Errorf("%w: polygon can't be triangulated", ErrInvalidPolygon)
+			}
+			left = append(left[:ear], left[ear+1:]...)
+			continue
+		}
+		m := len(left)
+		triangles = append(triangles, orderedTriangle(left[(ear+m-1)%m], left[ear], left[(ear+1)%m], orientation))
+		left = append(left[:ear], left[ear+1:]...)
+	}
+	if a, b, c := pol.earVertices(left, 1); math.Abs(crossProduct(a, b, c)) > tolerance {
+		triangles = append(triangles, orderedTriangle(left[0], left[1], left[2], orientation))
+	}
+	return triangles, nil
+}
+
+// earVertices returns the vertex by k among left ones and its neighbours.
+func (pol *Polygon) earVertices(left []int, k int) (prev, p, next *Point) {
+	m := len(left)
+	return pol.Points[left[(k+m-1)%m]], pol.Points[left[k]], pol.Points[left[(k+1)%m]]
+}
+
+// isEar returns true if no other vertex among left ones lies inside of the triangle of the vertex by k and its
+// neighbours or on its sides.
+func (pol *Polygon) isEar(left []int, k int) bool {
+	m := len(left)
+	vertices := [3]int{left[(k+m-1)%m], left[k], left[(k+1)%m]}
+	a, b, c := pol.Points[vertices[0]], pol.Points[vertices[1]], pol.Points[vertices[2]]
+	for _, i := range left {
+		if pol.pointsEqual(i, vertices[0]) || pol.pointsEqual(i, vertices[1]) || pol.pointsEqual(i, vertices[2]) {
+			continue
+		}
+		p := pol.Points[i]
+		if crossProduct(a, b, p) >= -tolerance && crossProduct(b, c, p) >= -tolerance && crossProduct(c, a, p) >= -tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// orderedTriangle returns indexes of the triangle in the order of the polygon with the orientation.
+func orderedTriangle(a, b, c int, orientation float64) [3]int {
+	if orientation < 0 {
+		return [3]int{c, b, a}
+	}
+	return [3]int{a, b, c}
+}
+
+// minAngle returns the smallest angle of the triangle.
+func minAngle(a, b, c *Point) float64 {
+	angle := func(p, q, r *Point) float64 {
+		return math.Abs(normalizeAngle(pointDirection(p, q) - pointDirection(p, r)))
+	}
+	return math.Min(angle(a, b, c), math.Min(angle(b, c, a), angle(c, a, b)))
+}
+
+// Triangulate returns triangles of the polygon (look at Triangulation), their points are copies of points
+// of the polygon without arcs and calculators.
+func (pol *Polygon) Triangulate() ([]*Triangle, error) {
+	indexes, err := pol.Triangulation()
+	if err != nil {
+		return nil, err
+	}
+	triangles := make([]*Triangle, len(indexes))
+	for i, t := range indexes {
+		points := make([]*Point, 3)
+		for k, v := range t {
+			points[k] = &Point{X: pol.Points[v].X, Y: pol.Points[v].Y}
+		}
+		if triangles[i], err = NewTriangle(points...); err != nil {
+			return nil, err
+		}
+	}
+	return triangles, nil
+}
+
+// TriangulationReport returns the report of triangles of the polygon (look at Triangulation) for checking the area
+// by hand.
+func (pol *Polygon) TriangulationReport() (*TriangulationReport, error) {
+	indexes, err := pol.Triangulation()
+	if err != nil {
+		return nil, err
+	}
+	report := &TriangulationReport{Triangles: make([]*TriangleReport, len(indexes))}
+	for i, t := range indexes {
+		tr := &TriangleReport{Vertices: t}
+		for k := range t {
+			tr.Sides[k] = (&Segment{A: pol.Points[t[k]], B: pol.Points[t[(k+1)%3]]}).Distance()
+		}
+		s := (tr.Sides[0] + tr.Sides[1] + tr.Sides[2]) / 2
+		tr.Area = math.Sqrt(math.Max(0, s*(s-tr.Sides[0])*(s-tr.Sides[1])*(s-tr.Sides[2])))
+		report.Triangles[i], report.Area = tr, report.Area+tr.Area
+	}
+	return report, nil
+}
@@ -0,0 +1,73 @@
+package figure
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolygon_TriangulationReport(t *testing.T) {
+	tests := []struct {
+		name          string
+		points        []*Point
+		wantTriangles int
+		wantArea      float64
+		wantErr       error
+	}{
+		{
+			name:          "Rectangle",
+			points:        []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 4, Y: 3}, {X: 4, Y: 0}},
+			wantTriangles: 2,
+			wantArea:      12,
+		},
+		{
+			name: "Concave polygon",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 1, Y: 3}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 3},
+				{X: 3, Y: 3}, {X: 3, Y: 0}},
+			wantTriangles: 6,
+			wantArea:      7,
+		},
+		{
+			name:          "Counterclockwise with the point on the side",
+			points:        []*Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 2}, {X: 0, Y: 2}},
+			wantTriangles: 3,
+			wantArea:      8,
+		},
+		{
+			name:          "Repeated point",
+			points:        []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 0, Y: 2}, {X: 2, Y: 0}},
+			wantTriangles: 1,
+			wantArea:      2,
+		},
+		{
+			name:    "Not enough points",
+			points:  []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}},
+			wantErr: ErrNotEnoughPoints,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := NewPolygon(tt.points...)
+			got, err := pol.TriangulationReport()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TriangulationReport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(got.Triangles) != tt.wantTriangles || !compareFloats(got.Area, tt.wantArea, 1e-9) {
+				t.Errorf("TriangulationReport() got %d triangles with the area %v, want %d and %v",
+					len(got.Triangles), got.Area, tt.wantTriangles, tt.wantArea)
+			}
+			triangles, err := pol.Triangulate()
+			if err != nil {
+				t.Fatalf("Triangulate() error = %v", err)
+			}
+			for i, tr := range triangles {
+				if !compareFloats(tr.Area(), got.Triangles[i].Area, 1e-9) || (tr.signedArea() > 0) != (pol.signedArea() > 0) {
+					t.Errorf("Triangulate() triangle %d area = %v, want %v in the order of the polygon", i,
+						tr.Area(), got.Triangles[i].Area)
+				}
+			}
+		})
+	}
+}
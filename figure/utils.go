@@ -1,10 +1,30 @@
 package figure
 
 import (
+	"fmt"
 	"math"
 	"reflect"
 )
 
+// tolerance is a relative difference of coordinates, which are taken as equal.
+const tolerance = 1e-9
+
+// pointOnSegment returns true if the point p lies on the segment s.
+func pointOnSegment(p *Point, s *Segment) bool {
+	length := s.Distance()
+	if math.Abs(crossProduct(s.A, s.B, p)) > tolerance*math.Max(1, length*length) {
+		return false
+	}
+	return p.X >= math.Min(s.A.X, s.B.X)-tolerance && p.X <= math.Max(s.A.X, s.B.X)+tolerance &&
+		p.Y >= math.Min(s.A.Y, s.B.Y)-tolerance && p.Y <= math.Max(s.A.Y, s.B.Y)+tolerance
+}
+
+// crossProduct returns z component of the cross product of vectors from a to b and from a to p.
+// It's positive if p lies to the left of the direction from a to b.
+func crossProduct(a, b, p *Point) float64 {
+	return (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+}
+
 // pointDirection returns direction in radians from cp to p.
 func pointDirection(cp, p *Point) float64 {
 	x, y := p.X-cp.X, p.Y-cp.Y
@@ -15,6 +35,17 @@ func pointDirection(cp, p *Point) float64 {
 	return r
 }
 
+// normalizeAngle returns the angle in radians, reduced to the range (-pi; pi].
+func normalizeAngle(a float64) float64 {
+	a = math.Mod(a, math.Pi*2)
+	if a > math.Pi {
+		a -= math.Pi * 2
+	} else if a <= -math.Pi {
+		a += math.Pi * 2
+	}
+	return a
+}
+
 // sliceOfForwardElements returns slice of all forward elements of i.
 // The first element of the result slice is i+1 and the last is i-1 (i+1, i+2, ..., i-2, i-1).
 // For example, sliceOfForwardElements(3, []int{0,1,2,3,4,5,6}) returns []int{4,5,6,0,1,2}
@@ -27,3 +58,38 @@ func sliceOfForwardElements(i int, slice interface{}) (interface{}, error) {
 	startSlice := sliceOf.Slice(i+1, sliceOf.Len())
 	return reflect.AppendSlice(startSlice, endSlice).Interface(), nil
 }
+
+// solveLinearSystem solves the system of linear equations a*x = b by Gaussian elimination with partial pivoting.
+// Arguments a and b are changed during solving.
+func solveLinearSystem(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-15 {
+			return nil, fmt.Errorf("%w: singular matrix", ErrUnsolvable)
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for row := col + 1; row < n; row++ {
+			k := a[row][col] / a[col][col]
+			for c := col; c < n; c++ {
+				a[row][c] -= k * a[col][c]
+			}
+			b[row] -= k * b[col]
+		}
+	}
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for c := row + 1; c < n; c++ {
+			sum -= a[row][c] * x[c]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, nil
+}
@@ -97,6 +97,28 @@ func Test_sliceOfForwardElements(t *testing.T) {
 	}
 }
 
+func Test_normalizeAngle(t *testing.T) {
+	tests := []struct {
+		name string
+		a    float64
+		want float64
+	}{
+		{name: "Zero", a: 0, want: 0},
+		{name: "Positive", a: 270, want: -90},
+		{name: "Negative", a: -270, want: 90},
+		{name: "Full circles", a: 725, want: 5},
+		{name: "Pi", a: -180, want: 180},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertFromOne(Degree, normalizeAngle(ConvertToOne(Degree, tt.a)))
+			if !compareFloats(got, tt.want, 1e-9) {
+				t.Errorf("normalizeAngle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // comparePointsSlices compares all points in two slides with comparePoints function.
 // Accuracy is a maximum value of difference between coordinates.
 // If the difference more than accuracy, function returns false.
@@ -129,3 +151,47 @@ func compareFloats(a, b, accuracy float64) bool {
 	d := math.Abs(a - b)
 	return d <= accuracy
 }
+
+func Test_solveLinearSystem(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       [][]float64
+		b       []float64
+		want    []float64
+		wantErr bool
+	}{
+		{
+			name: "Simple",
+			a:    [][]float64{{2, 1}, {1, 3}},
+			b:    []float64{3, 5},
+			want: []float64{0.8, 1.4},
+		},
+		{
+			name: "Pivoting",
+			a:    [][]float64{{0, 1, 0}, {1, 0, 0}, {0, 0, 4}},
+			b:    []float64{2, 3, 8},
+			want: []float64{3, 2, 2},
+		},
+		{
+			name:    "Singular",
+			a:       [][]float64{{1, 2}, {2, 4}},
+			b:       []float64{1, 2},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := solveLinearSystem(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("solveLinearSystem() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for i := range tt.want {
+				if !compareFloats(got[i], tt.want[i], 1e-9) {
+					t.Errorf("solveLinearSystem() = %v, want %v", got, tt.want)
+					return
+				}
+			}
+		})
+	}
+}
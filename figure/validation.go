@@ -0,0 +1,226 @@
+package figure
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ProblemKind is a kind of the polygon problem found by the validation.
+type ProblemKind string
+
+const (
+	ProblemSelfIntersection ProblemKind = "self_intersection"
+	ProblemDuplicatePoints  ProblemKind = "duplicate_points"
+	ProblemZeroLengthSide   ProblemKind = "zero_length_side"
+	ProblemCollinearPoints  ProblemKind = "collinear_points"
+	ProblemOrientation      ProblemKind = "orientation"
+)
+
+// arcValidationSteps is a number of chords, which approximate a curved side during searching of intersections.
+const arcValidationSteps = 16
+
+// Problem is a defect of the polygon. Sides and Points contain indexes of the offending sides and points,
+// where side i goes from the point i to the point i+1. Warning problems don't make the polygon broken,
+// e.g. counterclockwise order of the points or a redundant point in the middle of the straight side.
+type Problem struct {
+	Kind    ProblemKind `json:"kind"`
+	Sides   []int       `json:"sides,omitempty"`
+	Points  []int       `json:"points,omitempty"`
+	Warning bool        `json:"warning,omitempty"`
+}
+
+func (p *Problem) Error() string {
+	s := string(p.Kind)
+	if len(p.Sides) > 0 {
+		s = fmt.Sprintf("%s of sides %v", s, p.Sides)
+	}
+	if len(p.Points) > 0 {
+		s = fmt.Sprintf("%s at points %v", s, p.Points)
+	}
+	return s
+}
+
+// ValidationError is an error of the broken polygon, which contains all found problems.
+type ValidationError struct {
+	Problems []*Problem
+}
+
+func (e *ValidationError) Error() string {
+	ss := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		ss[i] = p.Error()
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidPolygon, strings.Join(ss, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidPolygon
+}
+
+// Validate returns *ValidationError with all problems of the polygon if at least one of them isn't a warning.
+// Returns nil if the polygon is valid.
+func (pol *Polygon) Validate() error {
+	problems := pol.Problems()
+	for _, p := range problems {
+		if !p.Warning {
+			return &ValidationError{Problems: problems}
+		}
+	}
+	return nil
+}
+
+// Problems returns all problems of the polygon: zero length sides, duplicate points, self-intersections,
+// collinear points and counterclockwise orientation. The points of the polygon are expected in the clockwise order,
+// like the angle calculator builds them.
+func (pol *Polygon) Problems() []*Problem {
+	problems := pol.pointsProblems()
+	problems = append(problems, pol.intersectionsProblems()...)
+	if pol.Len() > 2 && len(problems) == 0 && pol.signedArea() > 0 {
+		problems = append(problems, &Problem{Kind: ProblemOrientation, Warning: true})
+	}
+	return problems
+}
+
+// signedArea returns area of the polygon, which is positive for counterclockwise order of the points.
+func (pol *Polygon) signedArea() float64 {
+	n, area := pol.Len(), 0.0
+	for i, p := range pol.Points {
+		next := pol.Points[(i+1)%n]
+		area += p.X*next.Y - next.X*p.Y
+	}
+	area /= 2
+	for _, cs := range pol.CurvedSides() {
+		area += cs.SegmentArea()
+	}
+	return area
+}
+
+// pointsProblems returns problems of duplicate points, zero length sides and collinear points.
+func (pol *Polygon) pointsProblems() []*Problem {
+	n, problems := pol.Len(), make([]*Problem, 0)
+	if n < 2 {
+		return problems
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if !pol.pointsEqual(i, j) {
+				continue
+			}
+			if j == i+1 || (i == 0 && j == n-1) {
+				side := i
+				if j != i+1 {
+					side = j
+				}
+				problems = append(problems, &Problem{Kind: ProblemZeroLengthSide, Sides: []int{side}, Points: []int{i, j}})
+			} else {
+				problems = append(problems, &Problem{Kind: ProblemDuplicatePoints, Points: []int{i, j}})
+			}
+		}
+	}
+	if n < 3 {
+		return problems
+	}
+	for i, p := range pol.Points {
+		prevIndex, nextIndex := (i+n-1)%n, (i+1)%n
+		prev, next := pol.Points[prevIndex], pol.Points[nextIndex]
+		if p.Arc != nil || next.Arc != nil || pol.pointsEqual(prevIndex, i) || pol.pointsEqual(i, nextIndex) {
+			continue
+		}
+		l1, l2 := (&Segment{A: prev, B: p}).Distance(), (&Segment{A: p, B: next}).Distance()
+		if math.Abs(crossProduct(prev, p, next)) > tolerance*math.Max(1, l1*l2) {
+			continue
+		}
+		// the side turning back is found as a self-intersection
+		if (p.X-prev.X)*(next.X-p.X)+(p.Y-prev.Y)*(next.Y-p.Y) > 0 {
+			problems = append(problems, &Problem{Kind: ProblemCollinearPoints, Points: []int{prevIndex, i, nextIndex}, Warning: true})
+		}
+	}
+	return problems
+}
+
+// intersectionsProblems returns problems of intersections of not adjacent sides and of adjacent curved sides.
+func (pol *Polygon) intersectionsProblems() []*Problem {
+	n, problems := pol.Len(), make([]*Problem, 0)
+	if n < 3 {
+		return problems
+	}
+	paths := make([][]*Segment, n)
+	for i := range paths {
+		paths[i] = pol.sidePath(i)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if len(paths[i]) == 0 || len(paths[j]) == 0 {
+				continue
+			}
+			var found bool
+			// sides are adjacent through the common point or through the equal points
+			switch iEnd, jEnd := (i+1)%n, (j+1)%n; {
+			case iEnd == j || pol.pointsEqual(iEnd, j):
+				found = pathsIntersect(paths[i], paths[j], true)
+			case jEnd == i || pol.pointsEqual(jEnd, i):
+				found = pathsIntersect(paths[j], paths[i], true)
+			case pol.pointsEqual(i, j) || pol.pointsEqual(iEnd, jEnd):
+				// touching by the duplicate points is found as duplicate_points
+				continue
+			default:
+				found = pathsIntersect(paths[i], paths[j], false)
+			}
+			if found {
+				problems = append(problems, &Problem{Kind: ProblemSelfIntersection, Sides: []int{i, j}})
+			}
+		}
+	}
+	return problems
+}
+
+// sidePath returns the side i as chords: one for the straight side and arcValidationSteps for the curved one.
+// Returns nil for the side with zero length.
+func (pol *Polygon) sidePath(i int) []*Segment {
+	n := pol.Len()
+	if pol.pointsEqual(i, (i+1)%n) {
+		return nil
+	}
+	cs, err := pol.CurvedSide(i)
+	if err != nil || cs == nil {
+		return []*Segment{{A: pol.Points[i], B: pol.Points[(i+1)%n]}}
+	}
+	path, prev := make([]*Segment, arcValidationSteps), cs.A
+	for k := range path {
+		next := cs.PointAt(float64(k+1) / arcValidationSteps)
+		if k == arcValidationSteps-1 {
+			next = cs.B
+		}
+		path[k], prev = &Segment{A: prev, B: next}, next
+	}
+	return path
+}
+
+// pathsIntersect returns true if the paths have a common point. If adjacent is true, then the last point of
+// the first path is the first point of the second one and the paths intersect only if they have another common point.
+func pathsIntersect(first, second []*Segment, adjacent bool) bool {
+	for i, s1 := range first {
+		for j, s2 := range second {
+			if !adjacent || i != len(first)-1 || j != 0 {
+				if s1.Intersects(s2) {
+					return true
+				}
+				continue
+			}
+			// the segments touching by the common point overlap only if the second one turns back
+			if math.Abs(crossProduct(s1.A, s1.B, s2.B)) <= tolerance*math.Max(1, s1.Distance()*s2.Distance()) &&
+				(s1.B.X-s1.A.X)*(s2.B.X-s2.A.X)+(s1.B.Y-s1.A.Y)*(s2.B.Y-s2.A.Y) < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pointsEqual returns true if the points i and j have the same coordinates.
+func (pol *Polygon) pointsEqual(i, j int) bool {
+	a, b := pol.Points[i], pol.Points[j]
+	return math.Abs(a.X-b.X) <= tolerance*math.Max(1, math.Abs(a.X)) &&
+		math.Abs(a.Y-b.Y) <= tolerance*math.Max(1, math.Abs(a.Y))
+}
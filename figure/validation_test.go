@@ -0,0 +1,137 @@
+package figure
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPolygon_Problems(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []*Point
+		want   []*Problem
+	}{
+		{
+			name:   "Valid clockwise",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}},
+			want:   []*Problem{},
+		},
+		{
+			name:   "Counterclockwise",
+			points: []*Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 2}, {X: 0, Y: 2}},
+			want:   []*Problem{{Kind: ProblemOrientation, Warning: true}},
+		},
+		{
+			name:   "Bow-tie",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 0}, {X: 4, Y: 2}},
+			want:   []*Problem{{Kind: ProblemSelfIntersection, Sides: []int{1, 3}}},
+		},
+		{
+			name:   "Zero length side",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}},
+			want:   []*Problem{{Kind: ProblemZeroLengthSide, Sides: []int{1}, Points: []int{1, 2}}},
+		},
+		{
+			name:   "Zero length closing side",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}, {X: 0, Y: 0}},
+			want:   []*Problem{{Kind: ProblemZeroLengthSide, Sides: []int{4}, Points: []int{0, 4}}},
+		},
+		{
+			name: "Duplicate points",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 4}, {X: 4, Y: 4},
+				{X: 4, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 0}},
+			want: []*Problem{{Kind: ProblemDuplicatePoints, Points: []int{2, 6}}},
+		},
+		{
+			name:   "Collinear points",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0}},
+			want:   []*Problem{{Kind: ProblemCollinearPoints, Points: []int{0, 1, 2}, Warning: true}},
+		},
+		{
+			name:   "Turning back",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 0, Y: 3}, {X: 0, Y: 1}, {X: 4, Y: 0}},
+			want: []*Problem{{Kind: ProblemCollinearPoints, Points: []int{0, 1, 2}, Warning: true},
+				{Kind: ProblemSelfIntersection, Sides: []int{0, 2}}, {Kind: ProblemSelfIntersection, Sides: []int{0, 3}},
+				{Kind: ProblemSelfIntersection, Sides: []int{1, 2}}},
+		},
+		{
+			name:   "Arc crossing sides",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0, Arc: &Arc{Sagitta: 1.5, Right: true}}},
+			want: []*Problem{{Kind: ProblemSelfIntersection, Sides: []int{1, 2}},
+				{Kind: ProblemSelfIntersection, Sides: []int{2, 3}}},
+		},
+		{
+			name:   "Valid arc",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 4, Y: 0, Arc: &Arc{Sagitta: 1, Right: true}}},
+			want:   []*Problem{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pol := &Polygon{Points: tt.points}
+			if got := pol.Problems(); !reflect.DeepEqual(got, tt.want) {
+				for _, p := range got {
+					t.Logf("got %+v", p)
+				}
+				t.Errorf("Problems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolygon_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		points  []*Point
+		wantErr bool
+	}{
+		{
+			name:   "Warning only",
+			points: []*Point{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 2}, {X: 0, Y: 2}},
+		},
+		{
+			name:    "Bow-tie",
+			points:  []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 4, Y: 0}, {X: 4, Y: 2}},
+			wantErr: true,
+		},
+		{
+			name:   "Not enough points",
+			points: []*Point{{X: 0, Y: 0}, {X: 0, Y: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Polygon{Points: tt.points}).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var vErr *ValidationError
+			if tt.wantErr && (!errors.Is(err, ErrInvalidPolygon) || !errors.As(err, &vErr) || len(vErr.Problems) == 0) {
+				t.Errorf("Validate() error = %v, want *ValidationError with problems", err)
+			}
+		})
+	}
+}
+
+func TestSegment_Intersects(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *Segment
+		want bool
+	}{
+		{"Crossing", &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 2, Y: 2}}, &Segment{A: &Point{X: 0, Y: 2}, B: &Point{X: 2, Y: 0}}, true},
+		{"Touching", &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 2, Y: 2}}, &Segment{A: &Point{X: 1, Y: 1}, B: &Point{X: 2, Y: 0}}, true},
+		{"Collinear overlapping", &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 2, Y: 0}}, &Segment{A: &Point{X: 1, Y: 0}, B: &Point{X: 3, Y: 0}}, true},
+		{"Collinear apart", &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 1, Y: 0}}, &Segment{A: &Point{X: 2, Y: 0}, B: &Point{X: 3, Y: 0}}, false},
+		{"Parallel", &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 2, Y: 0}}, &Segment{A: &Point{X: 0, Y: 1}, B: &Point{X: 2, Y: 1}}, false},
+		{"Apart", &Segment{A: &Point{X: 0, Y: 0}, B: &Point{X: 2, Y: 2}}, &Segment{A: &Point{X: 3, Y: 0}, B: &Point{X: 4, Y: 1}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Intersects(tt.b); got != tt.want {
+				t.Errorf("Intersects() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/maxsid/goCeilings/drawing/raster"
+	"github.com/maxsid/goCeilings/figure"
 	"github.com/maxsid/goCeilings/server/common"
 	"github.com/maxsid/goCeilings/value"
 	"github.com/urfave/negroni"
@@ -21,17 +22,38 @@ const ctxKeyUserStorage = ctxKey(iota)
 const defaultAddress = "127.0.0.1:8081"
 
 const (
-	pathVarUserID      = pathVarKey("user_id")
-	pathVarDrawingID   = pathVarKey("drawing_id")
-	pathVarPointNumber = pathVarKey("point_num")
+	pathVarUserID        = pathVarKey("user_id")
+	pathVarDrawingID     = pathVarKey("drawing_id")
+	pathVarPointNumber   = pathVarKey("point_num")
+	pathVarHoleNumber    = pathVarKey("hole_num")
+	pathVarFixtureNumber = pathVarKey("fixture_num")
+	pathVarLevelNumber   = pathVarKey("level_num")
+	pathVarPriceListID   = pathVarKey("price_list_id")
 )
 
 const (
 	urlParamInfo      = urlParamKey("info")
+	urlParamAngles    = urlParamKey("angles")
+	urlParamTriangles = urlParamKey("triangles")
 	urlParamPage      = urlParamKey("p")
 	urlParamPageLimit = urlParamKey("lim")
 	urlParamPrecision = urlParamKey("p")
 	urlParamMeasure   = urlParamKey("m")
+
+	urlParamSkipValidation = urlParamKey("skip_validation")
+	urlParamShrinkX        = urlParamKey("shrink_x")
+	urlParamShrinkY        = urlParamKey("shrink_y")
+	urlParamDirection      = urlParamKey("direction")
+	urlParamPriceList      = urlParamKey("price_list")
+	urlParamFixtures       = urlParamKey("fixtures")
+	urlParamPipeBypasses   = urlParamKey("pipes")
+	urlParamOffset         = urlParamKey("offset")
+	urlParamDistance       = urlParamKey("distance")
+	urlParamJoin           = urlParamKey("join")
+	urlParamX              = urlParamKey("x")
+	urlParamY              = urlParamKey("y")
+	urlParamTolerance      = urlParamKey("tolerance")
+	urlParamMode           = urlParamKey("mode")
 )
 
 // Run runs the REST API server.
@@ -80,6 +102,8 @@ func addHandlersToRouter(router *mux.Router, st common.Storage) {
 	router.HandleFunc(path, drawingsListGettingHandler).Methods(http.MethodGet)
 	router.HandleFunc(path, drawingCreatingHandler).Methods(http.MethodPost)
 
+	router.HandleFunc("/drawings/merge", drawingsMergingHandler).Methods(http.MethodPost)
+
 	path = fmt.Sprintf("/drawings/{%s:[0-9]+}", pathVarDrawingID)
 	router.HandleFunc(path, drawingGettingHandler).Methods(http.MethodGet)
 	router.HandleFunc(path, drawingDeletingHandler).Methods(http.MethodDelete)
@@ -87,6 +111,32 @@ func addHandlersToRouter(router *mux.Router, st common.Storage) {
 	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/image", pathVarDrawingID)
 	router.HandleFunc(path, drawingImageHandler).Methods(http.MethodGet)
 
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/rolls", pathVarDrawingID)
+	router.HandleFunc(path, drawingRollsUpdatingHandler).Methods(http.MethodPut)
+	router.HandleFunc(path, drawingRollsDeletingHandler).Methods(http.MethodDelete)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/tiles", pathVarDrawingID)
+	router.HandleFunc(path, drawingTilesUpdatingHandler).Methods(http.MethodPut)
+	router.HandleFunc(path, drawingTilesDeletingHandler).Methods(http.MethodDelete)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/triangulation", pathVarDrawingID)
+	router.HandleFunc(path, drawingTriangulationHandler).Methods(http.MethodGet)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/query", pathVarDrawingID)
+	router.HandleFunc(path, drawingQueryHandler).Methods(http.MethodGet)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/offset", pathVarDrawingID)
+	router.HandleFunc(path, drawingOffsetHandler).Methods(http.MethodGet)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/pattern", pathVarDrawingID)
+	router.HandleFunc(path, drawingPatternHandler).Methods(http.MethodGet)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/estimate", pathVarDrawingID)
+	router.HandleFunc(path, drawingEstimateHandler).Methods(http.MethodGet)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/estimate/image", pathVarDrawingID)
+	router.HandleFunc(path, drawingEstimateImageHandler).Methods(http.MethodGet)
+
 	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/permissions", pathVarDrawingID)
 	router.HandleFunc(path, permissionsOfDrawingGettingHandler).Methods(http.MethodGet)
 	router.HandleFunc(path, permissionCreatingHandler).Methods(http.MethodPost)
@@ -99,10 +149,67 @@ func addHandlersToRouter(router *mux.Router, st common.Storage) {
 	router.HandleFunc(path, drawingPointsListGettingHandler).Methods(http.MethodGet)
 	router.HandleFunc(path, drawingPointsAddingHandler).Methods(http.MethodPost)
 
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/points/order", pathVarDrawingID)
+	router.HandleFunc(path, drawingPointsOrderingHandler).Methods(http.MethodPost)
+
 	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/points/{%s:[0-9]+}", pathVarDrawingID, pathVarPointNumber)
 	router.HandleFunc(path, drawingPointGettingHandler).Methods(http.MethodGet)
+	router.HandleFunc(path, drawingPointInsertingHandler).Methods(http.MethodPost)
 	router.HandleFunc(path, drawingPointUpdatingHandler).Methods(http.MethodPut)
 	router.HandleFunc(path, drawingPointDeletingHandler).Methods(http.MethodDelete)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/square", pathVarDrawingID)
+	router.HandleFunc(path, drawingSquaringHandler).Methods(http.MethodPost)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/simplify", pathVarDrawingID)
+	router.HandleFunc(path, drawingSimplifyingHandler).Methods(http.MethodPost)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/transform", pathVarDrawingID)
+	router.HandleFunc(path, drawingTransformingHandler).Methods(http.MethodPost)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/split", pathVarDrawingID)
+	router.HandleFunc(path, drawingSplittingHandler).Methods(http.MethodPost)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/holes", pathVarDrawingID)
+	router.HandleFunc(path, drawingHolesListGettingHandler).Methods(http.MethodGet)
+	router.HandleFunc(path, drawingHolesAddingHandler).Methods(http.MethodPost)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/holes/{%s:[0-9]+}", pathVarDrawingID, pathVarHoleNumber)
+	router.HandleFunc(path, drawingHoleGettingHandler).Methods(http.MethodGet)
+	router.HandleFunc(path, drawingHoleUpdatingHandler).Methods(http.MethodPut)
+	router.HandleFunc(path, drawingHoleDeletingHandler).Methods(http.MethodDelete)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/levels", pathVarDrawingID)
+	router.HandleFunc(path, drawingLevelsListGettingHandler).Methods(http.MethodGet)
+	router.HandleFunc(path, drawingLevelsAddingHandler).Methods(http.MethodPost)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/levels/{%s:[0-9]+}", pathVarDrawingID, pathVarLevelNumber)
+	router.HandleFunc(path, drawingLevelGettingHandler).Methods(http.MethodGet)
+	router.HandleFunc(path, drawingLevelUpdatingHandler).Methods(http.MethodPut)
+	router.HandleFunc(path, drawingLevelDeletingHandler).Methods(http.MethodDelete)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/fixtures", pathVarDrawingID)
+	router.HandleFunc(path, drawingFixturesListGettingHandler).Methods(http.MethodGet)
+	router.HandleFunc(path, drawingFixturesAddingHandler).Methods(http.MethodPost)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/fixtures/grid", pathVarDrawingID)
+	router.HandleFunc(path, drawingLightingGridHandler).Methods(http.MethodPost)
+
+	path = fmt.Sprintf("/drawings/{%s:[0-9]+}/fixtures/{%s:[0-9]+}", pathVarDrawingID, pathVarFixtureNumber)
+	router.HandleFunc(path, drawingFixtureGettingHandler).Methods(http.MethodGet)
+	router.HandleFunc(path, drawingFixtureUpdatingHandler).Methods(http.MethodPut)
+	router.HandleFunc(path, drawingFixtureDeletingHandler).Methods(http.MethodDelete)
+
+	path = "/price-lists"
+	router.HandleFunc(path, priceListsGettingHandler).Methods(http.MethodGet)
+	router.HandleFunc(path, priceListCreatingHandler).Methods(http.MethodPost)
+
+	path = fmt.Sprintf("/price-lists/{%s:[0-9]+}", pathVarPriceListID)
+	router.HandleFunc(path, priceListGettingHandler).Methods(http.MethodGet)
+	router.HandleFunc(path, priceListUpdatingHandler).Methods(http.MethodPut)
+	router.HandleFunc(path, priceListDeletingHandler).Methods(http.MethodDelete)
+
+	router.HandleFunc("/calculate/triangle", triangleCalculatingHandler).Methods(http.MethodPost)
 }
 
 // drawingCreatingHandler handles creating one drawing by drawingPostPutRequestData body.
@@ -125,138 +232,1418 @@ func drawingCreatingHandler(w http.ResponseWriter, req *http.Request) {
 	drawing := common.Drawing{DrawingBasic: requestData.DrawingBasic, GGDrawing: *raster.NewEmptyGGDrawing()}
 	drawing.Measures = requestData.Measures.ToFigureMeasures(drawing.Measures)
 
-	if err := drawing.AddPoints(getPointsFromRequestPoint(requestData.Points...)...); writeError(w, err) {
+	if err := drawing.AddPoints(getPointsFromRequestPoint(0, requestData.Points...)...); writeError(w, badRequestError(err)) {
+		return
+	}
+	if requestData.Closure != "" {
+		if err := drawing.CloseTraverse(requestData.Closure, requestData.TransverseRatio); writeError(w, badRequestError(err)) {
+			return
+		}
+	}
+	if len(requestData.Diagonals) > 0 {
+		if err := drawing.AddDiagonals(getDiagonalsFromRequestData(requestData.Diagonals...)...); writeError(w, badRequestError(err)) {
+			return
+		}
+		if _, err := drawing.SolveDiagonals(); writeError(w, badRequestError(err)) {
+			return
+		}
+	}
+
+	if requestData.Rolls != nil {
+		if err := drawing.SetRolls(requestData.Rolls); writeError(w, badRequestError(err)) {
+			return
+		}
+	}
+
+	if requestData.Tiles != nil {
+		if err := drawing.SetTiles(requestData.Tiles); writeError(w, badRequestError(err)) {
+			return
+		}
+	}
+
+	if !validateDrawingOrWriteError(w, req, &drawing) {
+		return
+	}
+
+	if err := storage.CreateDrawings(user.ID, &drawing); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		log.Print(err)
+		return
+	}
+
+	w.Header().Add("Location", fmt.Sprintf("/drawings/%d", drawing.ID))
+	http.Error(w, "", http.StatusCreated)
+}
+
+// drawingsMergingHandler handles creating a new drawing by the union of two or more drawings, e.g. adjacent rooms
+// under a single ceiling. The user must have the permission for getting every merged drawing.
+// Handles: POST /drawings/merge
+func drawingsMergingHandler(w http.ResponseWriter, req *http.Request) {
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+	user := storage.GetCurrentUser()
+
+	var reqData mergingRequestData
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+	if reqData.Name == "" || len(reqData.Drawings) < 2 {
+		http.Error(w, "Bad Request: Wrong JSON body", http.StatusBadRequest)
+		return
+	}
+
+	sources := make([]*raster.GGDrawing, 0, len(reqData.Drawings))
+	for _, d := range reqData.Drawings {
+		source, err := storage.GetDrawing(d.ID)
+		if writeError(w, err) {
+			return
+		}
+		if d.Transform != nil {
+			dmCopy := source.Measures
+			source.Measures = reqData.Measures.ToFigureMeasures(source.Measures)
+			if err := source.Transform(d.Transform); writeError(w, badRequestError(err)) {
+				return
+			}
+			source.Measures = dmCopy
+		}
+		sources = append(sources, &source.GGDrawing)
+	}
+	merged, err := raster.MergeGGDrawings(sources...)
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+	drawing := common.Drawing{DrawingBasic: common.DrawingBasic{Name: reqData.Name}, GGDrawing: *merged}
+
+	if !validateDrawingOrWriteError(w, req, &drawing) {
+		return
+	}
+
+	if err := storage.CreateDrawings(user.ID, &drawing); err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		log.Print(err)
+		return
+	}
+
+	w.Header().Add("Location", fmt.Sprintf("/drawings/%d", drawing.ID))
+	http.Error(w, "", http.StatusCreated)
+}
+
+// drawingDeletingHandler handles deleting one drawing by its ID.
+// Handles: DELETE /drawings/{id}
+func drawingDeletingHandler(w http.ResponseWriter, req *http.Request) {
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	drawingID := uint(0)
+	if err := parsePathValue(mux.Vars(req), pathVarDrawingID, &drawingID); writeError(w, err) {
+		return
+	}
+
+	if err := storage.RemoveDrawing(drawingID); writeError(w, err) {
+		return
+	}
+}
+
+// drawingGettingHandler handles getting one drawing by ID and presents it as drawingGetResponseData type.
+// Handles: GET /drawings/{id}
+func drawingGettingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	layout, err := drawing.GetLayout()
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+	tileLayout, err := drawing.GetTileLayout()
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+	respData := drawingGetResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Points:       drawing.GetPoints(),
+		Holes:        drawing.GetHoles(),
+		Rolls:        drawing.GetRolls(),
+		Tiles:        drawing.GetTiles(),
+		Levels:       drawing.GetLevels(),
+		Fixtures:     getFixturesData(drawing.GetFixtures()...),
+		drawingCalculatedData: drawingCalculatedData{
+			Area:           drawing.Area(),
+			Perimeter:      drawing.Perimeter(),
+			PointsCount:    drawing.Len(),
+			Width:          drawing.Width(),
+			Height:         drawing.Height(),
+			Centroid:       drawing.GetCentroid(),
+			BoundingBox:    drawing.GetBoundingBox(),
+			OrientedBox:    drawing.GetOrientedBoundingBox(),
+			PrincipalAxis:  drawing.GetPrincipalAxis(),
+			Corners:        drawing.GetCorners(),
+			HolesArea:      drawing.HolesArea(),
+			HolesPerimeter: drawing.HolesPerimeter(),
+			Closure:        drawing.GetClosure(),
+			Diagonals:      getDiagonalsData(drawing.GetResiduals()...),
+			TileLayout:     tileLayout,
+			Levels:         drawing.LevelsSummary(),
+		},
+		Measures: drawing.Measures.ToFigureMeasuresNames(),
+	}
+	respData.InnerCorners, respData.OuterCorners = drawing.Polygon.CornersCount()
+	if layout != nil {
+		respData.Layout = &layoutData{Layout: layout, Seams: len(layout.Strips) - 1}
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingImageHandler handle getting an image of the drawing by its ID.
+// Arcs of interior angles are drawn by angles parameter, the offset outline is drawn by offset and join parameters.
+// Handles: GET /drawings/{id}/image?info={bool}&angles={bool}&offset={distance}&join={join}
+func drawingImageHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var err error
+	drawDescription := false
+	if err := parseURLParamValue(req.URL.Query(), urlParamInfo, &drawDescription); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+		return
+	}
+	drawAngles := false
+	if err := parseURLParamValue(req.URL.Query(), urlParamAngles, &drawAngles); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+		return
+	}
+	drawing.ShowAngles(drawAngles)
+	drawTriangles := false
+	if err := parseURLParamValue(req.URL.Query(), urlParamTriangles, &drawTriangles); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+		return
+	}
+	drawing.ShowTriangles(drawTriangles)
+	offset, err := getOffsetByURLParams(req.URL.Query(), urlParamOffset)
+	if err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+		return
+	}
+	if err := drawing.ShowOffset(offset); writeError(w, badRequestError(err)) {
+		return
+	}
+	drawer := drawing.GetDrawer()
+	imageBytes, err := drawer.Draw(drawDescription)
+	if writeError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", drawer.DrawingMIME())
+	_, _ = w.Write(imageBytes)
+}
+
+// drawingOffsetHandler handles getting points and the perimeter of the offset outline of the drawing by its ID,
+// e.g. the line of the mounting profile. The distance is in the drawing length measure, negative one is inwards.
+// Handles: GET /drawings/{id}/offset?distance={distance}&join={join}
+func drawingOffsetHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	offset, err := getOffsetByURLParams(req.URL.Query(), urlParamDistance)
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+	distance := offset.Distance
+	points, perimeter, err := drawing.Offset(offset)
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+
+	marshalAndWrite(w, &offsetGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Offset:       distance,
+		Join:         offset.Join,
+		Points:       points,
+		Perimeter:    perimeter,
+		Measures:     drawing.Measures.ToFigureMeasuresNames(),
+	})
+}
+
+// drawingTriangulationHandler handles getting triangles of the drawing by its ID with their sides and areas,
+// so the area can be checked by hand.
+// Handles: GET /drawings/{id}/triangulation
+func drawingTriangulationHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	report, err := drawing.GetTriangulation()
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+
+	marshalAndWrite(w, &triangulationResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Triangles:    getTrianglesData(drawing.Len(), report.Triangles...),
+		Area:         report.Area,
+		Measures:     drawing.Measures.ToFigureMeasuresNames(),
+	})
+}
+
+// drawingQueryHandler handles getting the location of the point relatively to the drawing by its ID, the nearest
+// vertex and distances to walls. Coordinates and the tolerance of lying on a wall are in the drawing length measure.
+// Handles: GET /drawings/{id}/query?x={x}&y={y}&tolerance={tolerance}
+func drawingQueryHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	vars := req.URL.Query()
+	p, tol := &figure.Point{}, 0.0
+	if err := parseURLParamValue(vars, urlParamX, &p.X); writeError(w, badRequestError(err)) {
+		return
+	}
+	if err := parseURLParamValue(vars, urlParamY, &p.Y); writeError(w, badRequestError(err)) {
+		return
+	}
+	if err := parseURLParamValue(vars, urlParamTolerance, &tol); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+		return
+	}
+
+	q, err := drawing.Query(p, tol)
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+
+	names, n := pointsNames(drawing.Len()), drawing.Len()
+	walls := make([]*wallDistanceData, len(q.Walls))
+	for i, wd := range q.Walls {
+		walls[i] = &wallDistanceData{Name: names[wd.Side] + names[(wd.Side+1)%n], Distance: wd.Distance, Foot: wd.Foot}
+	}
+	marshalAndWrite(w, &queryResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Point:        q.Point,
+		Location:     q.Location,
+		Vertex:       &vertexDistanceData{Name: names[q.Vertex], Distance: q.VertexDistance},
+		Walls:        walls,
+		Measure:      value.NameOfLengthMeasure(drawing.Measures.Length),
+	})
+}
+
+// drawingPatternHandler handles getting an image of the cut pattern of the drawing by its ID.
+// Percents of shrinkage along and across the roll are required, the roll direction is in the drawing angle measure.
+// Handles: GET /drawings/{id}/pattern?shrink_x={x}&shrink_y={y}&direction={direction}
+func drawingPatternHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	vars := req.URL.Query()
+	shrinkX, shrinkY, direction := 0.0, 0.0, 0.0
+	if err := parseURLParamValue(vars, urlParamShrinkX, &shrinkX); writeError(w, badRequestError(err)) {
+		return
+	}
+	if err := parseURLParamValue(vars, urlParamShrinkY, &shrinkY); writeError(w, badRequestError(err)) {
+		return
+	}
+	if err := parseURLParamValue(vars, urlParamDirection, &direction); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+		return
+	}
+	drawDescription := false
+	if err := parseURLParamValue(vars, urlParamInfo, &drawDescription); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+		return
+	}
+
+	pattern, err := drawing.ShrinkagePattern(shrinkX, shrinkY, direction)
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+	drawer := pattern.GetDrawer()
+	imageBytes, err := drawer.Draw(drawDescription)
+	if writeError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", drawer.DrawingMIME())
+	_, _ = w.Write(imageBytes)
+}
+
+// drawingSquaringHandler snaps nearly right angles of the drawing by its ID and squaringRequestData body and returns
+// shifts of points. If dry_run is true, the image of the squared drawing is returned without saving, info and angles
+// parameters are the same as in drawingImageHandler.
+// Handles: POST /drawings/{id}/square?info={bool}&angles={bool}
+func drawingSquaringHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var reqData squaringRequestData
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+
+	dmCopy := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	squared, report, err := drawing.Square(&reqData.Squaring)
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+
+	if reqData.DryRun {
+		drawing.Measures = dmCopy
+		vars := req.URL.Query()
+		drawDescription, drawAngles := false, false
+		if err := parseURLParamValue(vars, urlParamInfo, &drawDescription); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+			return
+		}
+		if err := parseURLParamValue(vars, urlParamAngles, &drawAngles); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+			return
+		}
+		squared.Measures = dmCopy
+		squared.ShowAngles(drawAngles)
+		drawer := squared.GetDrawer()
+		imageBytes, err := drawer.Draw(drawDescription)
+		if writeError(w, err) {
+			return
+		}
+		w.Header().Set("Content-Type", drawer.DrawingMIME())
+		_, _ = w.Write(imageBytes)
+		return
+	}
+
+	drawing.GGDrawing = *squared
+	respData := squaringResponseData{
+		DrawingBasic:   drawing.DrawingBasic,
+		SquaringReport: report,
+		Points:         drawing.GetPoints(),
+		Measure:        value.NameOfLengthMeasure(drawing.Measures.Length),
+	}
+	drawing.Measures = dmCopy
+
+	if !validateDrawingOrWriteError(w, req, drawing) {
+		return
+	}
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingSimplifyingHandler merges close points and removes redundant points of the drawing by its ID and
+// simplificationRequestData body. If the body has dry_run, then the image of the simplified drawing is returned
+// without saving (info and angles parameters are the same as in drawingImageHandler).
+// Handles: POST /drawings/{id}/simplify
+func drawingSimplifyingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var reqData simplificationRequestData
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+
+	dmCopy := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	simplified, report, err := drawing.Simplify(&reqData.Simplification)
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+
+	if reqData.DryRun {
+		drawing.Measures = dmCopy
+		vars := req.URL.Query()
+		drawDescription, drawAngles := false, false
+		if err := parseURLParamValue(vars, urlParamInfo, &drawDescription); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+			return
+		}
+		if err := parseURLParamValue(vars, urlParamAngles, &drawAngles); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+			return
+		}
+		simplified.Measures = dmCopy
+		simplified.ShowAngles(drawAngles)
+		drawer := simplified.GetDrawer()
+		imageBytes, err := drawer.Draw(drawDescription)
+		if writeError(w, err) {
+			return
+		}
+		w.Header().Set("Content-Type", drawer.DrawingMIME())
+		_, _ = w.Write(imageBytes)
+		return
+	}
+
+	names := pointsNames(drawing.Len())
+	respData := simplificationResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Merged:       make([]string, len(report.Merged)),
+		Removed:      make([]string, len(report.Removed)),
+		MaxDeviation: report.MaxDeviation,
+		AreaChange:   report.AreaChange,
+	}
+	for i, v := range report.Merged {
+		respData.Merged[i] = names[v]
+	}
+	for i, v := range report.Removed {
+		respData.Removed[i] = names[v]
+	}
+	drawing.GGDrawing = *simplified
+	respData.Points = drawing.GetPoints()
+	respData.Measures = drawing.Measures.ToFigureMeasuresNames()
+	drawing.Measures = dmCopy
+
+	if !validateDrawingOrWriteError(w, req, drawing) {
+		return
+	}
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingTransformingHandler moves, mirrors, scales and rotates the drawing by its ID and transformingRequestData
+// body about the pivot point and returns new points.
+// Handles: POST /drawings/{id}/transform
+func drawingTransformingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var reqData transformingRequestData
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+
+	dmCopy := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	if err := drawing.Transform(&reqData.Transform); writeError(w, badRequestError(err)) {
+		return
+	}
+	respData := drawingPointsGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Points:       drawing.GetPoints(),
+		Measure:      value.NameOfLengthMeasure(drawing.Measures.Length),
+	}
+	drawing.Measures = dmCopy
+
+	if !validateDrawingOrWriteError(w, req, drawing) {
+		return
+	}
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingSplittingHandler handles splitting the drawing along the line into new drawings, e.g. separate canvases
+// of a large ceiling. New drawings get the same permissions as the drawing has, the drawing isn't changed.
+// Handles: POST /drawings/{id}/split
+func drawingSplittingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var reqData splittingRequestData
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+	line := figure.SplitLine{A: reqData.Line.A, B: reqData.Line.B, Direction: reqData.Line.Direction}
+	if line.A == nil {
+		if reqData.Line.Vertex == 0 {
+			http.Error(w, "Bad Request: Wrong JSON body", http.StatusBadRequest)
+			return
+		}
+		line.Vertex = int(reqData.Line.Vertex) - 1
+	}
+
+	dmCopy := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	parts, err := drawing.Split(&line)
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+	respData := splittingResponseData{
+		Drawings: make([]*splitPartData, len(parts)),
+		Measure:  value.NameOfAreaMeasure(drawing.Measures.Area),
+	}
+	newDrawings := make([]*common.Drawing, len(parts))
+	for i, part := range parts {
+		name := fmt.Sprintf("%s (%d)", drawing.Name, i+1)
+		if i < len(reqData.Names) && reqData.Names[i] != "" {
+			name = reqData.Names[i]
+		}
+		respData.Drawings[i] = &splitPartData{DrawingBasic: common.DrawingBasic{Name: name}, Area: part.Area()}
+		measures := *dmCopy
+		part.Measures = &measures
+		newDrawings[i] = &common.Drawing{DrawingBasic: respData.Drawings[i].DrawingBasic, GGDrawing: *part}
+		if !validateDrawingOrWriteError(w, req, newDrawings[i]) {
+			return
+		}
+	}
+	drawing.Measures = dmCopy
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+	if err := storage.CreateDrawingsFrom(drawing.ID, newDrawings...); writeError(w, err) {
+		return
+	}
+	for i, d := range newDrawings {
+		respData.Drawings[i].ID = d.ID
+	}
+
+	marshalAndWriteStatus(w, http.StatusCreated, &respData)
+}
+
+// drawingEstimateHandler handles getting the quote of the drawing by its ID and the price list ID.
+// Numbers of light fixtures and pipe bypasses are counted by fixtures of the drawing, if they aren't specified.
+// Handles: GET /drawings/{id}/estimate?price_list={id}&fixtures={n}&pipes={n}
+func drawingEstimateHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, priceList, in, ok := getEstimateByRequestOrWriteError(w, req)
+	if !ok {
+		return
+	}
+
+	respData := estimateResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		PriceList:    priceList,
+		Quantities:   in,
+		Quote:        priceList.Estimate(in),
+	}
+	marshalAndWrite(w, &respData)
+}
+
+// drawingEstimateImageHandler handles getting an image of the quote sheet of the drawing by its ID and
+// the price list ID. The description of the drawing is added to the sheet by info parameter.
+// Handles: GET /drawings/{id}/estimate/image?price_list={id}&fixtures={n}&pipes={n}
+func drawingEstimateImageHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, priceList, in, ok := getEstimateByRequestOrWriteError(w, req)
+	if !ok {
+		return
+	}
+	drawDescription := false
+	if err := parseURLParamValue(req.URL.Query(), urlParamInfo, &drawDescription); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+		return
+	}
+
+	sheet := &raster.QuoteSheet{
+		Title:       fmt.Sprintf("%s (%s)", drawing.Name, priceList.Name),
+		Quote:       priceList.Estimate(in),
+		Description: drawing.Description,
+	}
+	drawer := sheet.GetDrawer()
+	imageBytes, err := drawer.Draw(drawDescription)
+	if writeError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", drawer.DrawingMIME())
+	_, _ = w.Write(imageBytes)
+}
+
+// drawingRollsUpdatingHandler sets rolls of the strips layout of the drawing by its ID and rollsWithMeasures body.
+// Handles: PUT /drawings/{id}/rolls
+func drawingRollsUpdatingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var reqData rollsWithMeasures
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+	if reqData.Rolls == nil {
+		_ = writeError(w, fmt.Errorf("%w: rolls are not specified", ErrBadRequestData))
+		return
+	}
+
+	drawingMeasures := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	if err := drawing.SetRolls(reqData.Rolls); writeError(w, badRequestError(err)) {
+		return
+	}
+
+	drawing.Measures = drawingMeasures
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+}
+
+// drawingRollsDeletingHandler removes the strips layout of the drawing by its ID.
+// Handles: DELETE /drawings/{id}/rolls
+func drawingRollsDeletingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	_ = drawing.SetRolls(nil)
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+}
+
+// drawingTilesUpdatingHandler sets the suspended ceiling grid of the drawing by its ID and tilesWithMeasures body.
+// Handles: PUT /drawings/{id}/tiles
+func drawingTilesUpdatingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var reqData tilesWithMeasures
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+	if reqData.Tiles == nil {
+		_ = writeError(w, fmt.Errorf("%w: tiles are not specified", ErrBadRequestData))
+		return
+	}
+
+	drawingMeasures := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	if err := drawing.SetTiles(reqData.Tiles); writeError(w, badRequestError(err)) {
+		return
+	}
+
+	drawing.Measures = drawingMeasures
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+}
+
+// drawingTilesDeletingHandler removes the suspended ceiling grid of the drawing by its ID.
+// Handles: DELETE /drawings/{id}/tiles
+func drawingTilesDeletingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	_ = drawing.SetTiles(nil)
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+}
+
+// drawingsListGettingHandler handles getting a list of drawings the current user
+// and presents it as drawingsListResponseData.
+// Handles: GET /drawings
+func drawingsListGettingHandler(w http.ResponseWriter, req *http.Request) {
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+	user := storage.GetCurrentUser()
+
+	amount, err := storage.DrawingsAmount(user.ID)
+	if writeError(w, err) {
+		return
+	}
+	stat, err := readListStatData(req.URL.Query(), amount)
+	if writeError(w, err) {
+		return
+	}
+
+	respData := drawingsListResponseData{Drawings: make([]*common.DrawingBasic, 0), listStatData: *stat}
+	if amount > 0 {
+		respData.Drawings, err = storage.GetDrawingsList(user.ID, respData.Page, respData.PageLimit)
+		if writeError(w, err) {
+			return
+		}
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingPointsAddingHandler handles adding new points into the drawing by its ID and pointsCalculatingWithMeasures body.
+// Handles: POST /drawings/{id}/points
+func drawingPointsAddingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var reqData pointsCalculatingWithMeasures
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+
+	dmCopy := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	if err := drawing.AddPoints(getPointsFromRequestPoint(drawing.Len(), reqData.Points...)...); writeError(w, badRequestError(err)) {
+		return
+	}
+	if reqData.Closure != "" {
+		if err := drawing.CloseTraverse(reqData.Closure, reqData.TransverseRatio); writeError(w, badRequestError(err)) {
+			return
+		}
+	}
+	if !validateDrawingOrWriteError(w, req, drawing) {
+		return
+	}
+
+	respData := drawingPointsGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Points:       drawing.GetPointsWithParams(drawing.Measures.Length, 2),
+		Measure:      reqData.Measures.Length,
+	}
+
+	drawing.Measures = dmCopy
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingPointInsertingHandler handles inserting a point before the point of the drawing by drawing ID, a number
+// of the point and pointCalculatingWithMeasures body, so the new point gets the number. Calculated points are
+// computed again from new previous points, mode=keep URL parameter keeps their coordinates instead.
+// Handles: POST /drawings/{id}/points/{number}
+func drawingPointInsertingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+	pointIndex, ok := getPointIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
+		return
+	}
+	mode, ok := getPointsUpdateModeByRequestOrWriteError(w, req, figure.RecomputeCoordinates)
+	if !ok {
+		return
+	}
+
+	var reqData pointCalculatingWithMeasures
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+
+	dmCopy := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	point := getPointsFromRequestPoint(pointIndex, &reqData.Point)[0]
+	if err := drawing.InsertPoint(pointIndex, point, mode); writeError(w, badRequestError(err)) {
+		return
+	}
+	if !validateDrawingOrWriteError(w, req, drawing) {
+		return
+	}
+
+	respData := drawingPointsGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Points:       drawing.GetPointsWithParams(drawing.Measures.Length, 2),
+		Measure:      value.NameOfLengthMeasure(drawing.Measures.Length),
+	}
+
+	drawing.Measures = dmCopy
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingPointsOrderingHandler handles changing the order of points of the drawing by its ID and
+// pointsOrderingRequestData body: the point by the number becomes the first one, then the direction is reversed
+// if it's requested. The outline of the drawing isn't changed.
+// Handles: POST /drawings/{id}/points/order
+func drawingPointsOrderingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var reqData pointsOrderingRequestData
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+
+	if reqData.First > 1 {
+		if reqData.First > uint(drawing.Len()) {
+			_ = writeError(w, ErrPointNotFound)
+			return
+		}
+		if err := drawing.RotatePoints(int(reqData.First) - 1); writeError(w, badRequestError(err)) {
+			return
+		}
+	}
+	if reqData.Reverse {
+		if err := drawing.ReversePoints(); writeError(w, badRequestError(err)) {
+			return
+		}
+	}
+	if !validateDrawingOrWriteError(w, req, drawing) {
+		return
+	}
+
+	respData := drawingPointsGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Points:       drawing.GetPointsWithParams(drawing.Measures.Length, 2),
+		Measure:      value.NameOfLengthMeasure(drawing.Measures.Length),
+	}
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingPointDeletingHandler handles deleting one point from the drawing by drawing ID and a number of the point.
+// The first point of the drawing has a number one. Calculated points keep their coordinates, mode=recompute
+// URL parameter computes them again from new previous points instead.
+// Handles: DELETE /drawings/{id}/points/{number}
+func drawingPointDeletingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+	pointIndex, ok := getPointIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
+		return
+	}
+	mode, ok := getPointsUpdateModeByRequestOrWriteError(w, req, figure.KeepCoordinates)
+	if !ok {
+		return
+	}
+
+	if err := drawing.DeletePoint(pointIndex, mode); writeError(w, badRequestError(err)) {
+		return
+	}
+	if !validateDrawingOrWriteError(w, req, drawing) {
+		return
+	}
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+}
+
+// drawingPointGettingHandler handles getting one point of a drawing by drawing ID and a number of the point.
+// The first point of the drawing has a number one.
+// Handles: GET /drawings/{id}/points/{number}
+func drawingPointGettingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+	pointIndex, ok := getPointIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
+		return
+	}
+
+	precision, measure := 2, drawing.Measures.Length
+	if err := readLengthMeasureAndPrecision(req.URL.Query(), &measure, &precision); writeError(w, err) {
+		return
+	}
+	point := drawing.Points[pointIndex]
+	marshalAndWrite(w, pointWithMeasure{
+		X:       value.ConvertFromOneRound(measure, point.X, precision),
+		Y:       value.ConvertFromOneRound(measure, point.Y, precision),
+		Measure: value.NameOfLengthMeasure(measure),
+	})
+}
+
+// drawingPointsListGettingHandler handles getting points of the drawing by its ID.
+// Handles: GET /drawings/{id}/points
+func drawingPointsListGettingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	precision, measure := 2, drawing.Measures.Length
+	if err := readLengthMeasureAndPrecision(req.URL.Query(), &measure, &precision); writeError(w, err) {
+		return
+	}
+	respData := drawingPointsGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Points:       drawing.GetPointsWithParams(measure, precision),
+		Measure:      value.NameOfLengthMeasure(measure),
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingPointUpdatingHandler updates a point of the drawing by drawing ID, a number of the point and
+// pointCalculatingWithMeasures body.
+// Handles: PUT /drawings/{id}/points/{number}
+func drawingPointUpdatingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+	pointIndex, ok := getPointIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
+		return
+	}
+
+	var pointWithMeasure pointCalculatingWithMeasures
+	if err := unmarshalReaderContent(req.Body, &pointWithMeasure); writeError(w, err) {
+		return
+	}
+
+	drawingMeasures := drawing.Measures
+	drawing.Measures = pointWithMeasure.Measures.ToFigureMeasures(drawing.Measures)
+
+	point := getPointsFromRequestPoint(pointIndex, &pointWithMeasure.Point)[0]
+	if err := drawing.SetPoint(pointIndex, point); writeError(w, badRequestError(err)) {
+		return
+	}
+	if !validateDrawingOrWriteError(w, req, drawing) {
+		return
+	}
+
+	drawing.Measures = drawingMeasures
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+}
+
+// drawingHolesListGettingHandler handles getting holes of the drawing by its ID.
+// Handles: GET /drawings/{id}/holes
+func drawingHolesListGettingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	precision, measure := 2, drawing.Measures.Length
+	if err := readLengthMeasureAndPrecision(req.URL.Query(), &measure, &precision); writeError(w, err) {
+		return
+	}
+	respData := drawingHolesGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Holes:        drawing.GetHolesWithParams(measure, precision),
+		Measure:      value.NameOfLengthMeasure(measure),
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingHolesAddingHandler handles adding new holes into the drawing by its ID and holesWithMeasures body.
+// Handles: POST /drawings/{id}/holes
+func drawingHolesAddingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var reqData holesWithMeasures
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+	if len(reqData.Holes) == 0 {
+		_ = writeError(w, fmt.Errorf("%w: holes are not specified", ErrBadRequestData))
+		return
+	}
+
+	dmCopy := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	if err := drawing.AddHoles(reqData.Holes...); writeError(w, badRequestError(err)) {
+		return
+	}
+
+	respData := drawingHolesGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Holes:        drawing.GetHolesWithParams(drawing.Measures.Length, 2),
+		Measure:      reqData.Measures.Length,
+	}
+
+	drawing.Measures = dmCopy
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingHoleGettingHandler handles getting one hole of a drawing by drawing ID and a number of the hole.
+// The first hole of the drawing has a number one.
+// Handles: GET /drawings/{id}/holes/{number}
+func drawingHoleGettingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+	holeIndex, ok := getHoleIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
+		return
+	}
+
+	precision, measure := 2, drawing.Measures.Length
+	if err := readLengthMeasureAndPrecision(req.URL.Query(), &measure, &precision); writeError(w, err) {
+		return
+	}
+	marshalAndWrite(w, holeWithMeasure{
+		Hole:    *drawing.GetHolesWithParams(measure, precision)[holeIndex],
+		Measure: value.NameOfLengthMeasure(measure),
+	})
+}
+
+// drawingHoleUpdatingHandler updates a hole of the drawing by drawing ID, a number of the hole and
+// holeWithMeasures body.
+// Handles: PUT /drawings/{id}/holes/{number}
+func drawingHoleUpdatingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+	holeIndex, ok := getHoleIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
+		return
+	}
+
+	var reqData holeWithMeasures
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+
+	drawingMeasures := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	if err := drawing.SetHole(holeIndex, &reqData.Hole); writeError(w, badRequestError(err)) {
+		return
+	}
+
+	drawing.Measures = drawingMeasures
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+}
+
+// drawingHoleDeletingHandler handles deleting one hole from the drawing by drawing ID and a number of the hole.
+// The first hole of the drawing has a number one.
+// Handles: DELETE /drawings/{id}/holes/{number}
+func drawingHoleDeletingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+	holeIndex, ok := getHoleIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
+		return
+	}
+
+	if err := drawing.RemoveHole(holeIndex); writeError(w, err) {
+		return
+	}
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
+}
+
+// drawingLevelsListGettingHandler handles getting levels of the drawing by its ID with their calculations.
+// Handles: GET /drawings/{id}/levels
+func drawingLevelsListGettingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	precision, measure := 2, drawing.Measures.Length
+	if err := readLengthMeasureAndPrecision(req.URL.Query(), &measure, &precision); writeError(w, err) {
+		return
+	}
+	respData := drawingLevelsGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Levels:       drawing.GetLevelsWithParams(measure, precision),
+		Summary:      drawing.LevelsSummary(),
+		Measure:      value.NameOfLengthMeasure(measure),
+	}
+
+	marshalAndWrite(w, &respData)
+}
+
+// drawingLevelsAddingHandler handles adding new levels into the drawing by its ID and levelsWithMeasures body.
+// Handles: POST /drawings/{id}/levels
+func drawingLevelsAddingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return
+	}
+
+	var reqData levelsWithMeasures
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+	if len(reqData.Levels) == 0 {
+		_ = writeError(w, fmt.Errorf("%w: levels are not specified", ErrBadRequestData))
+		return
+	}
+
+	dmCopy := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	if err := drawing.AddLevels(reqData.Levels...); writeError(w, badRequestError(err)) {
+		return
+	}
+
+	respData := drawingLevelsGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Levels:       drawing.GetLevelsWithParams(drawing.Measures.Length, 2),
+		Summary:      drawing.LevelsSummary(),
+		Measure:      reqData.Measures.Length,
+	}
+
+	drawing.Measures = dmCopy
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
 		return
 	}
 
-	if err := storage.CreateDrawings(user.ID, &drawing); err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		log.Print(err)
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
 		return
 	}
 
-	w.Header().Add("Location", fmt.Sprintf("/drawings/%d", drawing.ID))
-	http.Error(w, "", http.StatusCreated)
+	marshalAndWrite(w, &respData)
 }
 
-// drawingDeletingHandler handles deleting one drawing by its ID.
-// Handles: DELETE /drawings/{id}
-func drawingDeletingHandler(w http.ResponseWriter, req *http.Request) {
-	var storage common.UserStorage
-	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+// drawingLevelGettingHandler handles getting one level of a drawing by drawing ID and a number of the level.
+// The first level of the drawing has a number one.
+// Handles: GET /drawings/{id}/levels/{number}
+func drawingLevelGettingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
 		return
 	}
-
-	drawingID := uint(0)
-	if err := parsePathValue(mux.Vars(req), pathVarDrawingID, &drawingID); writeError(w, err) {
+	levelIndex, ok := getLevelIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
 		return
 	}
 
-	if err := storage.RemoveDrawing(drawingID); writeError(w, err) {
+	precision, measure := 2, drawing.Measures.Length
+	if err := readLengthMeasureAndPrecision(req.URL.Query(), &measure, &precision); writeError(w, err) {
 		return
 	}
+	marshalAndWrite(w, levelWithMeasure{
+		Level:   *drawing.GetLevelsWithParams(measure, precision)[levelIndex],
+		Measure: value.NameOfLengthMeasure(measure),
+	})
 }
 
-// drawingGettingHandler handles getting one drawing by ID and presents it as drawingGetResponseData type.
-// Handles: GET /drawings/{id}
-func drawingGettingHandler(w http.ResponseWriter, req *http.Request) {
+// drawingLevelUpdatingHandler updates a level of the drawing by drawing ID, a number of the level and
+// levelWithMeasures body.
+// Handles: PUT /drawings/{id}/levels/{number}
+func drawingLevelUpdatingHandler(w http.ResponseWriter, req *http.Request) {
 	drawing, _ := getDrawingByRequestOrWriteError(w, req)
 	if drawing == nil {
 		return
 	}
+	levelIndex, ok := getLevelIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
+		return
+	}
 
-	respData := drawingGetResponseData{
-		DrawingBasic: drawing.DrawingBasic,
-		Points:       drawing.GetPoints(),
-		drawingCalculatedData: drawingCalculatedData{
-			Area:        drawing.Area(),
-			Perimeter:   drawing.Perimeter(),
-			PointsCount: drawing.Len(),
-			Width:       drawing.Width(),
-			Height:      drawing.Height(),
-		},
-		Measures: drawing.Measures.ToFigureMeasuresNames(),
+	var reqData levelWithMeasures
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
 	}
 
-	marshalAndWrite(w, &respData)
+	drawingMeasures := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	if err := drawing.SetLevel(levelIndex, &reqData.Level); writeError(w, badRequestError(err)) {
+		return
+	}
+
+	drawing.Measures = drawingMeasures
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
 }
 
-// drawingImageHandler handle getting an image of the drawing by its ID.
-// Handles: GET /drawings/{id}/image
-func drawingImageHandler(w http.ResponseWriter, req *http.Request) {
+// drawingLevelDeletingHandler handles deleting one level from the drawing by drawing ID and a number of the level.
+// The first level of the drawing has a number one.
+// Handles: DELETE /drawings/{id}/levels/{number}
+func drawingLevelDeletingHandler(w http.ResponseWriter, req *http.Request) {
 	drawing, _ := getDrawingByRequestOrWriteError(w, req)
 	if drawing == nil {
 		return
 	}
-
-	var err error
-	drawDescription := false
-	if err := parseURLParamValue(req.URL.Query(), urlParamInfo, &drawDescription); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+	levelIndex, ok := getLevelIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
 		return
 	}
-	drawer := drawing.GetDrawer()
-	imageBytes, err := drawer.Draw(drawDescription)
-	if writeError(w, err) {
+
+	if err := drawing.RemoveLevel(levelIndex); writeError(w, err) {
 		return
 	}
 
-	w.Header().Set("Content-Type", drawer.DrawingMIME())
-	_, _ = w.Write(imageBytes)
-}
-
-// drawingsListGettingHandler handles getting a list of drawings the current user
-// and presents it as drawingsListResponseData.
-// Handles: GET /drawings
-func drawingsListGettingHandler(w http.ResponseWriter, req *http.Request) {
 	var storage common.UserStorage
 	if storage = getUserStorageOrWriteError(w, req); storage == nil {
 		return
 	}
-	user := storage.GetCurrentUser()
 
-	amount, err := storage.DrawingsAmount(user.ID)
-	if writeError(w, err) {
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
 		return
 	}
-	stat, err := readListStatData(req.URL.Query(), amount)
-	if writeError(w, err) {
+}
+
+// drawingFixturesListGettingHandler handles getting fixtures of the drawing by its ID with their numbers by types.
+// Handles: GET /drawings/{id}/fixtures
+func drawingFixturesListGettingHandler(w http.ResponseWriter, req *http.Request) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
 		return
 	}
 
-	respData := drawingsListResponseData{Drawings: make([]*common.DrawingBasic, 0), listStatData: *stat}
-	if amount > 0 {
-		respData.Drawings, err = storage.GetDrawingsList(user.ID, respData.Page, respData.PageLimit)
-		if writeError(w, err) {
-			return
-		}
+	precision, measure := 2, drawing.Measures.Length
+	if err := readLengthMeasureAndPrecision(req.URL.Query(), &measure, &precision); writeError(w, err) {
+		return
+	}
+	respData := drawingFixturesGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Fixtures:     getFixturesData(drawing.GetFixturesWithParams(measure, precision)...),
+		Counts:       drawing.FixturesCount(),
+		Measure:      value.NameOfLengthMeasure(measure),
 	}
 
 	marshalAndWrite(w, &respData)
 }
 
-// drawingPointsAddingHandler handles adding new points into the drawing by its ID and pointsCalculatingWithMeasures body.
-// Handles: POST /drawings/{id}/points
-func drawingPointsAddingHandler(w http.ResponseWriter, req *http.Request) {
+// drawingFixturesAddingHandler handles adding new fixtures into the drawing by its ID and fixturesWithMeasures body.
+// Handles: POST /drawings/{id}/fixtures
+func drawingFixturesAddingHandler(w http.ResponseWriter, req *http.Request) {
 	drawing, _ := getDrawingByRequestOrWriteError(w, req)
 	if drawing == nil {
 		return
 	}
 
-	var reqData pointsCalculatingWithMeasures
+	var reqData fixturesWithMeasures
 	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
 		return
 	}
+	if len(reqData.Fixtures) == 0 {
+		_ = writeError(w, fmt.Errorf("%w: fixtures are not specified", ErrBadRequestData))
+		return
+	}
 
 	dmCopy := drawing.Measures
 	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
 
-	if err := drawing.AddPoints(getPointsFromRequestPoint(reqData.Points...)...); writeError(w, err) {
+	if err := drawing.AddFixtures(getFixturesFromRequestData(reqData.Fixtures...)...); writeError(w, badRequestError(err)) {
 		return
 	}
 
-	respData := drawingPointsGettingResponseData{
+	respData := drawingFixturesGettingResponseData{
 		DrawingBasic: drawing.DrawingBasic,
-		Points:       drawing.GetPointsWithParams(drawing.Measures.Length, 2),
+		Fixtures:     getFixturesData(drawing.GetFixturesWithParams(drawing.Measures.Length, 2)...),
+		Counts:       drawing.FixturesCount(),
 		Measure:      reqData.Measures.Length,
 	}
 
@@ -274,40 +1661,58 @@ func drawingPointsAddingHandler(w http.ResponseWriter, req *http.Request) {
 	marshalAndWrite(w, &respData)
 }
 
-// drawingPointDeletingHandler handles deleting one point from the drawing by drawing ID and a number of the point.
-// The first point of the drawing has a number one.
-// Handles: DELETE /drawings/{id}/points/{number}
-func drawingPointDeletingHandler(w http.ResponseWriter, req *http.Request) {
+// drawingLightingGridHandler handles generating fixtures evenly placed by the grid in the drawing by its ID and
+// lightingGridRequestData body. Generated fixtures are added into the drawing only if write is true.
+// Handles: POST /drawings/{id}/fixtures/grid
+func drawingLightingGridHandler(w http.ResponseWriter, req *http.Request) {
 	drawing, _ := getDrawingByRequestOrWriteError(w, req)
 	if drawing == nil {
 		return
 	}
-	pointIndex, ok := getPointIndexByRequestOrWriteError(w, req, drawing)
-	if !ok {
+
+	var reqData lightingGridRequestData
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
 		return
 	}
 
-	drawing.Points = append(drawing.Points[:pointIndex], drawing.Points[pointIndex+1:]...)
+	dmCopy := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
 
-	var storage common.UserStorage
-	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+	fixtures, err := drawing.LightingGrid(&reqData.Grid, reqData.Write)
+	if writeError(w, badRequestError(err)) {
 		return
 	}
+	respData := drawingFixturesGettingResponseData{
+		DrawingBasic: drawing.DrawingBasic,
+		Fixtures:     fixtures,
+		Counts:       map[figure.FixtureType]int{reqData.Grid.Type: len(fixtures)},
+		Measure:      value.NameOfLengthMeasure(drawing.Measures.Length),
+	}
 
-	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
-		return
+	drawing.Measures = dmCopy
+
+	if reqData.Write {
+		var storage common.UserStorage
+		if storage = getUserStorageOrWriteError(w, req); storage == nil {
+			return
+		}
+		if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+			return
+		}
 	}
+
+	marshalAndWrite(w, &respData)
 }
 
-// drawingPointGettingHandler handles getting one point of a drawing by drawing ID and a number of the point.
-// The first point of the drawing has a number one.
-// Handles: GET /drawings/{id}/points/{number}
-func drawingPointGettingHandler(w http.ResponseWriter, req *http.Request) {
+// drawingFixtureGettingHandler handles getting one fixture of a drawing by drawing ID and a number of the fixture.
+// The first fixture of the drawing has a number one.
+// Handles: GET /drawings/{id}/fixtures/{number}
+func drawingFixtureGettingHandler(w http.ResponseWriter, req *http.Request) {
 	drawing, _ := getDrawingByRequestOrWriteError(w, req)
 	if drawing == nil {
 		return
 	}
-	pointIndex, ok := getPointIndexByRequestOrWriteError(w, req, drawing)
+	fixtureIndex, ok := getFixtureIndexByRequestOrWriteError(w, req, drawing)
 	if !ok {
 		return
 	}
@@ -316,63 +1721,66 @@ func drawingPointGettingHandler(w http.ResponseWriter, req *http.Request) {
 	if err := readLengthMeasureAndPrecision(req.URL.Query(), &measure, &precision); writeError(w, err) {
 		return
 	}
-	point := drawing.Points[pointIndex]
-	marshalAndWrite(w, pointWithMeasure{
-		X:       value.ConvertFromOneRound(measure, point.X, precision),
-		Y:       value.ConvertFromOneRound(measure, point.Y, precision),
+	marshalAndWrite(w, fixtureWithMeasure{
+		Fixture: *getFixturesData(drawing.GetFixturesWithParams(measure, precision)[fixtureIndex])[0],
 		Measure: value.NameOfLengthMeasure(measure),
 	})
 }
 
-// drawingPointsListGettingHandler handles getting points of the drawing by its ID.
-// Handles: GET /drawings/{id}/points
-func drawingPointsListGettingHandler(w http.ResponseWriter, req *http.Request) {
+// drawingFixtureUpdatingHandler updates a fixture of the drawing by drawing ID, a number of the fixture and
+// fixtureWithMeasures body.
+// Handles: PUT /drawings/{id}/fixtures/{number}
+func drawingFixtureUpdatingHandler(w http.ResponseWriter, req *http.Request) {
 	drawing, _ := getDrawingByRequestOrWriteError(w, req)
 	if drawing == nil {
 		return
 	}
+	fixtureIndex, ok := getFixtureIndexByRequestOrWriteError(w, req, drawing)
+	if !ok {
+		return
+	}
 
-	precision, measure := 2, drawing.Measures.Length
-	if err := readLengthMeasureAndPrecision(req.URL.Query(), &measure, &precision); writeError(w, err) {
+	var reqData fixtureWithMeasures
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
 		return
 	}
-	respData := drawingPointsGettingResponseData{
-		DrawingBasic: drawing.DrawingBasic,
-		Points:       drawing.GetPointsWithParams(measure, precision),
-		Measure:      value.NameOfLengthMeasure(measure),
+
+	drawingMeasures := drawing.Measures
+	drawing.Measures = reqData.Measures.ToFigureMeasures(drawing.Measures)
+
+	if err := drawing.SetFixture(fixtureIndex, getFixturesFromRequestData(&reqData.Fixture)[0]); writeError(w, badRequestError(err)) {
+		return
 	}
 
-	marshalAndWrite(w, &respData)
+	drawing.Measures = drawingMeasures
+
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	if err := storage.UpdateDrawing(drawing); writeError(w, err) {
+		return
+	}
 }
 
-// drawingPointUpdatingHandler updates a point of the drawing by drawing ID, a number of the point and
-// pointCalculatingWithMeasures body.
-// Handles: PUT /drawings/{id}/points/{number}
-func drawingPointUpdatingHandler(w http.ResponseWriter, req *http.Request) {
+// drawingFixtureDeletingHandler handles deleting one fixture from the drawing by drawing ID and a number of the fixture.
+// The first fixture of the drawing has a number one.
+// Handles: DELETE /drawings/{id}/fixtures/{number}
+func drawingFixtureDeletingHandler(w http.ResponseWriter, req *http.Request) {
 	drawing, _ := getDrawingByRequestOrWriteError(w, req)
 	if drawing == nil {
 		return
 	}
-	pointIndex, ok := getPointIndexByRequestOrWriteError(w, req, drawing)
+	fixtureIndex, ok := getFixtureIndexByRequestOrWriteError(w, req, drawing)
 	if !ok {
 		return
 	}
 
-	var pointWithMeasure pointCalculatingWithMeasures
-	if err := unmarshalReaderContent(req.Body, &pointWithMeasure); writeError(w, err) {
-		return
-	}
-
-	drawingMeasures := drawing.Measures
-	drawing.Measures = pointWithMeasure.Measures.ToFigureMeasures(drawing.Measures)
-
-	point := getPointsFromRequestPoint(&pointWithMeasure.Point)[0]
-	if err := drawing.SetPoint(pointIndex, point); writeError(w, err) {
+	if err := drawing.RemoveFixture(fixtureIndex); writeError(w, err) {
 		return
 	}
 
-	drawing.Measures = drawingMeasures
-
 	var storage common.UserStorage
 	if storage = getUserStorageOrWriteError(w, req); storage == nil {
 		return
@@ -653,3 +2061,138 @@ func userUpdatingHandler(w http.ResponseWriter, req *http.Request) {
 	err := storage.UpdateUser(&user)
 	_ = writeError(w, err)
 }
+
+// priceListsGettingHandler handles getting all price lists of the current user.
+// Handles: GET /price-lists
+func priceListsGettingHandler(w http.ResponseWriter, req *http.Request) {
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	lists, err := storage.GetPriceListsOfUser(storage.GetCurrentUser().ID)
+	if writeError(w, err) {
+		return
+	}
+
+	marshalAndWrite(w, &priceListsResponseData{PriceLists: lists})
+}
+
+// priceListCreatingHandler handles creating of the price list of the current user by PriceList body.
+// Handles: POST /price-lists
+func priceListCreatingHandler(w http.ResponseWriter, req *http.Request) {
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	var list common.PriceList
+	if err := unmarshalReaderContent(req.Body, &list); writeError(w, err) {
+		return
+	}
+	if err := checkPriceList(&list); writeError(w, err) {
+		return
+	}
+	if err := storage.CreatePriceLists(storage.GetCurrentUser().ID, &list); writeError(w, err) {
+		return
+	}
+	w.Header().Add("Location", fmt.Sprintf("/price-lists/%d", list.ID))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// priceListGettingHandler handles getting of one price list by ID.
+// Handles: GET /price-lists/{id}
+func priceListGettingHandler(w http.ResponseWriter, req *http.Request) {
+	list, _ := getPriceListByRequestOrWriteError(w, req)
+	if list == nil {
+		return
+	}
+
+	marshalAndWrite(w, list)
+}
+
+// priceListUpdatingHandler handles updating of one price list by ID and PriceList body.
+// Handles: PUT /price-lists/{id}
+func priceListUpdatingHandler(w http.ResponseWriter, req *http.Request) {
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	listID := uint(0)
+	if err := parsePathValue(mux.Vars(req), pathVarPriceListID, &listID); writeError(w, err) {
+		return
+	}
+
+	var list common.PriceList
+	if err := unmarshalReaderContent(req.Body, &list); writeError(w, err) {
+		return
+	}
+	if err := checkPriceList(&list); writeError(w, err) {
+		return
+	}
+
+	list.ID = listID
+	err := storage.UpdatePriceList(&list)
+	_ = writeError(w, err)
+}
+
+// priceListDeletingHandler handles removing of one price list by ID.
+// Handles: DELETE /price-lists/{id}
+func priceListDeletingHandler(w http.ResponseWriter, req *http.Request) {
+	var storage common.UserStorage
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return
+	}
+
+	listID := uint(0)
+	if err := parsePathValue(mux.Vars(req), pathVarPriceListID, &listID); writeError(w, err) {
+		return
+	}
+
+	if err := storage.RemovePriceList(listID); writeError(w, err) {
+		return
+	}
+}
+
+// triangleCalculatingHandler handles solving of the triangle by known sides and angles in measures of the body,
+// it doesn't need any drawing, e.g. for a triangular niche measured on site.
+// Handles: POST /calculate/triangle
+func triangleCalculatingHandler(w http.ResponseWriter, req *http.Request) {
+	var reqData triangleRequestData
+	if err := unmarshalReaderContent(req.Body, &reqData); writeError(w, err) {
+		return
+	}
+
+	measures := reqData.Measures.ToFigureMeasures(nil)
+	known := &figure.TriangleSolution{Sides: reqData.Sides, Angles: reqData.Angles}
+	known.ConvertToOne(measures)
+	triangle, err := figure.SolveTriangle(known.Sides, known.Angles)
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+	solution, err := triangle.Solution()
+	if writeError(w, badRequestError(err)) {
+		return
+	}
+
+	precision := 2
+	solution.ConvertFromOne(measures)
+	for i := range solution.Sides {
+		solution.Sides[i] = value.Round(solution.Sides[i], precision)
+		solution.Angles[i] = value.Round(solution.Angles[i], precision)
+	}
+	solution.Area = value.Round(solution.Area, precision)
+	points := make([]*figure.Point, triangle.Len())
+	for i, p := range triangle.Points {
+		points[i] = &figure.Point{
+			X: value.ConvertFromOneRound(measures.Length, p.X, precision),
+			Y: value.ConvertFromOneRound(measures.Length, p.Y, precision),
+		}
+	}
+	marshalAndWrite(w, &triangleResponseData{
+		TriangleSolution: solution,
+		Points:           points,
+		Measures:         measures.ToFigureMeasuresNames(),
+	})
+}
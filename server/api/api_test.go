@@ -12,6 +12,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/maxsid/goCeilings/drawing/raster"
+	"github.com/maxsid/goCeilings/estimate"
 	"github.com/maxsid/goCeilings/figure"
 	"github.com/maxsid/goCeilings/server/common"
 )
@@ -43,9 +44,11 @@ type MockStorageT struct {
 	UntilNotAllowedOperation uint
 	autoincrementUserID      uint
 	autoincrementDrawingID   uint
+	autoincrementPriceListID uint
 	users                    []*common.UserConfident
 	drawings                 []*common.Drawing
 	permissions              []*common.DrawingPermission
+	priceLists               []*common.PriceList
 }
 
 func (td *MockStorageT) GetDrawingPermission(userID, drawingID uint) (*common.DrawingPermission, error) {
@@ -124,7 +127,7 @@ func (td *MockStorageT) RemoveDrawingPermission(userID, drawingID uint) error {
 }
 
 func newMockStorage() *MockStorageT {
-	storage := MockStorageT{autoincrementUserID: 4, autoincrementDrawingID: 10}
+	storage := MockStorageT{autoincrementUserID: 4, autoincrementDrawingID: 10, autoincrementPriceListID: 3}
 	storage.users = []*common.UserConfident{
 		{UserBasic: common.UserBasic{ID: 1, Login: "maxim", Role: common.RoleAdmin}, Password: "12345"},
 		{UserBasic: common.UserBasic{ID: 2, Login: "oleg", Role: common.RoleUser}, Password: "123456"},
@@ -177,6 +180,11 @@ func newMockStorage() *MockStorageT {
 		{User: &storage.users[2].UserBasic, Drawing: &storage.drawings[7].DrawingBasic, Get: true, Change: true, Delete: true},
 		{User: &storage.users[2].UserBasic, Drawing: &storage.drawings[8].DrawingBasic, Get: true, Change: true, Delete: true, Share: true},
 	}
+	storage.priceLists = []*common.PriceList{
+		{ID: 1, UserID: 1, Name: "Standard", PriceList: estimate.PriceList{Currency: "USD", Canvas: 10, Profile: 3,
+			InnerCorner: 2, OuterCorner: 4, Fixture: 15, PipeBypass: 7, Labour: 5}},
+		{ID: 2, UserID: 3, Name: "Cheap", PriceList: estimate.PriceList{Canvas: 6, Profile: 2, Labour: 3}},
+	}
 	return &storage
 }
 
@@ -302,6 +310,29 @@ func (td *MockStorageT) UpdateUser(user *common.UserConfident) error {
 	return ErrUserNotFound
 }
 
+func (td *MockStorageT) CreateDrawingsFrom(drawingID uint, drawings ...*common.Drawing) error {
+	if err := td.simulateError(); err != nil {
+		return err
+	}
+	permissions, err := td.GetDrawingsPermissionsOfDrawing(drawingID)
+	if err != nil {
+		return err
+	}
+	for _, d := range drawings {
+		d.ID = td.autoincrementDrawingID
+		for _, p := range permissions {
+			c := *p
+			c.Drawing = &d.DrawingBasic
+			if err := td.CreateDrawingPermission(&c); err != nil {
+				return err
+			}
+		}
+		td.autoincrementDrawingID++
+	}
+	td.drawings = append(td.drawings, drawings...)
+	return nil
+}
+
 func (td *MockStorageT) GetDrawing(id uint) (*common.Drawing, error) {
 	if err := td.simulateError(); err != nil {
 		return nil, err
@@ -424,6 +455,70 @@ func (td *MockStorageT) RemoveDrawingOfUser(userID, drawingID uint) error {
 	return td.RemoveDrawing(drawingID)
 }
 
+func (td *MockStorageT) CreatePriceLists(userID uint, lists ...*common.PriceList) error {
+	if err := td.simulateError(); err != nil {
+		return err
+	}
+	for _, l := range lists {
+		l.ID, l.UserID = td.autoincrementPriceListID, userID
+		td.autoincrementPriceListID++
+	}
+	td.priceLists = append(td.priceLists, lists...)
+	return nil
+}
+
+func (td *MockStorageT) GetPriceList(id uint) (*common.PriceList, error) {
+	if err := td.simulateError(); err != nil {
+		return nil, err
+	}
+	for _, l := range td.priceLists {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return nil, ErrPriceListNotFound
+}
+
+func (td *MockStorageT) GetPriceListsOfUser(userID uint) ([]*common.PriceList, error) {
+	if err := td.simulateError(); err != nil {
+		return nil, err
+	}
+	out := make([]*common.PriceList, 0)
+	for _, l := range td.priceLists {
+		if l.UserID == userID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (td *MockStorageT) UpdatePriceList(list *common.PriceList) error {
+	if err := td.simulateError(); err != nil {
+		return err
+	}
+	for i, l := range td.priceLists {
+		if l.ID == list.ID {
+			list.UserID = l.UserID
+			td.priceLists[i] = list
+			return nil
+		}
+	}
+	return ErrPriceListNotFound
+}
+
+func (td *MockStorageT) RemovePriceList(id uint) error {
+	if err := td.simulateError(); err != nil {
+		return err
+	}
+	for i, l := range td.priceLists {
+		if l.ID == id {
+			td.priceLists = append(td.priceLists[:i], td.priceLists[i+1:]...)
+			return nil
+		}
+	}
+	return ErrPriceListNotFound
+}
+
 // Test cases
 type TestCase struct {
 	name                      string
@@ -1103,6 +1198,81 @@ func Test_getDrawingCreatingHandler(t *testing.T) {
 			tokenUserID:         1,
 			wantResponseHeaders: map[string]string{"Location": "/drawings/10"},
 		},
+		{
+			name:   "OK with closure",
+			url:    "/drawings",
+			method: http.MethodPost,
+			requestBody: `{"name":"New Drawing","points":[{},{"distance":300,"direction":90},{"distance":400,"angle":90},` +
+				`{"distance":300.5,"angle":90},{"distance":400.4,"direction":180}],"closure":"least_squares"}`,
+			wantStatus:          http.StatusCreated,
+			tokenUserID:         1,
+			wantResponseHeaders: map[string]string{"Location": "/drawings/10"},
+		},
+		{
+			name:   "Bad closure method",
+			url:    "/drawings",
+			method: http.MethodPost,
+			requestBody: `{"name":"New Drawing","points":[{},{"distance":300,"direction":90},{"distance":400,"angle":90},` +
+				`{"distance":300.5,"angle":90},{"distance":400.4,"direction":180}],"closure":"bad"}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:   "Negative transverse ratio",
+			url:    "/drawings",
+			method: http.MethodPost,
+			requestBody: `{"name":"New Drawing","points":[{},{"distance":300,"direction":90},{"distance":400,"angle":90},` +
+				`{"distance":300.5,"angle":90},{"distance":400.4,"direction":180}],"closure":"least_squares",` +
+				`"transverse_ratio":-1}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Self-intersection",
+			url:         "/drawings",
+			method:      http.MethodPost,
+			requestBody: `{"name":"New Drawing","points":[{},{"x":0,"y":200},{"x":400,"y":0},{"x":400,"y":200},{"x":400,"y":200}]}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"error":"invalid polygon","problems":[{"kind":"zero_length_side","sides":["DE"],` +
+				`"points":["D","E"]},{"kind":"self_intersection","sides":["BC","EA"]}]}`,
+		},
+		{
+			name:                "Self-intersection with skipped validation",
+			url:                 "/drawings?skip_validation=true",
+			method:              http.MethodPost,
+			requestBody:         `{"name":"New Drawing","points":[{},{"x":0,"y":200},{"x":400,"y":0},{"x":400,"y":200}]}`,
+			wantStatus:          http.StatusCreated,
+			tokenUserID:         1,
+			wantResponseHeaders: map[string]string{"Location": "/drawings/10"},
+		},
+		{
+			name:   "OK with diagonals",
+			url:    "/drawings",
+			method: http.MethodPost,
+			requestBody: `{"name":"New Drawing","points":[{},{"distance":300,"direction":90},{"distance":400,"angle":95},` +
+				`{"distance":300,"angle":90}],"diagonals":[{"a":4,"b":1,"length":400},{"a":1,"b":3,"length":500}]}`,
+			wantStatus:          http.StatusCreated,
+			tokenUserID:         1,
+			wantResponseHeaders: map[string]string{"Location": "/drawings/10"},
+		},
+		{
+			name:   "Diagonal with wrong point",
+			url:    "/drawings",
+			method: http.MethodPost,
+			requestBody: `{"name":"New Drawing","points":[{},{"distance":300,"direction":90},{"distance":400,"angle":95},` +
+				`{"distance":300,"angle":90}],"diagonals":[{"a":0,"b":3,"length":500}]}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Closure without traverse",
+			url:         "/drawings",
+			method:      http.MethodPost,
+			requestBody: `{"name":"New Drawing","points":[{},{"x":0,"y":125}],"closure":"compass"}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
 		{
 			name:        "Bad request 1",
 			url:         "/drawings",
@@ -1165,7 +1335,14 @@ func Test_drawingGettingHandler(t *testing.T) {
 			wantStatus:  http.StatusOK,
 			tokenUserID: 1,
 			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","area":19.95,"perimeter":20.05,"points_count":8,` +
-				`"width":345,"height":599.99,` +
+				`"width":345,"height":599.99,"centroid":{"x":177.09,"y":297.36},` +
+				`"bounding_box":{"center":{"x":172.5,"y":300},"width":345,"height":599.99,"direction":0},` +
+				`"oriented_box":{"center":{"x":172.5,"y":300},"width":599.99,"height":345,"direction":90},` +
+				`"principal_axis":{"a":{"x":173.19,"y":-2.25},"b":{"x":181.06,"y":602.09},"direction":89.25,"length":604.4},` +
+				`"inner_corners":6,"outer_corners":2,` +
+				`"corners":[{"angle":90,"type":"inner"},{"angle":90,"type":"inner"},{"angle":270,"type":"outer"},` +
+				`{"angle":269.99,"type":"outer"},{"angle":90.01,"type":"inner"},{"angle":90.43,"type":"inner"},` +
+				`{"angle":89.81,"type":"inner"},{"angle":89.76,"type":"inner"}],` +
 				`"points":[{"x":0,"y":0},{"x":0,"y":155},{"x":72.5,"y":155},{"x":72.5,"y":167.5},` +
 				`{"x":12.5,"y":167.51},{"x":12.53,"y":597.51},{"x":342.52,"y":599.99},{"x":345,"y":0}],` +
 				`"measures":{"length":"cm","area":"m2","perimeter":"m","angle":"deg"}}`,
@@ -1177,7 +1354,13 @@ func Test_drawingGettingHandler(t *testing.T) {
 			wantStatus:  http.StatusOK,
 			tokenUserID: 2,
 			wantResponseBodyEquality: `{"id":1,"name":"Drawing 1","area":3.69,"perimeter":7.88,"points_count":6,` +
-				`"width":225,"height":171,` +
+				`"width":225,"height":171,"centroid":{"x":114.94,"y":82.97},` +
+				`"bounding_box":{"center":{"x":112.5,"y":85.5},"width":225,"height":171,"direction":0},` +
+				`"oriented_box":{"center":{"x":112.5,"y":85.5},"width":225,"height":171,"direction":0},` +
+				`"principal_axis":{"a":{"x":-7.77,"y":69.19},"b":{"x":230.32,"y":95.92},"direction":6.4,"length":239.59},` +
+				`"inner_corners":5,"outer_corners":1,` +
+				`"corners":[{"angle":90,"type":"inner"},{"angle":90,"type":"inner"},{"angle":269.99,"type":"outer"},` +
+				`{"angle":89.71,"type":"inner"},{"angle":91.31,"type":"inner"},{"angle":88.99,"type":"inner"}],` +
 				`"points":[{"x":0,"y":0},{"x":0,"y":125},{"x":27,"y":125},{"x":27.01,"y":171},{"x":222.01,"y":169.98},` +
 				`{"x":225,"y":0}],"measures":{"length":"cm","area":"m2","perimeter":"m","angle":"deg"}}`,
 		},
@@ -1221,6 +1404,58 @@ func Test_drawingGettingHandler(t *testing.T) {
 	}
 }
 
+func Test_drawingGettingHandler_Closure(t *testing.T) {
+	data := newMockStorage()
+	d, _ := data.GetDrawing(6)
+	err := d.AddPoints(
+		figure.NewCalculatedPoint(&figure.DirectionCalculator{Direction: 90, Distance: 300}),
+		figure.NewCalculatedPoint(&figure.AngleCalculator{Angle: 90, Distance: 400}),
+		figure.NewCalculatedPoint(&figure.AngleCalculator{Angle: 90, Distance: 300.5}),
+		figure.NewCalculatedPoint(&figure.DirectionCalculator{Direction: 180, Distance: 400.4}),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := d.CloseTraverse(figure.AdjustmentTransit, 0); err != nil {
+		t.Error(err)
+		return
+	}
+	checkTestCase(t, TestCase{
+		url:         "/drawings/6",
+		method:      http.MethodGet,
+		wantStatus:  http.StatusOK,
+		tokenUserID: 1,
+		wantResponseBodyByPattern: `"closure":\{"method":"transit","dx":-0.4,"dy":-0.5,"linear":0.64,"angular":-0.07,` +
+			`"length":1400.9,"ratio":2188\}`,
+	}, data)
+}
+
+func Test_drawingGettingHandler_Diagonals(t *testing.T) {
+	data := newMockStorage()
+	d, _ := data.GetDrawing(6)
+	err := d.AddPoints(
+		figure.NewCalculatedPoint(&figure.DirectionCalculator{Direction: 90, Distance: 300}),
+		figure.NewCalculatedPoint(&figure.AngleCalculator{Angle: 95, Distance: 400}),
+		figure.NewCalculatedPoint(&figure.AngleCalculator{Angle: 90, Distance: 300}),
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err := d.AddDiagonals(&figure.Measurement{A: 0, B: 2, Length: 500.1}); err != nil {
+		t.Error(err)
+		return
+	}
+	checkTestCase(t, TestCase{
+		url:                       "/drawings/6",
+		method:                    http.MethodGet,
+		wantStatus:                http.StatusOK,
+		tokenUserID:               1,
+		wantResponseBodyByPattern: `"diagonals":\[\{"a":1,"b":3,"length":500.1,"residual":-20.4\}\]`,
+	}, data)
+}
+
 // ====================
 // /drawings/{id}/image
 // ====================
@@ -1241,6 +1476,43 @@ func Test_drawingImageHandler(t *testing.T) {
 		},
 			DrawingID: 2,
 		},
+		{TestCase: TestCase{
+			name:                "OK with angles",
+			url:                 "/drawings/2/image?info=true&angles=true",
+			method:              http.MethodGet,
+			wantStatus:          http.StatusOK,
+			wantResponseHeaders: map[string]string{"Content-Type": "image/png"},
+			tokenUserID:         1,
+		},
+			DrawingID: 2,
+		},
+		{TestCase: TestCase{
+			name:                "OK with triangles",
+			url:                 "/drawings/2/image?triangles=true",
+			method:              http.MethodGet,
+			wantStatus:          http.StatusOK,
+			wantResponseHeaders: map[string]string{"Content-Type": "image/png"},
+			tokenUserID:         1,
+		},
+			DrawingID: 2,
+		},
+		{TestCase: TestCase{
+			name:                "OK with offset",
+			url:                 "/drawings/2/image?info=true&offset=-5&join=round",
+			method:              http.MethodGet,
+			wantStatus:          http.StatusOK,
+			wantResponseHeaders: map[string]string{"Content-Type": "image/png"},
+			tokenUserID:         1,
+		},
+			DrawingID: 2,
+		},
+		{TestCase: TestCase{
+			name:        "Too large offset",
+			url:         "/drawings/2/image?offset=-500",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		}},
 		{TestCase: TestCase{
 			name:            "UserConfident doesn't have access",
 			url:             "/drawings/1/image",
@@ -1272,125 +1544,286 @@ func Test_drawingImageHandler(t *testing.T) {
 	}
 }
 
-func Test_getDrawingDeletingHandler(t *testing.T) {
-	type DrawingTestCase struct {
-		TestCase
-		DrawingID uint
-	}
-	tests := []DrawingTestCase{
-		{TestCase: TestCase{
-			name:        "OK",
-			url:         "/drawings/6",
-			method:      http.MethodDelete,
-			wantStatus:  http.StatusOK,
-			tokenUserID: 1,
+func Test_drawingTriangulationHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:                     "OK",
+			url:                      "/drawings/3/triangulation",
+			method:                   http.MethodGet,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"id":3,"name":"Drawing 3","triangles":[{"vertices":["D","E","F"],"sides":[{"name":"DE","length":195},{"name":"EF","length":170.01},{"name":"FD","length":261.61}],"area":1.66},{"vertices":["F","A","B"],"sides":[{"name":"FA","length":225},{"name":"AB","length":125},{"name":"BF","length":257.39}],"area":1.41},{"vertices":["C","D","F"],"sides":[{"name":"CD","length":46},{"name":"DF","length":261.61},{"name":"FC","length":234.16}],"area":0.46},{"vertices":["B","C","F"],"sides":[{"name":"BC","length":27},{"name":"CF","length":234.16},{"name":"FB","length":257.39}],"area":0.17}],"area":3.69,"measures":{"length":"cm","area":"m2","perimeter":"m","angle":"deg"}}`,
 		},
-			DrawingID: 6,
+		{
+			name:        "Empty drawing",
+			url:         "/drawings/4/triangulation",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
 		},
-		{TestCase: TestCase{
-			name:            "UserConfident doesn't have access",
-			url:             "/drawings/1",
-			method:          http.MethodDelete,
-			simulateDBError: ErrorSimulation{Error: ErrOperationNotAllowed},
-			wantStatus:      http.StatusForbidden,
-			tokenUserID:     1,
-		}},
-		{TestCase: TestCase{
+		{
 			name:        "Not found",
-			url:         "/drawings/432",
-			method:      http.MethodDelete,
+			url:         "/drawings/432/triangulation",
+			method:      http.MethodGet,
 			wantStatus:  http.StatusNotFound,
 			tokenUserID: 1,
-		}},
-		{TestCase: TestCase{
-			name:       "Unauthorized",
-			url:        "/drawings/1",
-			method:     http.MethodDelete,
-			wantStatus: http.StatusUnauthorized,
-			inPanic:    true,
-		}},
-		{TestCase: TestCase{
-			name:            "DB Error",
-			url:             "/drawings/2",
-			method:          http.MethodDelete,
-			tokenUserID:     1,
-			wantStatus:      http.StatusInternalServerError,
-			simulateDBError: ErrorSimulation{Error: errTestDB},
-			inPanic:         true,
-		}},
+		},
 	}
+	storage := newMockStorage()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			data := newMockStorage()
-			checkTestCase(t, tt.TestCase, data)
-			if tt.DrawingID != 0 {
-				if _, err := data.GetDrawing(tt.DrawingID); !errors.Is(err, ErrDrawingNotFound) {
-					t.Errorf("Drawing getting by ID have to return ErrDrawingNotFound error, but got %v", err)
-				}
-			}
+			checkTestCase(t, tt, storage)
 		})
 	}
 }
 
-// =====================
-// /drawings/{id}/points
-// =====================
-
-func Test_drawingPointsGettingHandler(t *testing.T) {
+func Test_drawingQueryHandler(t *testing.T) {
 	tests := []TestCase{
 		{
-			name:        "OK 1",
-			url:         "/drawings/1/points",
-			method:      http.MethodGet,
-			wantStatus:  http.StatusOK,
-			tokenUserID: 2,
-			wantResponseBodyEquality: `{"id":1,"name":"Drawing 1","points":[{"x":0,"y":0},{"x":0,"y":125},{"x":27,"y":125},{"x":27.01,"y":171},` +
-				`{"x":222.01,"y":169.98},{"x":225,"y":0}],"measure":"cm"}`,
-		},
-		{
-			name:        "OK 2",
-			url:         "/drawings/2/points",
-			method:      http.MethodGet,
-			wantStatus:  http.StatusOK,
-			tokenUserID: 1,
-			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","points":[{"x":0,"y":0},{"x":0,"y":155},{"x":72.5,"y":155},` +
-				`{"x":72.5,"y":167.5},{"x":12.5,"y":167.51},{"x":12.53,"y":597.51},` +
-				`{"x":342.52,"y":599.99},{"x":345,"y":0}],"measure":"cm"}`,
+			name:                     "Inside",
+			url:                      "/drawings/3/query?x=100&y=50",
+			method:                   http.MethodGet,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"id":3,"name":"Drawing 3","point":{"x":100,"y":50},"location":"inside","vertex":{"name":"C","distance":104.66},"walls":[{"name":"FA","distance":50,"foot":{"x":100,"y":0}},{"name":"AB","distance":100,"foot":{"x":0,"y":50}},{"name":"BC","distance":104.66,"foot":{"x":27,"y":125}},{"name":"CD","distance":104.66,"foot":{"x":27,"y":125}},{"name":"DE","distance":120.62,"foot":{"x":100.63,"y":170.61}},{"name":"EF","distance":124.1,"foot":{"x":224.08,"y":52.18}}],"measure":"cm"}`,
 		},
 		{
-			name:        "OK with params m=m&p=4",
-			url:         "/drawings/2/points?m=m&p=4",
-			method:      http.MethodGet,
-			wantStatus:  http.StatusOK,
-			tokenUserID: 1,
-			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","points":[{"x":0,"y":0},{"x":0,"y":1.55},{"x":0.725,"y":1.55},` +
-				`{"x":0.725,"y":1.675},{"x":0.125,"y":1.6751},{"x":0.1253,"y":5.9751},{"x":3.4252,"y":5.9999},` +
-				`{"x":3.45,"y":0}],"measure":"m"}`,
+			name:                      "On the wall within the tolerance",
+			url:                       "/drawings/3/query?x=-1&y=50&tolerance=2",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"location":"wall"`,
 		},
 		{
-			name:        "Bad request param m=de",
-			url:         "/drawings/2/points?m=de",
+			name:        "Without y",
+			url:         "/drawings/3/query?x=100",
 			method:      http.MethodGet,
 			wantStatus:  http.StatusBadRequest,
 			tokenUserID: 1,
 		},
 		{
-			name:        "Bad request param m=deg",
-			url:         "/drawings/2/points?m=deg",
+			name:        "Empty drawing",
+			url:         "/drawings/4/query?x=100&y=50",
 			method:      http.MethodGet,
 			wantStatus:  http.StatusBadRequest,
 			tokenUserID: 1,
 		},
 		{
-			name:        "Bad request param p=dsa",
-			url:         "/drawings/2/points?p=dsa",
+			name:        "Not found",
+			url:         "/drawings/432/query?x=100&y=50",
 			method:      http.MethodGet,
-			wantStatus:  http.StatusBadRequest,
+			wantStatus:  http.StatusNotFound,
 			tokenUserID: 1,
 		},
-		{
-			name:        "Bad request param p=-2",
-			url:         "/drawings/2/points?p=-2",
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingOffsetHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:        "OK",
+			url:         "/drawings/3/offset?distance=-5",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":3,"name":"Drawing 3","offset":-5,"join":"mitre","points":[{"x":5,"y":5},` +
+				`{"x":5,"y":120},{"x":32,"y":120},{"x":32.01,"y":165.97},{"x":217.1,"y":165.01},{"x":219.91,"y":5}],` +
+				`"perimeter":7.48,"measures":{"length":"cm","area":"m2","perimeter":"m","angle":"deg"}}`,
+		},
+		{
+			name:        "Without distance",
+			url:         "/drawings/3/offset?join=round",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Unknown join",
+			url:         "/drawings/3/offset?distance=5&join=bevel",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Not found",
+			url:         "/drawings/432/offset?distance=5",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, newMockStorage())
+		})
+	}
+}
+
+func Test_drawingPatternHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:                "OK",
+			url:                 "/drawings/2/pattern?shrink_x=7&shrink_y=10&direction=90&info=true",
+			method:              http.MethodGet,
+			wantStatus:          http.StatusOK,
+			wantResponseHeaders: map[string]string{"Content-Type": "image/png"},
+			tokenUserID:         1,
+		},
+		{
+			name:        "Without shrinkage",
+			url:         "/drawings/2/pattern?shrink_x=7",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Wrong shrinkage",
+			url:         "/drawings/2/pattern?shrink_x=7&shrink_y=110",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Not found",
+			url:         "/drawings/432/pattern?shrink_x=7&shrink_y=10",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, newMockStorage())
+		})
+	}
+}
+
+func Test_getDrawingDeletingHandler(t *testing.T) {
+	type DrawingTestCase struct {
+		TestCase
+		DrawingID uint
+	}
+	tests := []DrawingTestCase{
+		{TestCase: TestCase{
+			name:        "OK",
+			url:         "/drawings/6",
+			method:      http.MethodDelete,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+			DrawingID: 6,
+		},
+		{TestCase: TestCase{
+			name:            "UserConfident doesn't have access",
+			url:             "/drawings/1",
+			method:          http.MethodDelete,
+			simulateDBError: ErrorSimulation{Error: ErrOperationNotAllowed},
+			wantStatus:      http.StatusForbidden,
+			tokenUserID:     1,
+		}},
+		{TestCase: TestCase{
+			name:        "Not found",
+			url:         "/drawings/432",
+			method:      http.MethodDelete,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		}},
+		{TestCase: TestCase{
+			name:       "Unauthorized",
+			url:        "/drawings/1",
+			method:     http.MethodDelete,
+			wantStatus: http.StatusUnauthorized,
+			inPanic:    true,
+		}},
+		{TestCase: TestCase{
+			name:            "DB Error",
+			url:             "/drawings/2",
+			method:          http.MethodDelete,
+			tokenUserID:     1,
+			wantStatus:      http.StatusInternalServerError,
+			simulateDBError: ErrorSimulation{Error: errTestDB},
+			inPanic:         true,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := newMockStorage()
+			checkTestCase(t, tt.TestCase, data)
+			if tt.DrawingID != 0 {
+				if _, err := data.GetDrawing(tt.DrawingID); !errors.Is(err, ErrDrawingNotFound) {
+					t.Errorf("Drawing getting by ID have to return ErrDrawingNotFound error, but got %v", err)
+				}
+			}
+		})
+	}
+}
+
+// =====================
+// /drawings/{id}/points
+// =====================
+
+func Test_drawingPointsGettingHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:        "OK 1",
+			url:         "/drawings/1/points",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 2,
+			wantResponseBodyEquality: `{"id":1,"name":"Drawing 1","points":[{"x":0,"y":0},{"x":0,"y":125},{"x":27,"y":125},{"x":27.01,"y":171},` +
+				`{"x":222.01,"y":169.98},{"x":225,"y":0}],"measure":"cm"}`,
+		},
+		{
+			name:        "OK 2",
+			url:         "/drawings/2/points",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","points":[{"x":0,"y":0},{"x":0,"y":155},{"x":72.5,"y":155},` +
+				`{"x":72.5,"y":167.5},{"x":12.5,"y":167.51},{"x":12.53,"y":597.51},` +
+				`{"x":342.52,"y":599.99},{"x":345,"y":0}],"measure":"cm"}`,
+		},
+		{
+			name:        "OK with params m=m&p=4",
+			url:         "/drawings/2/points?m=m&p=4",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","points":[{"x":0,"y":0},{"x":0,"y":1.55},{"x":0.725,"y":1.55},` +
+				`{"x":0.725,"y":1.675},{"x":0.125,"y":1.6751},{"x":0.1253,"y":5.9751},{"x":3.4252,"y":5.9999},` +
+				`{"x":3.45,"y":0}],"measure":"m"}`,
+		},
+		{
+			name:        "Bad request param m=de",
+			url:         "/drawings/2/points?m=de",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Bad request param m=deg",
+			url:         "/drawings/2/points?m=deg",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Bad request param p=dsa",
+			url:         "/drawings/2/points?p=dsa",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Bad request param p=-2",
+			url:         "/drawings/2/points?p=-2",
 			method:      http.MethodGet,
 			wantStatus:  http.StatusBadRequest,
 			tokenUserID: 1,
@@ -1459,6 +1892,64 @@ func Test_getDrawingPointsAddingHandler(t *testing.T) {
 			wantResponseBodyEquality: `{"id":6,"name":"Drawing 6","points":[{"x":0,"y":0},{"x":0,"y":125},{"x":27,"y":125},{"x":27.01,"y":171},` +
 				`{"x":222.01,"y":169.98},{"x":225,"y":0}],"measure":"cm"}`,
 		},
+		{
+			name:   "OK closure",
+			url:    "/drawings/6/points",
+			method: http.MethodPost,
+			requestBody: `{"points":[{"distance":300,"direction":90},{"distance":400,"angle":90},{"distance":300.5,"angle":90},` +
+				`{"distance":400.4,"direction":180}],"measures":{"length":"cm","angle":"deg"},"closure":"compass"}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":6,"name":"Drawing 6","points":[{"x":0,"y":0},{"x":0.09,"y":300.11},` +
+				`{"x":400.2,"y":300.25},{"x":400.29,"y":-0.14}],"measure":"cm"}`,
+		},
+		{
+			name:   "OK arc",
+			url:    "/drawings/6/points",
+			method: http.MethodPost,
+			requestBody: `{"points":[{"x":400,"y":0},{"x":400,"y":200},` +
+				`{"x":0,"y":200,"arc":{"radius":200,"right":true}}],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":6,"name":"Drawing 6","points":[{"x":0,"y":0},{"x":400,"y":0},` +
+				`{"x":400,"y":200},{"x":0,"y":200,"arc":{"radius":200,"right":true}}],"measure":"cm"}`,
+		},
+		{
+			name:                     "Self-intersection",
+			url:                      "/drawings/6/points",
+			method:                   http.MethodPost,
+			requestBody:              `{"points":[{"x":0,"y":200},{"x":400,"y":0},{"x":400,"y":200}],"measures":{"length":"cm"}}`,
+			wantStatus:               http.StatusBadRequest,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"error":"invalid polygon","problems":[{"kind":"self_intersection","sides":["BC","DA"]}]}`,
+		},
+		{
+			name:        "Too small radius of arc",
+			url:         "/drawings/6/points",
+			method:      http.MethodPost,
+			requestBody: `{"points":[{"x":400,"y":0},{"x":400,"y":200,"arc":{"radius":50}}]}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:   "OK trilateration",
+			url:    "/drawings/6/points",
+			method: http.MethodPost,
+			requestBody: `{"points":[{"x":0,"y":300},{"x":400,"y":300},` +
+				`{"a":1,"b":3,"distance_a":400,"distance_b":300,"right":true}],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":6,"name":"Drawing 6","points":[{"x":0,"y":0},{"x":0,"y":300},` +
+				`{"x":400,"y":300},{"x":400,"y":0}],"measure":"cm"}`,
+		},
+		{
+			name:        "Trilateration from the next point",
+			url:         "/drawings/6/points",
+			method:      http.MethodPost,
+			requestBody: `{"points":[{"a":1,"b":3,"distance_a":400,"distance_b":300},{"x":0,"y":300}]}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
 		{
 			name:   "UserConfident doesn't have access",
 			url:    "/drawings/1/points",
@@ -1572,6 +2063,13 @@ func Test_getDrawingPointDeletingHandler(t *testing.T) {
 			tokenUserID: 2,
 			wantStatus:  http.StatusOK,
 		}, DrawingID: 1},
+		{TestCase: TestCase{
+			name:        "Unknown mode",
+			url:         "/drawings/1/points/1?mode=move",
+			method:      http.MethodDelete,
+			tokenUserID: 2,
+			wantStatus:  http.StatusBadRequest,
+		}},
 		{TestCase: TestCase{
 			name:        "Too big point number",
 			url:         "/drawings/1/points/412",
@@ -1622,7 +2120,7 @@ func Test_getDrawingPointUpdatingHandler(t *testing.T) {
 	tests := []UpdatePointTestCase{
 		{TestCase: TestCase{
 			name:        "OK Empty coordinates",
-			url:         "/drawings/1/points/2",
+			url:         "/drawings/1/points/2?skip_validation=true",
 			method:      http.MethodPut,
 			wantStatus:  http.StatusOK,
 			requestBody: `{}`,
@@ -1636,7 +2134,7 @@ func Test_getDrawingPointUpdatingHandler(t *testing.T) {
 			}},
 		{TestCase: TestCase{
 			name:        "OK Coordinates",
-			url:         "/drawings/1/points/2",
+			url:         "/drawings/1/points/2?skip_validation=true",
 			method:      http.MethodPut,
 			wantStatus:  http.StatusOK,
 			requestBody: `{"point":{"x":1.32,"y":3.1},"measures":{"length":"m"}}`,
@@ -1712,37 +2210,199 @@ func Test_getDrawingPointUpdatingHandler(t *testing.T) {
 	}
 }
 
-func Test_userPermissionsGettingHandler(t *testing.T) {
+func Test_drawingPointsChangingWithHoles(t *testing.T) {
 	tests := []TestCase{
 		{
-			name:        "OK",
-			url:         "/users/1/permissions",
-			method:      http.MethodGet,
-			tokenUserID: 1,
+			name:        "Adding hole",
+			url:         "/drawings/2/holes",
+			method:      http.MethodPost,
+			requestBody: `{"holes":[{"center":{"x":300,"y":100},"radius":20}],"measures":{"length":"cm"}}`,
 			wantStatus:  http.StatusOK,
-			wantResponseBodyEquality: `[{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":2,"name":"Drawing 2"},"owner":true},` +
-				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":6,"name":"Drawing 6"},"owner":true},` +
-				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":8,"name":"Drawing 8"},"owner":true},` +
-				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":9,"name":"Drawing 9"},"owner":true},` +
-				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":3,"name":"Drawing 3"},"get":true},` +
-				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":4,"name":"Drawing 4"},"get":true,"change":true},` +
-				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":5,"name":"Drawing 5"},"get":true,"change":true,"delete":true},` +
-				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":7,"name":"Drawing 7"},"get":true,"change":true,"delete":true,"share":true}]`,
+			tokenUserID: 1,
 		},
 		{
-			name:                     "OK Another user",
-			url:                      "/users/2/permissions",
-			method:                   http.MethodGet,
-			tokenUserID:              1,
-			wantStatus:               http.StatusOK,
-			wantResponseBodyEquality: `[{"user":{"id":2,"login":"oleg","role":2},"drawing":{"id":1,"name":"Drawing 1"},"owner":true}]`,
+			name:        "Updating point leaving hole out",
+			url:         "/drawings/2/points/8",
+			method:      http.MethodPut,
+			requestBody: `{"point":{"x":250,"y":0},"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
 		},
 		{
-			name:            "Forbidden",
-			url:             "/users/1/permissions",
-			method:          http.MethodGet,
-			tokenUserID:     2,
-			simulateDBError: ErrorSimulation{Error: ErrOperationNotAllowed, RequestsUntilError: 0},
+			name:        "Adding point leaving hole out",
+			url:         "/drawings/2/points",
+			method:      http.MethodPost,
+			requestBody: `{"points":[{"x":300,"y":150}],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:                      "Getting points after wrong changes",
+			url:                       "/drawings/2/points",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `{"x":342.52,"y":599.99},{"x":345,"y":0}\],`,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingPointsInsertingAndOrderingHandlers(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:                     "Inserting OK",
+			url:                      "/drawings/2/points/8",
+			method:                   http.MethodPost,
+			requestBody:              `{"point":{"x":344,"y":300}}`,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","points":[{"x":0,"y":0},{"x":0,"y":155},{"x":72.5,"y":155},{"x":72.5,"y":167.5},{"x":12.5,"y":167.51},{"x":12.53,"y":597.51},{"x":342.52,"y":599.99},{"x":344,"y":300},{"x":345,"y":0}],"measure":"cm"}`,
+		},
+		{
+			name:                      "Inserting calculated point",
+			url:                       "/drawings/2/points/2",
+			method:                    http.MethodPost,
+			requestBody:               `{"point":{"distance":50,"direction":90}}`,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"points":\[{"x":0,"y":0},{"x":0,"y":50},{"x":0,"y":155},`,
+		},
+		{
+			name:                      "Inserting with recomputing",
+			url:                       "/drawings/2/points/2",
+			method:                    http.MethodPost,
+			requestBody:               `{"point":{"x":10,"y":20}}`,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"points":\[{"x":0,"y":0},{"x":10,"y":20},{"x":10,"y":70},{"x":0,"y":155},`,
+		},
+		{
+			name:        "Deleting with recomputing",
+			url:         "/drawings/2/points/2?mode=recompute",
+			method:      http.MethodDelete,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:                      "Getting points after deleting",
+			url:                       "/drawings/2/points",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"points":\[{"x":0,"y":0},{"x":0,"y":50},{"x":0,"y":155},`,
+		},
+		{
+			name:        "Inserting with unknown mode",
+			url:         "/drawings/2/points/2?mode=move",
+			method:      http.MethodPost,
+			requestBody: `{"point":{"x":10,"y":20}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Inserting before not found point",
+			url:         "/drawings/2/points/42",
+			method:      http.MethodPost,
+			requestBody: `{"point":{"x":10,"y":20}}`,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+		{
+			name:                      "Changing the first point",
+			url:                       "/drawings/2/points/order",
+			method:                    http.MethodPost,
+			requestBody:               `{"first":3}`,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"points":\[{"x":0,"y":155},{"x":72.5,"y":155},.*{"x":0,"y":0},{"x":0,"y":50}\]`,
+		},
+		{
+			name:                      "Reversing",
+			url:                       "/drawings/2/points/order",
+			method:                    http.MethodPost,
+			requestBody:               `{"reverse":true}`,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"points":\[{"x":0,"y":155},{"x":0,"y":50},{"x":0,"y":0},{"x":345,"y":0},`,
+		},
+		{
+			name:        "Ordering from not found point",
+			url:         "/drawings/2/points/order",
+			method:      http.MethodPost,
+			requestBody: `{"first":42}`,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Adding hole",
+			url:         "/drawings/2/holes",
+			method:      http.MethodPost,
+			requestBody: `{"holes":[{"center":{"x":300,"y":100},"radius":20}],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Inserting point leaving hole out",
+			url:         "/drawings/2/points/4?mode=keep",
+			method:      http.MethodPost,
+			requestBody: `{"point":{"x":300,"y":150}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:                      "Getting points after wrong inserting",
+			url:                       "/drawings/2/points",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"points":\[{"x":0,"y":155},{"x":0,"y":50},{"x":0,"y":0},{"x":345,"y":0},`,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_userPermissionsGettingHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:        "OK",
+			url:         "/users/1/permissions",
+			method:      http.MethodGet,
+			tokenUserID: 1,
+			wantStatus:  http.StatusOK,
+			wantResponseBodyEquality: `[{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":2,"name":"Drawing 2"},"owner":true},` +
+				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":6,"name":"Drawing 6"},"owner":true},` +
+				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":8,"name":"Drawing 8"},"owner":true},` +
+				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":9,"name":"Drawing 9"},"owner":true},` +
+				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":3,"name":"Drawing 3"},"get":true},` +
+				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":4,"name":"Drawing 4"},"get":true,"change":true},` +
+				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":5,"name":"Drawing 5"},"get":true,"change":true,"delete":true},` +
+				`{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":7,"name":"Drawing 7"},"get":true,"change":true,"delete":true,"share":true}]`,
+		},
+		{
+			name:                     "OK Another user",
+			url:                      "/users/2/permissions",
+			method:                   http.MethodGet,
+			tokenUserID:              1,
+			wantStatus:               http.StatusOK,
+			wantResponseBodyEquality: `[{"user":{"id":2,"login":"oleg","role":2},"drawing":{"id":1,"name":"Drawing 1"},"owner":true}]`,
+		},
+		{
+			name:            "Forbidden",
+			url:             "/users/1/permissions",
+			method:          http.MethodGet,
+			tokenUserID:     2,
+			simulateDBError: ErrorSimulation{Error: ErrOperationNotAllowed, RequestsUntilError: 0},
 			wantStatus:      http.StatusForbidden,
 		},
 		{
@@ -1931,3 +2591,1064 @@ func Test_permissionGetterAndDeletingHandler(t *testing.T) {
 		})
 	}
 }
+
+func Test_drawingSquaringHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:                "Dry run OK",
+			url:                 "/drawings/2/square?info=true&angles=true",
+			method:              http.MethodPost,
+			requestBody:         `{"squaring":{"step":90,"tolerance":1},"dry_run":true}`,
+			wantStatus:          http.StatusOK,
+			tokenUserID:         1,
+			wantResponseHeaders: map[string]string{"Content-Type": "image/png"},
+		},
+		{
+			name:                      "Getting drawing after dry run",
+			url:                       "/drawings/2",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `\{"angle":90.43,"type":"inner"\}`,
+		},
+		{
+			name:        "Squaring OK",
+			url:         "/drawings/2/square",
+			method:      http.MethodPost,
+			requestBody: `{"squaring":{"step":90,"tolerance":1},"measures":{"length":"mm"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyByPattern: `^\{"id":2,"name":"Drawing 2","snapped_sides":8,"shifts":\[([0-9.]+,){7}[0-9.]+\],` +
+				`"max_shift":[0-9.]+,"total_shift":[0-9.]+,"points":\[.+\],"measure":"mm"\}$`,
+		},
+		{
+			name:        "Getting squared drawing",
+			url:         "/drawings/2",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyByPattern: `"corners":\[\{"angle":90,"type":"inner"\},\{"angle":90,"type":"inner"\},` +
+				`\{"angle":270,"type":"outer"\},\{"angle":270,"type":"outer"\},\{"angle":90,"type":"inner"\},` +
+				`\{"angle":90,"type":"inner"\},\{"angle":90,"type":"inner"\},\{"angle":90,"type":"inner"\}\]`,
+		},
+		{
+			name:        "Wrong step",
+			url:         "/drawings/2/square",
+			method:      http.MethodPost,
+			requestBody: `{"squaring":{"step":30,"tolerance":1}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Not found",
+			url:         "/drawings/432/square",
+			method:      http.MethodPost,
+			requestBody: `{"squaring":{"step":90,"tolerance":1}}`,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingSimplifyingHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:                "Dry run OK",
+			url:                 "/drawings/2/simplify?info=true",
+			method:              http.MethodPost,
+			requestBody:         `{"simplification":{"tolerance":15},"dry_run":true}`,
+			wantStatus:          http.StatusOK,
+			tokenUserID:         1,
+			wantResponseHeaders: map[string]string{"Content-Type": "image/png"},
+		},
+		{
+			name:                      "Getting drawing after dry run",
+			url:                       "/drawings/2",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"points_count":8,`,
+		},
+		{
+			name:                     "Simplification OK",
+			url:                      "/drawings/2/simplify",
+			method:                   http.MethodPost,
+			requestBody:              `{"simplification":{"tolerance":15,"merge_distance":1}}`,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","merged":[],"removed":["D"],"max_deviation":12.24,"area_change":0.04,"points":[{"x":0,"y":0},{"x":0,"y":155},{"x":72.5,"y":155},{"x":12.5,"y":167.51},{"x":12.53,"y":597.51},{"x":342.52,"y":599.99},{"x":345,"y":0}],"measures":{"length":"cm","area":"m2","perimeter":"m","angle":"deg"}}`,
+		},
+		{
+			name:                      "Getting simplified drawing",
+			url:                       "/drawings/2",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"points_count":7,`,
+		},
+		{
+			name:        "Without tolerance",
+			url:         "/drawings/2/simplify",
+			method:      http.MethodPost,
+			requestBody: `{"simplification":{}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Not found",
+			url:         "/drawings/432/simplify",
+			method:      http.MethodPost,
+			requestBody: `{"simplification":{"tolerance":15}}`,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingTransformingHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:        "Translation OK",
+			url:         "/drawings/3/transform",
+			method:      http.MethodPost,
+			requestBody: `{"transform":{"dx":10,"dy":20},"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":3,"name":"Drawing 3","points":[{"x":10,"y":20},{"x":10,"y":145},{"x":37,"y":145},` +
+				`{"x":37.01,"y":191},{"x":232.01,"y":189.98},{"x":235,"y":20}],"measure":"cm"}`,
+		},
+		{
+			name:        "Mirror OK",
+			url:         "/drawings/3/transform",
+			method:      http.MethodPost,
+			requestBody: `{"transform":{"pivot_x":10,"mirror":true,"mirror_axis":90},"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":3,"name":"Drawing 3","points":[{"x":10,"y":20},{"x":10,"y":145},{"x":-17,"y":145},` +
+				`{"x":-17.01,"y":191},{"x":-212.01,"y":189.98},{"x":-215,"y":20}],"measure":"cm"}`,
+		},
+		{
+			name:                     "Getting transformed drawing",
+			url:                      "/drawings/3/points/6",
+			method:                   http.MethodGet,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"x":-215,"y":20,"measure":"cm"}`,
+		},
+		{
+			name:        "Negative scale",
+			url:         "/drawings/3/transform",
+			method:      http.MethodPost,
+			requestBody: `{"transform":{"scale":-2}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Not found",
+			url:         "/drawings/432/transform",
+			method:      http.MethodPost,
+			requestBody: `{"transform":{"dx":1}}`,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingsMergingHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:   "OK",
+			url:    "/drawings/merge",
+			method: http.MethodPost,
+			requestBody: `{"name":"Merged","drawings":[{"id":3},{"id":2,"transform":{"dx":220}}],` +
+				`"measures":{"length":"cm"}}`,
+			wantStatus:          http.StatusCreated,
+			tokenUserID:         3,
+			wantResponseHeaders: map[string]string{"Location": "/drawings/10"},
+		},
+		{
+			name:        "Getting merged drawing",
+			url:         "/drawings/10/points",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 3,
+			wantResponseBodyEquality: `{"id":10,"name":"Merged","points":[{"x":0,"y":0},{"x":0,"y":125},{"x":27,"y":125},` +
+				`{"x":27.01,"y":171},{"x":222.01,"y":169.98},{"x":222.27,"y":155},{"x":292.5,"y":155},` +
+				`{"x":292.5,"y":167.5},{"x":232.5,"y":167.51},{"x":232.53,"y":597.51},{"x":562.52,"y":599.99},` +
+				`{"x":565,"y":0}],"measure":"cm"}`,
+		},
+		{
+			name:   "Apart drawings",
+			url:    "/drawings/merge",
+			method: http.MethodPost,
+			requestBody: `{"name":"Merged","drawings":[{"id":3},{"id":2,"transform":{"dy":1000}}],` +
+				`"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 3,
+		},
+		{
+			name:        "One drawing",
+			url:         "/drawings/merge",
+			method:      http.MethodPost,
+			requestBody: `{"name":"Merged","drawings":[{"id":3}]}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 3,
+		},
+		{
+			name:        "Without name",
+			url:         "/drawings/merge",
+			method:      http.MethodPost,
+			requestBody: `{"drawings":[{"id":3},{"id":5}]}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 3,
+		},
+		{
+			name:        "Not found",
+			url:         "/drawings/merge",
+			method:      http.MethodPost,
+			requestBody: `{"name":"Merged","drawings":[{"id":3},{"id":432}]}`,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 3,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingSplittingHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:   "OK",
+			url:    "/drawings/3/split",
+			method: http.MethodPost,
+			requestBody: `{"line":{"a":{"x":100,"y":0},"b":{"x":100,"y":10}},"names":["Hall left"],` +
+				`"measures":{"length":"cm","area":"cm2"}}`,
+			wantStatus:  http.StatusCreated,
+			tokenUserID: 3,
+			wantResponseBodyEquality: `{"drawings":[{"id":10,"name":"Hall left","area":15843.84},` +
+				`{"id":11,"name":"Drawing 3 (2)","area":21032.31}],"measure":"cm2"}`,
+		},
+		{
+			name:        "Permissions of new drawing",
+			url:         "/drawings/11/permissions",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 3,
+			wantResponseBodyEquality: `[{"user":{"id":1,"login":"maxim","role":1},"drawing":{"id":11,"name":"Drawing 3 (2)"},` +
+				`"get":true},{"user":{"id":3,"login":"elena","role":2},"drawing":{"id":11,"name":"Drawing 3 (2)"},"owner":true}]`,
+		},
+		{
+			name:                      "By vertex",
+			url:                       "/drawings/3/split",
+			method:                    http.MethodPost,
+			requestBody:               `{"line":{"vertex":2,"direction":0}}`,
+			wantStatus:                http.StatusCreated,
+			tokenUserID:               3,
+			wantResponseBodyByPattern: `"name":"Drawing 3 \(1\)"`,
+		},
+		{
+			name:        "Line misses the drawing",
+			url:         "/drawings/3/split",
+			method:      http.MethodPost,
+			requestBody: `{"line":{"a":{"x":1000,"y":0},"b":{"x":1000,"y":10}},"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 3,
+		},
+		{
+			name:        "Without line",
+			url:         "/drawings/3/split",
+			method:      http.MethodPost,
+			requestBody: `{"names":["Hall"]}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 3,
+		},
+		{
+			name:        "Not found",
+			url:         "/drawings/432/split",
+			method:      http.MethodPost,
+			requestBody: `{"line":{"vertex":1,"direction":0}}`,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingHolesHandlers(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:   "Adding OK",
+			url:    "/drawings/2/holes",
+			method: http.MethodPost,
+			requestBody: `{"holes":[{"center":{"x":100,"y":300},"radius":20},` +
+				`{"points":[{"x":200,"y":100},{"x":250,"y":100},{"x":250,"y":150},{"x":200,"y":150}]}],` +
+				`"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","holes":[{"center":{"x":100,"y":300},"radius":20},` +
+				`{"points":[{"x":200,"y":100},{"x":250,"y":100},{"x":250,"y":150},{"x":200,"y":150}]}],"measure":"cm"}`,
+		},
+		{
+			name:        "Adding hole out of drawing",
+			url:         "/drawings/2/holes",
+			method:      http.MethodPost,
+			requestBody: `{"holes":[{"center":{"x":10,"y":10},"radius":20}],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Adding overlapping hole",
+			url:         "/drawings/2/holes",
+			method:      http.MethodPost,
+			requestBody: `{"holes":[{"center":{"x":110,"y":300},"radius":20}],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Adding without holes",
+			url:         "/drawings/2/holes",
+			method:      http.MethodPost,
+			requestBody: `{"holes":[],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Getting list OK",
+			url:         "/drawings/2/holes?m=m",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","holes":[{"center":{"x":1,"y":3},"radius":0.2},` +
+				`{"points":[{"x":2,"y":1},{"x":2.5,"y":1},{"x":2.5,"y":1.5},{"x":2,"y":1.5}]}],"measure":"m"}`,
+		},
+		{
+			name:                     "Getting one OK",
+			url:                      "/drawings/2/holes/1",
+			method:                   http.MethodGet,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"center":{"x":100,"y":300},"radius":20,"measure":"cm"}`,
+		},
+		{
+			name:        "Updating OK",
+			url:         "/drawings/2/holes/1",
+			method:      http.MethodPut,
+			requestBody: `{"hole":{"center":{"x":1,"y":3},"radius":0.3},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:                     "Getting updated",
+			url:                      "/drawings/2/holes/1",
+			method:                   http.MethodGet,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"center":{"x":100,"y":300},"radius":30,"measure":"cm"}`,
+		},
+		{
+			name:        "Updating with wrong radius",
+			url:         "/drawings/2/holes/1",
+			method:      http.MethodPut,
+			requestBody: `{"hole":{"center":{"x":1,"y":3},"radius":-1},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Deleting OK",
+			url:         "/drawings/2/holes/2",
+			method:      http.MethodDelete,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Getting deleted",
+			url:         "/drawings/2/holes/2",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Updating not found hole",
+			url:         "/drawings/2/holes/5",
+			method:      http.MethodPut,
+			requestBody: `{"hole":{"center":{"x":1,"y":3},"radius":0.3},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 3,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingLevelsHandlers(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:   "Adding OK",
+			url:    "/drawings/2/levels",
+			method: http.MethodPost,
+			requestBody: `{"levels":[{"name":"Island","drop":10,` +
+				`"points":[{"x":100,"y":100},{"x":100,"y":200},{"x":200,"y":200},{"x":200,"y":100}]}],` +
+				`"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyByPattern: `^\{"id":2,"name":"Drawing 2","levels":\[\{"name":"Island","drop":10,` +
+				`"points":\[\{"x":100,"y":100\},\{"x":100,"y":200\},\{"x":200,"y":200\},\{"x":200,"y":100\}\]\}\],` +
+				`"summary":\[\{"name":"main","drop":0,"area":[0-9.]+,"perimeter":[0-9.]+,"transition":0,"transition_area":0\},` +
+				`\{"name":"Island","parent":"main","drop":10,"area":1,"perimeter":4,"transition":4,"transition_area":0.4\}\],` +
+				`"measure":"cm"\}$`,
+		},
+		{
+			name:   "Adding crossing level",
+			url:    "/drawings/2/levels",
+			method: http.MethodPost,
+			requestBody: `{"levels":[{"name":"Cross","drop":5,` +
+				`"points":[{"x":150,"y":150},{"x":150,"y":250},{"x":250,"y":250},{"x":250,"y":150}]}],` +
+				`"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Adding without levels",
+			url:         "/drawings/2/levels",
+			method:      http.MethodPost,
+			requestBody: `{"levels":[],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Getting drawing with levels",
+			url:         "/drawings/2",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyByPattern: `"levels_summary":\[\{"name":"main".+\{"name":"Island","parent":"main",.+\}\].*` +
+				`"levels":\[\{"name":"Island","drop":10,`,
+		},
+		{
+			name:                     "Getting one OK",
+			url:                      "/drawings/2/levels/1?m=m",
+			method:                   http.MethodGet,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"name":"Island","drop":0.1,"points":[{"x":1,"y":1},{"x":1,"y":2},{"x":2,"y":2},{"x":2,"y":1}],"measure":"m"}`,
+		},
+		{
+			name:   "Updating OK",
+			url:    "/drawings/2/levels/1",
+			method: http.MethodPut,
+			requestBody: `{"level":{"name":"Island","drop":-0.2,` +
+				`"points":[{"x":1,"y":1},{"x":1,"y":2},{"x":2,"y":2},{"x":2,"y":1}]},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:                      "Getting list OK",
+			url:                       "/drawings/2/levels",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"levels":\[\{"name":"Island","drop":-20,.+"transition_area":0.8\}\],"measure":"cm"\}$`,
+		},
+		{
+			name:        "Updating with main name",
+			url:         "/drawings/2/levels/1",
+			method:      http.MethodPut,
+			requestBody: `{"level":{"name":"main","drop":1,"points":[{"x":1,"y":1},{"x":1,"y":2},{"x":2,"y":2}]},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Deleting OK",
+			url:         "/drawings/2/levels/1",
+			method:      http.MethodDelete,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Getting deleted",
+			url:         "/drawings/2/levels/1",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingFixturesHandlers(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:   "Adding OK",
+			url:    "/drawings/2/fixtures",
+			method: http.MethodPost,
+			requestBody: `{"fixtures":[{"type":"light","x":100,"y":300,"diameter":10},` +
+				`{"type":"pipe","offsets":[{"side":1,"distance":100},{"side":8,"distance":50}]}],` +
+				`"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","fixtures":[{"type":"light","x":100,"y":300,"diameter":10},` +
+				`{"type":"pipe","x":100,"y":50,"offsets":[{"side":1,"distance":100},{"side":8,"distance":50}]}],` +
+				`"counts":{"light":1,"pipe":1},"measure":"cm"}`,
+		},
+		{
+			name:        "Adding fixture out of drawing",
+			url:         "/drawings/2/fixtures",
+			method:      http.MethodPost,
+			requestBody: `{"fixtures":[{"type":"fan","x":-10,"y":10}],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Adding unknown type",
+			url:         "/drawings/2/fixtures",
+			method:      http.MethodPost,
+			requestBody: `{"fixtures":[{"type":"lamp","x":10,"y":10}],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Adding without fixtures",
+			url:         "/drawings/2/fixtures",
+			method:      http.MethodPost,
+			requestBody: `{"fixtures":[],"measures":{"length":"cm"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Getting list OK",
+			url:         "/drawings/2/fixtures?m=m",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","fixtures":[{"type":"light","x":1,"y":3,"diameter":0.1},` +
+				`{"type":"pipe","x":1,"y":0.5,"offsets":[{"side":1,"distance":1},{"side":8,"distance":0.5}]}],` +
+				`"counts":{"light":1,"pipe":1},"measure":"m"}`,
+		},
+		{
+			name:                     "Getting one OK",
+			url:                      "/drawings/2/fixtures/2",
+			method:                   http.MethodGet,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"type":"pipe","x":100,"y":50,"offsets":[{"side":1,"distance":100},{"side":8,"distance":50}],"measure":"cm"}`,
+		},
+		{
+			name:        "Updating OK",
+			url:         "/drawings/2/fixtures/1",
+			method:      http.MethodPut,
+			requestBody: `{"fixture":{"type":"chandelier","x":1.5,"y":3,"diameter":0.6},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:                     "Getting updated",
+			url:                      "/drawings/2/fixtures/1",
+			method:                   http.MethodGet,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"type":"chandelier","x":150,"y":300,"diameter":60,"measure":"cm"}`,
+		},
+		{
+			name:                      "Estimate counts fixtures",
+			url:                       "/drawings/2/estimate?price_list=1",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `^\{"id":2,.+"quantities":\{.+"fixtures":1,"pipe_bypasses":1\},.+$`,
+		},
+		{
+			name:        "Updating with wrong offsets",
+			url:         "/drawings/2/fixtures/1",
+			method:      http.MethodPut,
+			requestBody: `{"fixture":{"type":"light","offsets":[{"side":1,"distance":1}]},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Deleting OK",
+			url:         "/drawings/2/fixtures/2",
+			method:      http.MethodDelete,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Getting deleted",
+			url:         "/drawings/2/fixtures/2",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Updating not found fixture",
+			url:         "/drawings/2/fixtures/5",
+			method:      http.MethodPut,
+			requestBody: `{"fixture":{"type":"light","x":1,"y":3},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 3,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingLightingGridHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:        "Preview OK",
+			url:         "/drawings/2/fixtures/grid",
+			method:      http.MethodPost,
+			requestBody: `{"grid":{"count":6,"clearance":0.5,"diameter":0.1},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyByPattern: `^\{"id":2,"name":"Drawing 2","fixtures":\[(\{"type":"light","x":[0-9.]+,"y":[0-9.]+,"diameter":0.1\},?){6}\],` +
+				`"counts":\{"light":6\},"measure":"m"\}$`,
+		},
+		{
+			name:                     "Preview doesn't write",
+			url:                      "/drawings/2/fixtures",
+			method:                   http.MethodGet,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              1,
+			wantResponseBodyEquality: `{"id":2,"name":"Drawing 2","fixtures":[],"counts":{},"measure":"cm"}`,
+		},
+		{
+			name:                      "Writing OK",
+			url:                       "/drawings/2/fixtures/grid",
+			method:                    http.MethodPost,
+			requestBody:               `{"grid":{"type":"fan","spacing":150,"clearance":50},"measures":{"length":"cm"},"write":true}`,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `^\{"id":2,.+"counts":\{"fan":[0-9]+\},"measure":"cm"\}$`,
+		},
+		{
+			name:                      "Getting written",
+			url:                       "/drawings/2/fixtures",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `^\{"id":2,"name":"Drawing 2","fixtures":\[\{"type":"fan",.+\],"counts":\{"fan":[0-9]+\},"measure":"cm"\}$`,
+		},
+		{
+			name:        "Count and spacing",
+			url:         "/drawings/2/fixtures/grid",
+			method:      http.MethodPost,
+			requestBody: `{"grid":{"count":6,"spacing":1},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Too big clearance",
+			url:         "/drawings/2/fixtures/grid",
+			method:      http.MethodPost,
+			requestBody: `{"grid":{"count":6,"clearance":4},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingRollsHandlers(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:        "Updating OK",
+			url:         "/drawings/2/rolls",
+			method:      http.MethodPut,
+			requestBody: `{"rolls":{"widths":[3.2,5],"direction":90},"measures":{"length":"m","angle":"deg"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Getting drawing with layout",
+			url:         "/drawings/2",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyByPattern: `"layout":\{"direction":90,"strips":\[\{"roll_width":500,"width":345,.+\}\],` +
+				`"material":[0-9.]+,"waste":[0-9.]+,"seams":0\}.*"rolls":\{"widths":\[320,500\],"direction":90\}`,
+		},
+		{
+			name:        "Updating with wrong width",
+			url:         "/drawings/2/rolls",
+			method:      http.MethodPut,
+			requestBody: `{"rolls":{"widths":[0]}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Updating without rolls",
+			url:         "/drawings/2/rolls",
+			method:      http.MethodPut,
+			requestBody: `{"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Deleting OK",
+			url:         "/drawings/2/rolls",
+			method:      http.MethodDelete,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:                      "Getting drawing without layout",
+			url:                       "/drawings/2",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"corners":\[[^\]]*\],"points":\[[^\]]*\],"measures"`,
+		},
+		{
+			name:        "Updating drawing without points",
+			url:         "/drawings/4/rolls",
+			method:      http.MethodPut,
+			requestBody: `{"rolls":{"widths":[320]}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:                      "Getting drawing without points",
+			url:                       "/drawings/4",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"rolls":\{"widths":\[320\],"direction":0\}`,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingTilesHandlers(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:        "Updating OK",
+			url:         "/drawings/2/tiles",
+			method:      http.MethodPut,
+			requestBody: `{"tiles":{"width":0.6,"length":1.2},"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Getting drawing with tiles",
+			url:         "/drawings/2",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyByPattern: `"tile_layout":\{"full_tiles":[0-9]+,"cut_tiles":[0-9]+,"main_runners":[0-9.]+,` +
+				`"cross_tees":[0-9.]+,"cuts":\[\{"points":\[.+\],"area":[0-9.]+\}.*\]\}.*` +
+				`"tiles":\{"width":60,"length":120,"origin_x":0,"origin_y":0,"direction":0\}`,
+		},
+		{
+			name:        "Updating with wrong size",
+			url:         "/drawings/2/tiles",
+			method:      http.MethodPut,
+			requestBody: `{"tiles":{"width":0,"length":60}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Updating without tiles",
+			url:         "/drawings/2/tiles",
+			method:      http.MethodPut,
+			requestBody: `{"measures":{"length":"m"}}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Deleting OK",
+			url:         "/drawings/2/tiles",
+			method:      http.MethodDelete,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:                      "Getting drawing without tiles",
+			url:                       "/drawings/2",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"corners":\[[^\]]*\],"points":\[[^\]]*\],"measures"`,
+		},
+		{
+			name:        "Updating drawing without points",
+			url:         "/drawings/4/tiles",
+			method:      http.MethodPut,
+			requestBody: `{"tiles":{"width":60,"length":60}}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+		},
+		{
+			name:                      "Getting drawing without points",
+			url:                       "/drawings/4",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               1,
+			wantResponseBodyByPattern: `"tiles":\{"width":60,"length":60,`,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_priceListsHandlers(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:                     "Getting list OK",
+			url:                      "/price-lists",
+			method:                   http.MethodGet,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              3,
+			wantResponseBodyEquality: `{"price_lists":[{"id":2,"user_id":3,"name":"Cheap","currency":"","canvas":6,"profile":2,"inner_corner":0,"outer_corner":0,"fixture":0,"pipe_bypass":0,"labour":3}]}`,
+		},
+		{
+			name:                "Creating OK",
+			url:                 "/price-lists",
+			method:              http.MethodPost,
+			requestBody:         `{"name":"New","currency":"EUR","canvas":12,"profile":4}`,
+			wantStatus:          http.StatusCreated,
+			tokenUserID:         2,
+			wantResponseHeaders: map[string]string{"Location": "/price-lists/3"},
+		},
+		{
+			name:        "Creating without name",
+			url:         "/price-lists",
+			method:      http.MethodPost,
+			requestBody: `{"canvas":12}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 2,
+		},
+		{
+			name:        "Creating with negative price",
+			url:         "/price-lists",
+			method:      http.MethodPost,
+			requestBody: `{"name":"Negative","canvas":-12}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 2,
+		},
+		{
+			name:                      "Getting OK",
+			url:                       "/price-lists/3",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               2,
+			wantResponseBodyByPattern: `^\{"id":3,"user_id":2,"name":"New","currency":"EUR","canvas":12,"profile":4,`,
+		},
+		{
+			name:        "Updating OK",
+			url:         "/price-lists/3",
+			method:      http.MethodPut,
+			requestBody: `{"name":"Updated","canvas":11}`,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 2,
+		},
+		{
+			name:                      "Getting updated",
+			url:                       "/price-lists/3",
+			method:                    http.MethodGet,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               2,
+			wantResponseBodyByPattern: `^\{"id":3,"user_id":2,"name":"Updated","currency":"","canvas":11,"profile":0,`,
+		},
+		{
+			name:        "Updating not found",
+			url:         "/price-lists/92",
+			method:      http.MethodPut,
+			requestBody: `{"name":"Updated"}`,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 2,
+		},
+		{
+			name:        "Deleting OK",
+			url:         "/price-lists/3",
+			method:      http.MethodDelete,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 2,
+		},
+		{
+			name:        "Getting deleted",
+			url:         "/price-lists/3",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 2,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_drawingEstimateHandlers(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:        "OK",
+			url:         "/drawings/2/estimate?price_list=1&fixtures=4&pipes=1",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusOK,
+			tokenUserID: 1,
+			wantResponseBodyByPattern: `^\{"id":2,"name":"Drawing 2","price_list":\{"id":1,.+\},` +
+				`"quantities":\{"canvas":19.95,"area":19.95,"perimeter":20.05,"inner_corners":6,"outer_corners":2,"fixtures":4,"pipe_bypasses":1\},` +
+				`"items":\[\{"name":"Canvas","unit":"m²",.+\{"name":"Light fixtures","unit":"pcs","quantity":4,"price":15,"cost":60\},` +
+				`.+\],"total":[0-9.]+,"currency":"USD"\}$`,
+		},
+		{
+			name:        "Without price list",
+			url:         "/drawings/2/estimate",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Negative fixtures",
+			url:         "/drawings/2/estimate?price_list=1&fixtures=-1",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Price list not found",
+			url:         "/drawings/2/estimate?price_list=92",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusNotFound,
+			tokenUserID: 1,
+		},
+		{
+			name:        "Not enough points",
+			url:         "/drawings/6/estimate?price_list=1",
+			method:      http.MethodGet,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 1,
+		},
+		{
+			name:                "Image OK",
+			url:                 "/drawings/2/estimate/image?price_list=1&info=true",
+			method:              http.MethodGet,
+			wantStatus:          http.StatusOK,
+			tokenUserID:         1,
+			wantResponseHeaders: map[string]string{"Content-Type": "image/png"},
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
+
+func Test_triangleCalculatingHandler(t *testing.T) {
+	tests := []TestCase{
+		{
+			name:                     "SSS",
+			url:                      "/calculate/triangle",
+			method:                   http.MethodPost,
+			requestBody:              `{"sides": [300, 400, 500]}`,
+			wantStatus:               http.StatusOK,
+			tokenUserID:              2,
+			wantResponseBodyEquality: `{"sides":[300,400,500],"angles":[36.87,53.13,90],"area":6,"points":[{"x":0,"y":0},{"x":0,"y":500},{"x":240,"y":320}],"measures":{"length":"cm","area":"m2","perimeter":"m","angle":"deg"}}`,
+		},
+		{
+			name:                      "SAS in metres",
+			url:                       "/calculate/triangle",
+			method:                    http.MethodPost,
+			requestBody:               `{"sides": [3, 4, null], "angles": [null, null, 90], "measures": {"length": "m", "area": "m2"}}`,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               2,
+			wantResponseBodyByPattern: `"sides":\[3,4,5\],"angles":\[36.87,53.13,90\],"area":6,`,
+		},
+		{
+			name:                      "ASA in radians",
+			url:                       "/calculate/triangle",
+			method:                    http.MethodPost,
+			requestBody:               `{"sides": [0, 0, 200], "angles": [0.7853981633974483, 0.7853981633974483, 0], "measures": {"angle": "rad"}}`,
+			wantStatus:                http.StatusOK,
+			tokenUserID:               2,
+			wantResponseBodyByPattern: `"sides":\[141.42,141.42,200\],"angles":\[0.79,0.79,1.57\],"area":1,`,
+		},
+		{
+			name:        "Impossible triangle",
+			url:         "/calculate/triangle",
+			method:      http.MethodPost,
+			requestBody: `{"sides": [100, 200, 300]}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 2,
+		},
+		{
+			name:        "Not enough values",
+			url:         "/calculate/triangle",
+			method:      http.MethodPost,
+			requestBody: `{"sides": [100, 200]}`,
+			wantStatus:  http.StatusBadRequest,
+			tokenUserID: 2,
+		},
+		{
+			name:        "Unauthorized",
+			url:         "/calculate/triangle",
+			method:      http.MethodPost,
+			requestBody: `{"sides": [300, 400, 500]}`,
+			wantStatus:  http.StatusUnauthorized,
+		},
+	}
+	storage := newMockStorage()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkTestCase(t, tt, storage)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"github.com/maxsid/goCeilings/estimate"
 	"github.com/maxsid/goCeilings/figure"
 	"github.com/maxsid/goCeilings/server/common"
 	"github.com/maxsid/goCeilings/value"
@@ -25,32 +26,86 @@ type drawingsListResponseData struct {
 }
 
 type drawingCalculatedData struct {
-	Area        float64 `json:"area"`
-	Perimeter   float64 `json:"perimeter"`
-	PointsCount int     `json:"points_count"`
-	Width       float64 `json:"width"`
-	Height      float64 `json:"height"`
+	Area           float64                `json:"area"`
+	Perimeter      float64                `json:"perimeter"`
+	PointsCount    int                    `json:"points_count"`
+	Width          float64                `json:"width"`
+	Height         float64                `json:"height"`
+	Centroid       *figure.Point          `json:"centroid,omitempty"`
+	BoundingBox    *figure.Rectangle      `json:"bounding_box,omitempty"`
+	OrientedBox    *figure.Rectangle      `json:"oriented_box,omitempty"`
+	PrincipalAxis  *figure.Axis           `json:"principal_axis,omitempty"`
+	InnerCorners   int                    `json:"inner_corners"`
+	OuterCorners   int                    `json:"outer_corners"`
+	Corners        []*figure.Corner       `json:"corners,omitempty"`
+	HolesArea      float64                `json:"holes_area,omitempty"`
+	HolesPerimeter float64                `json:"holes_perimeter,omitempty"`
+	Closure        *figure.Closure        `json:"closure,omitempty"`
+	Diagonals      []*diagonalData        `json:"diagonals,omitempty"`
+	Layout         *layoutData            `json:"layout,omitempty"`
+	TileLayout     *figure.TileLayout     `json:"tile_layout,omitempty"`
+	Levels         []*figure.LevelSummary `json:"levels_summary,omitempty"`
+}
+
+// layoutData is the layout of strips with a number of seams.
+type layoutData struct {
+	*figure.Layout
+	Seams int `json:"seams"`
 }
 
 type drawingGetResponseData struct {
 	common.DrawingBasic
 	drawingCalculatedData
 	Points   []*figure.Point            `json:"points"`
+	Holes    []*figure.Hole             `json:"holes,omitempty"`
+	Rolls    *figure.Rolls              `json:"rolls,omitempty"`
+	Tiles    *figure.Tiles              `json:"tiles,omitempty"`
+	Levels   []*figure.Level            `json:"levels,omitempty"`
+	Fixtures []*figure.Fixture          `json:"fixtures,omitempty"`
 	Measures *value.FigureMeasuresNames `json:"measures"`
 }
 
 type drawingPostPutRequestData struct {
 	common.DrawingBasic
-	Points   []*pointCalculating       `json:"points"`
+	Points          []*pointCalculating       `json:"points"`
+	Measures        value.FigureMeasuresNames `json:"measures"`
+	Closure         figure.AdjustmentMethod   `json:"closure"`
+	TransverseRatio float64                   `json:"transverse_ratio"`
+	Diagonals       []*diagonalData           `json:"diagonals"`
+	Rolls           *figure.Rolls             `json:"rolls"`
+	Tiles           *figure.Tiles             `json:"tiles"`
+}
+
+type rollsWithMeasures struct {
+	Rolls    *figure.Rolls             `json:"rolls"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+}
+
+type tilesWithMeasures struct {
+	Tiles    *figure.Tiles             `json:"tiles"`
 	Measures value.FigureMeasuresNames `json:"measures"`
 }
 
+// diagonalData is a measured distance between two points of the drawing by their numbers (starting with one).
+type diagonalData struct {
+	A        uint     `json:"a"`
+	B        uint     `json:"b"`
+	Length   float64  `json:"length"`
+	Residual *float64 `json:"residual,omitempty"`
+}
+
 type pointCalculating struct {
-	X         float64  `json:"x"`
-	Y         float64  `json:"y"`
-	Distance  float64  `json:"distance"`
-	Direction *float64 `json:"direction"`
-	Angle     *float64 `json:"angle"`
+	X         float64     `json:"x"`
+	Y         float64     `json:"y"`
+	Distance  float64     `json:"distance"`
+	Direction *float64    `json:"direction"`
+	Angle     *float64    `json:"angle"`
+	A         *uint       `json:"a"`
+	B         *uint       `json:"b"`
+	DistanceA float64     `json:"distance_a"`
+	DistanceB float64     `json:"distance_b"`
+	Right     bool        `json:"right"`
+	Arc       *figure.Arc `json:"arc"`
 }
 
 type pointCalculatingWithMeasures struct {
@@ -70,9 +125,263 @@ type drawingPointsGettingResponseData struct {
 	Measure string          `json:"measure"`
 }
 
+// pointsOrderingRequestData is the number of the point, which becomes the first one (zero keeps the first point),
+// and the flag of reversing the direction of going around the drawing.
+type pointsOrderingRequestData struct {
+	First   uint `json:"first"`
+	Reverse bool `json:"reverse"`
+}
+
 type pointsCalculatingWithMeasures struct {
-	Points   []*pointCalculating       `json:"points"`
+	Points          []*pointCalculating       `json:"points"`
+	Measures        value.FigureMeasuresNames `json:"measures"`
+	Closure         figure.AdjustmentMethod   `json:"closure"`
+	TransverseRatio float64                   `json:"transverse_ratio"`
+}
+
+type problemData struct {
+	Kind    figure.ProblemKind `json:"kind"`
+	Sides   []string           `json:"sides,omitempty"`
+	Points  []string           `json:"points,omitempty"`
+	Warning bool               `json:"warning,omitempty"`
+}
+
+type validationErrorResponseData struct {
+	Error    string         `json:"error"`
+	Problems []*problemData `json:"problems"`
+}
+
+type drawingHolesGettingResponseData struct {
+	common.DrawingBasic
+	Holes   []*figure.Hole `json:"holes"`
+	Measure string         `json:"measure"`
+}
+
+type holesWithMeasures struct {
+	Holes    []*figure.Hole            `json:"holes"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+}
+
+type holeWithMeasures struct {
+	Hole     figure.Hole               `json:"hole"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+}
+
+type holeWithMeasure struct {
+	figure.Hole
+	Measure string `json:"measure"`
+}
+
+type drawingLevelsGettingResponseData struct {
+	common.DrawingBasic
+	Levels  []*figure.Level        `json:"levels"`
+	Summary []*figure.LevelSummary `json:"summary"`
+	Measure string                 `json:"measure"`
+}
+
+type levelsWithMeasures struct {
+	Levels   []*figure.Level           `json:"levels"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+}
+
+type levelWithMeasures struct {
+	Level    figure.Level              `json:"level"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+}
+
+type levelWithMeasure struct {
+	figure.Level
+	Measure string `json:"measure"`
+}
+
+type priceListsResponseData struct {
+	PriceLists []*common.PriceList `json:"price_lists"`
+}
+
+// estimateResponseData is the quote of the drawing with quantities in metres and square metres.
+type estimateResponseData struct {
+	common.DrawingBasic
+	PriceList  *common.PriceList `json:"price_list"`
+	Quantities *estimate.Input   `json:"quantities"`
+	*estimate.Quote
+}
+
+type drawingFixturesGettingResponseData struct {
+	common.DrawingBasic
+	Fixtures []*figure.Fixture          `json:"fixtures"`
+	Counts   map[figure.FixtureType]int `json:"counts"`
+	Measure  string                     `json:"measure"`
+}
+
+type fixturesWithMeasures struct {
+	Fixtures []*figure.Fixture         `json:"fixtures"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+}
+
+type fixtureWithMeasures struct {
+	Fixture  figure.Fixture            `json:"fixture"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+}
+
+type fixtureWithMeasure struct {
+	figure.Fixture
+	Measure string `json:"measure"`
+}
+
+// lightingGridRequestData is parameters of the lighting grid. Generated fixtures are written into the drawing if Write is true.
+type lightingGridRequestData struct {
+	Grid     figure.LightingGrid       `json:"grid"`
 	Measures value.FigureMeasuresNames `json:"measures"`
+	Write    bool                      `json:"write"`
+}
+
+// squaringRequestData is parameters of the squaring. The image of the squared drawing is returned without saving
+// if DryRun is true.
+type squaringRequestData struct {
+	Squaring figure.Squaring           `json:"squaring"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+	DryRun   bool                      `json:"dry_run"`
+}
+
+type squaringResponseData struct {
+	common.DrawingBasic
+	*figure.SquaringReport
+	Points  []*figure.Point `json:"points"`
+	Measure string          `json:"measure"`
+}
+
+// simplificationRequestData is parameters of the simplification. The image of the simplified drawing is returned
+// without saving if DryRun is true.
+type simplificationRequestData struct {
+	Simplification figure.Simplification     `json:"simplification"`
+	Measures       value.FigureMeasuresNames `json:"measures"`
+	DryRun         bool                      `json:"dry_run"`
+}
+
+// simplificationResponseData is names of merged and removed points before the simplification, the largest deviation
+// of removed points from the new outline in the length measure and the change of the area in the area measure.
+type simplificationResponseData struct {
+	common.DrawingBasic
+	Merged       []string                   `json:"merged"`
+	Removed      []string                   `json:"removed"`
+	MaxDeviation float64                    `json:"max_deviation"`
+	AreaChange   float64                    `json:"area_change"`
+	Points       []*figure.Point            `json:"points"`
+	Measures     *value.FigureMeasuresNames `json:"measures"`
+}
+
+// offsetGettingResponseData is the offset outline of the drawing with the perimeter in the perimeter measure.
+type offsetGettingResponseData struct {
+	common.DrawingBasic
+	Offset    float64                    `json:"offset"`
+	Join      figure.OffsetJoin          `json:"join"`
+	Points    []*figure.Point            `json:"points"`
+	Perimeter float64                    `json:"perimeter"`
+	Measures  *value.FigureMeasuresNames `json:"measures"`
+}
+
+// transformingRequestData is the transformation of the drawing with lengths and angles in Measures.
+type transformingRequestData struct {
+	Transform figure.Transform          `json:"transform"`
+	Measures  value.FigureMeasuresNames `json:"measures"`
+}
+
+// mergingRequestData is the name of the new drawing and drawings merged into it. Each drawing can be transformed
+// before merging with lengths and angles in Measures, e.g. to put rooms measured separately side by side.
+type mergingRequestData struct {
+	Name     string                    `json:"name"`
+	Drawings []*mergingDrawingData     `json:"drawings"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+}
+
+type mergingDrawingData struct {
+	ID        uint              `json:"id"`
+	Transform *figure.Transform `json:"transform,omitempty"`
+}
+
+// splittingRequestData is the split line and names of new drawings in the order of parts (look at
+// figure.Polygon.Split). Parts without names are named after the drawing with the number of the part.
+type splittingRequestData struct {
+	Line     splitLineData             `json:"line"`
+	Names    []string                  `json:"names"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+}
+
+// splitLineData is the line through points A and B, or through the vertex by its number (starting with one)
+// in the direction.
+type splitLineData struct {
+	A         *figure.Point `json:"a"`
+	B         *figure.Point `json:"b"`
+	Vertex    uint          `json:"vertex"`
+	Direction float64       `json:"direction"`
+}
+
+type splittingResponseData struct {
+	Drawings []*splitPartData `json:"drawings"`
+	Measure  string           `json:"measure"`
+}
+
+type splitPartData struct {
+	common.DrawingBasic
+	Area float64 `json:"area"`
+}
+
+// triangulationResponseData is triangles of the drawing and their total area, which is the area of the drawing
+// without segments of curved walls.
+type triangulationResponseData struct {
+	common.DrawingBasic
+	Triangles []*triangleData            `json:"triangles"`
+	Area      float64                    `json:"area"`
+	Measures  *value.FigureMeasuresNames `json:"measures"`
+}
+
+// triangleData is a triangle by names of its vertices with lengths of its sides and the area.
+type triangleData struct {
+	Vertices []string          `json:"vertices"`
+	Sides    []*sideLengthData `json:"sides"`
+	Area     float64           `json:"area"`
+}
+
+type sideLengthData struct {
+	Name   string  `json:"name"`
+	Length float64 `json:"length"`
+}
+
+// queryResponseData is the location of the point relatively to the drawing, the nearest vertex and distances to walls
+// sorted by the distance in the length measure.
+type queryResponseData struct {
+	common.DrawingBasic
+	Point    *figure.Point        `json:"point"`
+	Location figure.PointLocation `json:"location"`
+	Vertex   *vertexDistanceData  `json:"vertex"`
+	Walls    []*wallDistanceData  `json:"walls"`
+	Measure  string               `json:"measure"`
+}
+
+type vertexDistanceData struct {
+	Name     string  `json:"name"`
+	Distance float64 `json:"distance"`
+}
+
+// wallDistanceData is the distance to the wall by its name, Foot is the nearest point of the wall.
+type wallDistanceData struct {
+	Name     string        `json:"name"`
+	Distance float64       `json:"distance"`
+	Foot     *figure.Point `json:"foot"`
+}
+
+// triangleRequestData is known sides a, b, c and angles A, B, C of the triangle ABC in Measures, where the side
+// is opposite to the angle with the same index. Unknown values are null or zero.
+type triangleRequestData struct {
+	Sides    [3]float64                `json:"sides"`
+	Angles   [3]float64                `json:"angles"`
+	Measures value.FigureMeasuresNames `json:"measures"`
+}
+
+type triangleResponseData struct {
+	*figure.TriangleSolution
+	Points   []*figure.Point            `json:"points"`
+	Measures *value.FigureMeasuresNames `json:"measures"`
 }
 
 type drawingPermissionCreating struct {
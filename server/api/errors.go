@@ -17,6 +17,11 @@ var (
 	ErrUserNotFound    = fmt.Errorf("the user %w", ErrNotFound)
 	ErrDrawingNotFound = fmt.Errorf("the drawing %w", ErrNotFound)
 	ErrPointNotFound   = fmt.Errorf("the point %w", ErrNotFound)
+	ErrHoleNotFound    = fmt.Errorf("the hole %w", ErrNotFound)
+	ErrFixtureNotFound = fmt.Errorf("the fixture %w", ErrNotFound)
+	ErrLevelNotFound   = fmt.Errorf("the level %w", ErrNotFound)
+
+	ErrPriceListNotFound = fmt.Errorf("the price list %w", ErrNotFound)
 
 	ErrAlreadyExist       = errors.New("already exist")
 	ErrValueIsNotSettable = errors.New("the value is not settable")
@@ -29,6 +34,15 @@ var (
 	ErrOperationNotAllowed = fmt.Errorf("operation is not allowed")
 )
 
+// badRequestError wraps err into ErrBadRequestData, so writeError responds to it with Bad Request status.
+// Returns nil if err equals nil.
+func badRequestError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrBadRequestData, err)
+}
+
 // writeError writes error, if it's not equal nil, into http.ResponseWriter and log.Logger, and then returns true.
 // Returns false if err equals nil.
 func writeError(w http.ResponseWriter, err error) bool {
@@ -14,6 +14,8 @@ import (
 	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/maxsid/goCeilings/drawing/naming"
+	"github.com/maxsid/goCeilings/estimate"
 	"github.com/maxsid/goCeilings/figure"
 	"github.com/maxsid/goCeilings/server/common"
 	"github.com/maxsid/goCeilings/value"
@@ -69,11 +71,232 @@ func getPointIndexByRequestOrWriteError(w http.ResponseWriter, req *http.Request
 	return pointIndex - 1, true
 }
 
+// getPointsUpdateModeByRequestOrWriteError returns the mode of updating calculated points from mode URL parameter
+// or defaultMode, if the parameter isn't set. Returns false if an error has been written.
+func getPointsUpdateModeByRequestOrWriteError(w http.ResponseWriter, req *http.Request,
+	defaultMode figure.PointsUpdateMode) (figure.PointsUpdateMode, bool) {
+	mode := string(defaultMode)
+	if err := parseURLParamValue(req.URL.Query(), urlParamMode, &mode); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+		return "", false
+	}
+	return figure.PointsUpdateMode(mode), true
+}
+
+// validateDrawingOrWriteError checks the polygon of the drawing and writes its problems with Bad Request status
+// if the polygon is broken. The check is skipped if the request has skip_validation=true URL parameter.
+// Holes, levels and fixtures are always checked against the outline.
+// Returns false if the drawing is broken or an error has been written.
+func validateDrawingOrWriteError(w http.ResponseWriter, req *http.Request, drawing *common.Drawing) bool {
+	if err := drawing.CheckOutline(); writeError(w, badRequestError(err)) {
+		return false
+	}
+	skip := false
+	if err := parseURLParamValue(req.URL.Query(), urlParamSkipValidation, &skip); err != nil && !errors.Is(err, ErrNotFound) && writeError(w, err) {
+		return false
+	}
+	if skip {
+		return true
+	}
+	var validationErr *figure.ValidationError
+	if err := drawing.Validate(); !errors.As(err, &validationErr) {
+		return true
+	}
+	data, err := json.Marshal(validationErrorResponseData{
+		Error:    figure.ErrInvalidPolygon.Error(),
+		Problems: getProblemsData(drawing.Len(), validationErr.Problems...),
+	})
+	if writeError(w, err) {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = fmt.Fprintf(w, "%s", data)
+	return false
+}
+
+// pointsNames returns names of n points of the drawing.
+func pointsNames(n int) []string {
+	ni := naming.NewNameIterator('A', 'Z')
+	names := make([]string, n)
+	for i := range names {
+		names[i] = ni.Next()
+	}
+	return names
+}
+
+// getProblemsData converts problems of the polygon with n points into response data with names of points and sides.
+func getProblemsData(n int, problems ...*figure.Problem) []*problemData {
+	names := pointsNames(n)
+	out := make([]*problemData, len(problems))
+	for i, p := range problems {
+		pd := &problemData{Kind: p.Kind, Warning: p.Warning}
+		for _, s := range p.Sides {
+			pd.Sides = append(pd.Sides, names[s]+names[(s+1)%n])
+		}
+		for _, pi := range p.Points {
+			pd.Points = append(pd.Points, names[pi])
+		}
+		out[i] = pd
+	}
+	return out
+}
+
+// getTrianglesData converts triangles of the polygon with n points into response data with names of points and sides.
+func getTrianglesData(n int, triangles ...*figure.TriangleReport) []*triangleData {
+	names := pointsNames(n)
+	out := make([]*triangleData, len(triangles))
+	for i, t := range triangles {
+		td := &triangleData{Area: t.Area}
+		for k, v := range t.Vertices {
+			td.Vertices = append(td.Vertices, names[v])
+			td.Sides = append(td.Sides, &sideLengthData{Name: names[v] + names[t.Vertices[(k+1)%3]], Length: t.Sides[k]})
+		}
+		out[i] = td
+	}
+	return out
+}
+
+// getHoleIndexByRequestOrWriteError reads index of the hole from request path.
+// Second value of the returning tuple contains successfulness of the operation.
+func getHoleIndexByRequestOrWriteError(w http.ResponseWriter, req *http.Request, drawing *common.Drawing) (int, bool) {
+	holeIndex := 0
+	if err := parsePathValue(mux.Vars(req), pathVarHoleNumber, &holeIndex); writeError(w, err) {
+		return 0, false
+	}
+	if holeIndex > len(drawing.Holes) || holeIndex < 1 {
+		_ = writeError(w, ErrHoleNotFound)
+		return 0, false
+	}
+	return holeIndex - 1, true
+}
+
+// getLevelIndexByRequestOrWriteError reads index of the level from request path.
+// Second value of the returning tuple contains successfulness of the operation.
+func getLevelIndexByRequestOrWriteError(w http.ResponseWriter, req *http.Request, drawing *common.Drawing) (int, bool) {
+	levelIndex := 0
+	if err := parsePathValue(mux.Vars(req), pathVarLevelNumber, &levelIndex); writeError(w, err) {
+		return 0, false
+	}
+	if levelIndex > len(drawing.Levels) || levelIndex < 1 {
+		_ = writeError(w, ErrLevelNotFound)
+		return 0, false
+	}
+	return levelIndex - 1, true
+}
+
+// getFixtureIndexByRequestOrWriteError reads index of the fixture from request path.
+// Second value of the returning tuple contains successfulness of the operation.
+func getFixtureIndexByRequestOrWriteError(w http.ResponseWriter, req *http.Request, drawing *common.Drawing) (int, bool) {
+	fixtureIndex := 0
+	if err := parsePathValue(mux.Vars(req), pathVarFixtureNumber, &fixtureIndex); writeError(w, err) {
+		return 0, false
+	}
+	if fixtureIndex > len(drawing.Fixtures) || fixtureIndex < 1 {
+		_ = writeError(w, ErrFixtureNotFound)
+		return 0, false
+	}
+	return fixtureIndex - 1, true
+}
+
+// getFixturesFromRequestData converts numbers of sides in offsets of fixtures (starting with one) into indexes.
+func getFixturesFromRequestData(fixtures ...*figure.Fixture) []*figure.Fixture {
+	for _, f := range fixtures {
+		for _, o := range f.Offsets {
+			o.Side--
+		}
+	}
+	return fixtures
+}
+
+// getFixturesData converts indexes of sides in offsets of fixtures into numbers starting with one.
+func getFixturesData(fixtures ...*figure.Fixture) []*figure.Fixture {
+	for _, f := range fixtures {
+		for _, o := range f.Offsets {
+			o.Side++
+		}
+	}
+	return fixtures
+}
+
+// getPriceListByRequestOrWriteError returns the price list by ID from request path.
+// Second value of the returning tuple contains successfulness of the operation.
+func getPriceListByRequestOrWriteError(w http.ResponseWriter, req *http.Request) (*common.PriceList, bool) {
+	storage, listID := (common.UserStorage)(nil), uint(0)
+	if storage = getUserStorageOrWriteError(w, req); storage == nil {
+		return nil, false
+	}
+	if err := parsePathValue(mux.Vars(req), pathVarPriceListID, &listID); writeError(w, err) {
+		return nil, false
+	}
+
+	list, err := storage.GetPriceList(listID)
+	if writeError(w, err) {
+		return nil, false
+	}
+	return list, true
+}
+
+// getEstimateByRequestOrWriteError returns the drawing from request path, the price list and quantities
+// of the estimate, where numbers of fixtures can be replaced by URL parameters. Fourth value of the returning tuple contains successfulness of the operation.
+func getEstimateByRequestOrWriteError(w http.ResponseWriter, req *http.Request) (*common.Drawing, *common.PriceList, *estimate.Input, bool) {
+	drawing, _ := getDrawingByRequestOrWriteError(w, req)
+	if drawing == nil {
+		return nil, nil, nil, false
+	}
+	storage := getUserStorageOrWriteError(w, req)
+	if storage == nil {
+		return nil, nil, nil, false
+	}
+
+	vars, listID := req.URL.Query(), uint(0)
+	if err := parseURLParamValue(vars, urlParamPriceList, &listID); writeError(w, badRequestError(err)) {
+		return nil, nil, nil, false
+	}
+	in, err := drawing.EstimateInput()
+	if writeError(w, badRequestError(err)) {
+		return nil, nil, nil, false
+	}
+	// specified numbers replace the numbers of the drawing fixtures
+	for key, v := range map[urlParamKey]*int{urlParamFixtures: &in.Fixtures, urlParamPipeBypasses: &in.PipeBypasses} {
+		number := uint(0)
+		if err := parseURLParamValue(vars, key, &number); err == nil {
+			*v = int(number)
+		} else if !errors.Is(err, ErrNotFound) && writeError(w, badRequestError(err)) {
+			return nil, nil, nil, false
+		}
+	}
+
+	priceList, err := storage.GetPriceList(listID)
+	if writeError(w, err) {
+		return nil, nil, nil, false
+	}
+	return drawing, priceList, in, true
+}
+
+// checkPriceList returns ErrBadRequestData if the price list doesn't have a name or has negative prices.
+func checkPriceList(list *common.PriceList) error {
+	if list.Name == "" {
+		return fmt.Errorf("%w: the price list must have a name", ErrBadRequestData)
+	}
+	return badRequestError(list.Check())
+}
+
 // getPointsFromRequestPoint converts []*pointCalculating requests into []*figure.Point.
-func getPointsFromRequestPoint(points ...*pointCalculating) []*figure.Point {
+// first is an index of the first point in the drawing, it's needed for converting numbers of points.
+func getPointsFromRequestPoint(first int, points ...*pointCalculating) []*figure.Point {
 	resultPoints := make([]*figure.Point, len(points))
 	for i, p := range points {
 		switch {
+		case p.A != nil && p.B != nil && p.DistanceA != 0 && p.DistanceB != 0:
+			// numbers of points are converted into numbers counted back from the point
+			number := first + i + 1
+			resultPoints[i] = figure.NewCalculatedPoint(&figure.TrilaterationCalculator{
+				A:         number - int(*p.A),
+				B:         number - int(*p.B),
+				DistanceA: p.DistanceA,
+				DistanceB: p.DistanceB,
+				Right:     p.Right,
+			})
 		case p.Direction != nil && p.Distance != 0:
 			resultPoints[i] = figure.NewCalculatedPoint(&figure.DirectionCalculator{
 				Direction: *p.Direction,
@@ -87,10 +310,31 @@ func getPointsFromRequestPoint(points ...*pointCalculating) []*figure.Point {
 		default:
 			resultPoints[i] = figure.NewPoint(p.X, p.Y)
 		}
+		resultPoints[i].Arc = p.Arc
 	}
 	return resultPoints
 }
 
+// getDiagonalsFromRequestData converts []*diagonalData requests into []*figure.Measurement
+// with point numbers converted into indexes.
+func getDiagonalsFromRequestData(diagonals ...*diagonalData) []*figure.Measurement {
+	measurements := make([]*figure.Measurement, len(diagonals))
+	for i, d := range diagonals {
+		measurements[i] = &figure.Measurement{A: int(d.A) - 1, B: int(d.B) - 1, Length: d.Length}
+	}
+	return measurements
+}
+
+// getDiagonalsData converts residuals of the drawing diagonals into []*diagonalData with point numbers.
+func getDiagonalsData(residuals ...*figure.Residual) []*diagonalData {
+	diagonals := make([]*diagonalData, len(residuals))
+	for i, r := range residuals {
+		residual := r.Residual
+		diagonals[i] = &diagonalData{A: uint(r.A + 1), B: uint(r.B + 1), Length: r.Length, Residual: &residual}
+	}
+	return diagonals
+}
+
 // getSettable returns reflect.Value object of a settable parameter.
 func getSettable(v interface{}) (*reflect.Value, error) {
 	valueOfV := reflect.Indirect(reflect.ValueOf(v))
@@ -120,12 +364,18 @@ func getUserStorageOrWriteError(w http.ResponseWriter, req *http.Request) common
 
 // marshalAndWrite does marshal of v variable and writes result into http.ResponseWriter.
 func marshalAndWrite(w http.ResponseWriter, v interface{}) {
+	marshalAndWriteStatus(w, http.StatusOK, v)
+}
+
+// marshalAndWriteStatus marshals v into JSON and writes it with the status code.
+func marshalAndWriteStatus(w http.ResponseWriter, status int, v interface{}) {
 	data, err := json.Marshal(v)
 	if writeError(w, err) {
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
 	_, _ = fmt.Fprintf(w, "%s", data)
 }
 
@@ -291,3 +541,16 @@ func unmarshalReaderContent(body io.ReadCloser, v interface{}) error {
 	}
 	return nil
 }
+
+// getOffsetByURLParams returns the offset with the distance from the URL parameter key and the join from
+// the join parameter. Returns ErrNotFound if the distance parameter isn't set.
+func getOffsetByURLParams(vars url.Values, key urlParamKey) (*figure.Offset, error) {
+	distance, join := 0.0, ""
+	if err := parseURLParamValue(vars, key, &distance); err != nil {
+		return nil, err
+	}
+	if err := parseURLParamValue(vars, urlParamJoin, &join); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return &figure.Offset{Distance: distance, Join: figure.OffsetJoin(join)}, nil
+}
@@ -0,0 +1,11 @@
+package common
+
+import "github.com/maxsid/goCeilings/estimate"
+
+// PriceList contains prices of the user for estimates of drawings.
+type PriceList struct {
+	ID     uint   `json:"id"`
+	UserID uint   `json:"user_id"`
+	Name   string `json:"name"`
+	estimate.PriceList
+}
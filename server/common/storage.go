@@ -33,6 +33,9 @@ type UserManager interface {
 
 type DrawingCreator interface {
 	CreateDrawings(userID uint, drawings ...*Drawing) error
+	// CreateDrawingsFrom creates drawings with the same permissions as the drawing by drawingID has,
+	// e.g. parts of the split drawing.
+	CreateDrawingsFrom(drawingID uint, drawings ...*Drawing) error
 }
 
 type DrawingsListGetter interface {
@@ -85,12 +88,37 @@ type DrawingPermissionManager interface {
 	DrawingPermissionRemover
 }
 
+type PriceListCreator interface {
+	CreatePriceLists(userID uint, lists ...*PriceList) error
+}
+
+type PriceListGetter interface {
+	GetPriceList(id uint) (*PriceList, error)
+	GetPriceListsOfUser(userID uint) ([]*PriceList, error)
+}
+
+type PriceListUpdater interface {
+	UpdatePriceList(list *PriceList) error
+}
+
+type PriceListRemover interface {
+	RemovePriceList(id uint) error
+}
+
+type PriceListManager interface {
+	PriceListCreator
+	PriceListGetter
+	PriceListUpdater
+	PriceListRemover
+}
+
 // Storage executes all operations with database.
 type Storage interface {
 	GetUserStorage(user *UserBasic) (UserStorage, error)
 	UserManager
 	DrawingManager
 	DrawingPermissionManager
+	PriceListManager
 }
 
 // UserStorage executes operations with database allowed only for the user.
@@ -5,6 +5,7 @@ import (
 
 	"github.com/maxsid/goCeilings/drawing"
 	"github.com/maxsid/goCeilings/drawing/raster"
+	"github.com/maxsid/goCeilings/estimate"
 	"github.com/maxsid/goCeilings/server/common"
 	"gorm.io/gorm"
 )
@@ -35,6 +36,14 @@ type drawingPermissionModel struct {
 	DeletedAt                         gorm.DeletedAt `gorm:"index"`
 }
 
+type priceListModel struct {
+	gorm.Model
+	UserID uint       `gorm:"index"`
+	User   *userModel `gorm:"foreignKey:UserID"`
+	Name   string
+	Prices estimate.PriceList `gorm:"embedded"`
+}
+
 func (udp *drawingPermissionModel) ToAPI() *common.DrawingPermission {
 	pol := &common.DrawingPermission{
 		Get:    udp.Get,
@@ -104,3 +113,19 @@ func (u *userModel) FromAPI(au *common.UserConfident) {
 	u.Password = au.Password
 	u.ID = au.ID
 }
+
+func (pl *priceListModel) ToAPI() *common.PriceList {
+	return &common.PriceList{
+		ID:        pl.ID,
+		UserID:    pl.UserID,
+		Name:      pl.Name,
+		PriceList: pl.Prices,
+	}
+}
+
+func (pl *priceListModel) FromAPI(apl *common.PriceList) {
+	pl.ID = apl.ID
+	pl.UserID = apl.UserID
+	pl.Name = apl.Name
+	pl.Prices = apl.PriceList
+}
@@ -22,7 +22,7 @@ func NewDatabase(dialect gorm.Dialector) (st *Storage, err error) {
 	if err != nil {
 		return
 	}
-	if err = st.db.AutoMigrate(&drawingPermissionModel{}, &userModel{}, &drawingModel{}); err != nil {
+	if err = st.db.AutoMigrate(&drawingPermissionModel{}, &userModel{}, &drawingModel{}, &priceListModel{}); err != nil {
 		return
 	}
 	return
@@ -174,6 +174,35 @@ func (s *Storage) CreateDrawings(userID uint, drawings ...*common.Drawing) error
 	})
 }
 
+func (s *Storage) CreateDrawingsFrom(drawingID uint, drawings ...*common.Drawing) error {
+	rDrawings := make([]*drawingModel, len(drawings))
+	for i, d := range drawings {
+		rDrawings[i] = &drawingModel{}
+		rDrawings[i].FromAPI(d)
+	}
+	return s.db.Transaction(func(db *gorm.DB) error {
+		var permissions []*drawingPermissionModel
+		if err := db.Find(&permissions, "drawing_id = ?", drawingID).Error; err != nil {
+			return err
+		}
+		if len(permissions) == 0 {
+			return api.ErrDrawingNotFound
+		}
+		if err := db.Create(&rDrawings).Error; err != nil {
+			return err
+		}
+		rDrawingPermissions := make([]*drawingPermissionModel, 0, len(drawings)*len(permissions))
+		for i, d := range rDrawings {
+			drawings[i].ID = d.Model.ID
+			for _, p := range permissions {
+				rDrawingPermissions = append(rDrawingPermissions, &drawingPermissionModel{UserID: p.UserID, DrawingID: d.Model.ID,
+					Get: p.Get, Change: p.Change, Delete: p.Delete, Share: p.Share, Owner: p.Owner})
+			}
+		}
+		return db.Create(&rDrawingPermissions).Error
+	})
+}
+
 func (s *Storage) GetDrawing(id uint) (*common.Drawing, error) {
 	var drawing drawingModel
 	if err := s.db.First(&drawing, "id = ?", id).Error; err != nil {
@@ -351,3 +380,65 @@ func (s *Storage) RemoveDrawingPermission(userID, drawingID uint) error {
 	}
 	return nil
 }
+
+func (s *Storage) CreatePriceLists(userID uint, lists ...*common.PriceList) error {
+	records := make([]*priceListModel, len(lists))
+	for i, l := range lists {
+		records[i] = &priceListModel{}
+		records[i].FromAPI(l)
+		records[i].ID, records[i].UserID = 0, userID
+	}
+	if err := s.db.Create(&records).Error; err != nil {
+		return err
+	}
+	for i, r := range records {
+		lists[i].ID, lists[i].UserID = r.ID, r.UserID
+	}
+	return nil
+}
+
+func (s *Storage) GetPriceList(id uint) (*common.PriceList, error) {
+	var list priceListModel
+	if err := s.db.First(&list, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, api.ErrPriceListNotFound
+		}
+		return nil, err
+	}
+	return list.ToAPI(), nil
+}
+
+func (s *Storage) GetPriceListsOfUser(userID uint) ([]*common.PriceList, error) {
+	var lists []*priceListModel
+	if err := s.db.Order("id asc").Find(&lists, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	out := make([]*common.PriceList, len(lists))
+	for i, l := range lists {
+		out[i] = l.ToAPI()
+	}
+	return out, nil
+}
+
+func (s *Storage) UpdatePriceList(list *common.PriceList) error {
+	record := priceListModel{}
+	record.FromAPI(list)
+	// all prices are updated, including zero ones
+	tx := s.db.Model(&priceListModel{}).Select("*").Omit("id", "user_id", "created_at", "deleted_at").
+		Where("id = ?", list.ID).Updates(&record)
+	if tx.Error != nil {
+		return tx.Error
+	} else if tx.RowsAffected == 0 {
+		return api.ErrPriceListNotFound
+	}
+	return nil
+}
+
+func (s *Storage) RemovePriceList(id uint) error {
+	if tx := s.db.Delete(&priceListModel{}, "id = ?", id); tx.Error != nil {
+		return tx.Error
+	} else if tx.RowsAffected == 0 {
+		return api.ErrPriceListNotFound
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/go-test/deep"
 	"github.com/maxsid/goCeilings/drawing"
 	"github.com/maxsid/goCeilings/drawing/raster"
+	"github.com/maxsid/goCeilings/estimate"
 	"github.com/maxsid/goCeilings/figure"
 	"github.com/maxsid/goCeilings/server/api"
 	"github.com/maxsid/goCeilings/server/common"
@@ -79,6 +80,12 @@ var permissions = []*common.DrawingPermission{
 	{User: &users[3].UserBasic, Drawing: &drawings[2].DrawingBasic, Get: true},
 }
 
+var priceLists = map[int]*common.PriceList{
+	1: {ID: 1, UserID: 2, Name: "Standard", PriceList: estimate.PriceList{Currency: "USD", Canvas: 10, Profile: 3,
+		InnerCorner: 2, OuterCorner: 4, Fixture: 15, PipeBypass: 7, Labour: 5}},
+	2: {ID: 2, UserID: 3, Name: "Cheap", PriceList: estimate.PriceList{Canvas: 6, Profile: 2, Labour: 3}},
+}
+
 var (
 	previousStorageFile string
 	storage             *Storage
@@ -159,6 +166,15 @@ func createStorageData(storage *Storage) error {
 	if err := storage.db.Create(&storagePs).Error; err != nil {
 		return err
 	}
+
+	storageLists := make([]*priceListModel, len(priceLists))
+	for i, l := range priceLists {
+		storageLists[i-1] = &priceListModel{}
+		storageLists[i-1].FromAPI(l)
+	}
+	if err := storage.db.Create(&storageLists).Error; err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -913,6 +929,50 @@ func TestStorage_CreateDrawings(t *testing.T) {
 	}
 }
 
+func TestStorage_CreateDrawingsFrom(t *testing.T) {
+	createTempStorage()
+	defer deleteTempStorage()
+
+	tests := []struct {
+		name            string
+		drawingID       uint
+		wantPermissions int
+		wantErr         bool
+	}{
+		{
+			name:            "OK",
+			drawingID:       2,
+			wantPermissions: 2,
+		},
+		{
+			name:      "Not found drawing",
+			drawingID: 123,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := []*common.Drawing{randomDrawing(), randomDrawing()}
+			if err := storage.CreateDrawingsFrom(tt.drawingID, created...); (err != nil) != tt.wantErr {
+				t.Errorf("CreateDrawingsFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for _, d := range created {
+				got, err := storage.GetDrawingsPermissionsOfDrawing(d.ID)
+				if err != nil {
+					t.Errorf("GetDrawingsPermissionsOfDrawing() error: %v", err)
+					return
+				}
+				if len(got) != tt.wantPermissions {
+					t.Errorf("CreateDrawingsFrom() got %d permissions of drawing %d, want %d", len(got), d.ID, tt.wantPermissions)
+				}
+			}
+		})
+	}
+}
+
 func TestStorage_CreateDrawingPermission(t *testing.T) {
 	createTempStorage()
 	defer deleteTempStorage()
@@ -1236,3 +1296,106 @@ func TestStorage_CreateAdmin(t *testing.T) {
 		})
 	}
 }
+
+func TestStorage_CreatePriceLists(t *testing.T) {
+	createTempStorage()
+	defer deleteTempStorage()
+
+	list := &common.PriceList{Name: "New", PriceList: estimate.PriceList{Canvas: 12, Labour: 4}}
+	if err := storage.CreatePriceLists(3, list); err != nil {
+		t.Errorf("CreatePriceLists() error = %v", err)
+		return
+	}
+	if list.ID != 3 || list.UserID != 3 {
+		t.Errorf("CreatePriceLists() got ID %d and user ID %d, want 3 and 3", list.ID, list.UserID)
+	}
+	got, err := storage.GetPriceList(list.ID)
+	if err != nil {
+		t.Errorf("GetPriceList() error = %v", err)
+		return
+	}
+	if diff := deep.Equal(got, list); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestStorage_GetPriceListsOfUser(t *testing.T) {
+	createTempStorage()
+	defer deleteTempStorage()
+
+	tests := []struct {
+		name   string
+		userID uint
+		want   []*common.PriceList
+	}{
+		{name: "OK", userID: 2, want: []*common.PriceList{priceLists[1]}},
+		{name: "Empty", userID: 1, want: []*common.PriceList{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.GetPriceListsOfUser(tt.userID)
+			if err != nil {
+				t.Errorf("GetPriceListsOfUser() error = %v", err)
+				return
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestStorage_UpdatePriceList(t *testing.T) {
+	createTempStorage()
+	defer deleteTempStorage()
+
+	tests := []struct {
+		name    string
+		list    *common.PriceList
+		wantErr bool
+	}{
+		{
+			name: "OK with zero prices",
+			list: &common.PriceList{ID: 1, UserID: 2, Name: "Updated", PriceList: estimate.PriceList{Canvas: 11}},
+		},
+		{
+			name:    "Not found",
+			list:    &common.PriceList{ID: 92, Name: "Not Updated"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := storage.UpdatePriceList(tt.list); (err != nil) != tt.wantErr {
+				t.Errorf("UpdatePriceList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := storage.GetPriceList(tt.list.ID)
+			if err != nil {
+				t.Errorf("GetPriceList() error = %v", err)
+				return
+			}
+			if diff := deep.Equal(got, tt.list); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestStorage_RemovePriceList(t *testing.T) {
+	createTempStorage()
+	defer deleteTempStorage()
+
+	if err := storage.RemovePriceList(1); err != nil {
+		t.Errorf("RemovePriceList() error = %v", err)
+	}
+	if _, err := storage.GetPriceList(1); !errors.Is(err, api.ErrPriceListNotFound) {
+		t.Errorf("GetPriceList() error = %v, want %v", err, api.ErrPriceListNotFound)
+	}
+	if err := storage.RemovePriceList(92); !errors.Is(err, api.ErrPriceListNotFound) {
+		t.Errorf("RemovePriceList() error = %v, want %v", err, api.ErrPriceListNotFound)
+	}
+}
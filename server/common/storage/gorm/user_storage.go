@@ -102,6 +102,13 @@ func (u *UserStorage) CreateDrawings(userID uint, drawings ...*common.Drawing) e
 	return u.Storage.CreateDrawings(userID, drawings...)
 }
 
+func (u *UserStorage) CreateDrawingsFrom(drawingID uint, drawings ...*common.Drawing) error {
+	if err := u.checkPermission(drawingID, func(p *common.DrawingPermission) bool { return p.Change || p.Owner }); err != nil {
+		return err
+	}
+	return u.Storage.CreateDrawingsFrom(drawingID, drawings...)
+}
+
 func (u *UserStorage) GetDrawing(id uint) (*common.Drawing, error) {
 	if u.user.Role == common.RoleAdmin {
 		return u.Storage.GetDrawing(id)
@@ -199,3 +206,50 @@ func (u *UserStorage) RemoveDrawingPermission(userID, drawingID uint) error {
 	}
 	return u.Storage.RemoveDrawingPermission(userID, drawingID)
 }
+
+// checkPriceListOwner returns an error if the current user isn't the owner of the price list or an admin.
+func (u *UserStorage) checkPriceListOwner(id uint) error {
+	list, err := u.Storage.GetPriceList(id)
+	if err != nil {
+		return err
+	}
+	if u.user.Role != common.RoleAdmin && list.UserID != u.user.ID {
+		return api.ErrOperationNotAllowed
+	}
+	return nil
+}
+
+func (u *UserStorage) CreatePriceLists(userID uint, lists ...*common.PriceList) error {
+	if u.user.Role != common.RoleAdmin && u.user.ID != userID {
+		return api.ErrOperationNotAllowed
+	}
+	return u.Storage.CreatePriceLists(userID, lists...)
+}
+
+func (u *UserStorage) GetPriceList(id uint) (*common.PriceList, error) {
+	if err := u.checkPriceListOwner(id); err != nil {
+		return nil, err
+	}
+	return u.Storage.GetPriceList(id)
+}
+
+func (u *UserStorage) GetPriceListsOfUser(userID uint) ([]*common.PriceList, error) {
+	if u.user.Role == common.RoleAdmin || u.user.ID == userID {
+		return u.Storage.GetPriceListsOfUser(userID)
+	}
+	return nil, api.ErrOperationNotAllowed
+}
+
+func (u *UserStorage) UpdatePriceList(list *common.PriceList) error {
+	if err := u.checkPriceListOwner(list.ID); err != nil {
+		return err
+	}
+	return u.Storage.UpdatePriceList(list)
+}
+
+func (u *UserStorage) RemovePriceList(id uint) error {
+	if err := u.checkPriceListOwner(id); err != nil {
+		return err
+	}
+	return u.Storage.RemovePriceList(id)
+}
@@ -1,10 +1,12 @@
 package gorm
 
 import (
+	"errors"
 	"math/rand"
 	"testing"
 
 	"github.com/maxsid/goCeilings/drawing/raster"
+	"github.com/maxsid/goCeilings/server/api"
 	"github.com/maxsid/goCeilings/server/common"
 	"github.com/maxsid/goCeilings/server/common/storage/gorm/generator"
 )
@@ -466,6 +468,43 @@ func TestUserStorage_CreateDrawings(t *testing.T) {
 	}
 }
 
+func TestUserStorage_CreateDrawingsFrom(t *testing.T) {
+	createTempStorage()
+	defer deleteTempStorage()
+
+	tests := []struct {
+		name      string
+		user      *common.UserBasic
+		drawingID uint
+		wantErr   bool
+	}{
+		{
+			name:      "allowed for admins",
+			user:      &users[1].UserBasic,
+			drawingID: 2,
+		},
+		{
+			name:      "allowed for owners",
+			user:      &users[3].UserBasic,
+			drawingID: 3,
+		},
+		{
+			name:      "not allowed without changing permission",
+			user:      &users[3].UserBasic,
+			drawingID: 2,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &UserStorage{Storage: storage, user: tt.user}
+			if err := u.CreateDrawingsFrom(tt.drawingID, randomDrawing(), randomDrawing()); (err != nil) != tt.wantErr {
+				t.Errorf("CreateDrawingsFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func randomDrawing() *common.Drawing {
 	d := &common.Drawing{
 		DrawingBasic: common.DrawingBasic{Name: generator.GeneratePassword(15, 0, 0, 0)},
@@ -1263,3 +1302,38 @@ func TestUserStorage_RemoveDrawingPermission(t *testing.T) {
 		})
 	}
 }
+
+func TestUserStorage_PriceLists(t *testing.T) {
+	createTempStorage()
+	defer deleteTempStorage()
+
+	tests := []struct {
+		name    string
+		user    *common.UserBasic
+		id      uint
+		wantErr error
+	}{
+		{name: "allowed for admin", user: &users[1].UserBasic, id: 1},
+		{name: "allowed for owner", user: &users[2].UserBasic, id: 1},
+		{name: "not allowed", user: &users[3].UserBasic, id: 1, wantErr: api.ErrOperationNotAllowed},
+		{name: "not found", user: &users[3].UserBasic, id: 92, wantErr: api.ErrPriceListNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &UserStorage{Storage: storage, user: tt.user}
+			if _, err := u.GetPriceList(tt.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetPriceList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			list := &common.PriceList{ID: tt.id, Name: "Updated"}
+			if err := u.UpdatePriceList(list); !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdatePriceList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, err := u.GetPriceListsOfUser(2); tt.user.ID == 3 && !errors.Is(err, api.ErrOperationNotAllowed) {
+				t.Errorf("GetPriceListsOfUser() error = %v, want %v", err, api.ErrOperationNotAllowed)
+			}
+			if err := u.CreatePriceLists(2, &common.PriceList{Name: "New"}); tt.user.ID == 3 && !errors.Is(err, api.ErrOperationNotAllowed) {
+				t.Errorf("CreatePriceLists() error = %v, want %v", err, api.ErrOperationNotAllowed)
+			}
+		})
+	}
+}